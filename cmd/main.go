@@ -1,42 +1,59 @@
 package main
 
 import (
-	"github.com/gin-contrib/cors"
-	"github.com/gin-gonic/gin"
+	"context"
+	"google.golang.org/grpc"
 	"log"
-	"net/http"
+	"net"
 	"time"
 
+	grpc_basic_controller "task-management2/internal/controller/grpc/v1/_basic_controller"
+	grpc_projects_controller "task-management2/internal/controller/grpc/v1/projects"
+	grpc_tasks_controller "task-management2/internal/controller/grpc/v1/tasks"
+	grpc_users_controller "task-management2/internal/controller/grpc/v1/users"
+	calendar_controller "task-management2/internal/controller/http/v1/calendar"
+	comments_controller "task-management2/internal/controller/http/v1/comments"
 	export_controller "task-management2/internal/controller/http/v1/export"
+	graphql_controller "task-management2/internal/controller/http/v1/graphql"
+	notifications_controller "task-management2/internal/controller/http/v1/notifications"
 	projects_controller "task-management2/internal/controller/http/v1/projects"
+	recurrences_controller "task-management2/internal/controller/http/v1/recurrences"
+	sprints_controller "task-management2/internal/controller/http/v1/sprints"
+	stream_controller "task-management2/internal/controller/http/v1/stream"
 	tasks_controller "task-management2/internal/controller/http/v1/tasks"
+	trash_controller "task-management2/internal/controller/http/v1/trash"
 	users_controller "task-management2/internal/controller/http/v1/users"
+	webhooks_controller "task-management2/internal/controller/http/v1/webhooks"
+	worklogs_controller "task-management2/internal/controller/http/v1/worklogs"
 	"task-management2/internal/pkg/config"
+	"task-management2/internal/pkg/events"
+	"task-management2/internal/pkg/mailer"
+	"task-management2/internal/pkg/notification"
+	"task-management2/internal/pkg/recurrence"
 	"task-management2/internal/pkg/repository/postgres"
+	"task-management2/internal/pkg/stream"
+	"task-management2/internal/pkg/trash"
+	"task-management2/internal/pkg/validation"
+	"task-management2/internal/pkg/webhook"
+	"task-management2/internal/repository/postgres/calendar"
+	"task-management2/internal/repository/postgres/comments"
+	"task-management2/internal/repository/postgres/notifications"
+	"task-management2/internal/repository/postgres/outbox"
 	"task-management2/internal/repository/postgres/projects"
+	"task-management2/internal/repository/postgres/recurrences"
+	"task-management2/internal/repository/postgres/sprints"
 	"task-management2/internal/repository/postgres/tasks"
+	trash_repo "task-management2/internal/repository/postgres/trash"
 	"task-management2/internal/repository/postgres/users"
-	"task-management2/internal/router/export"
-	project_router "task-management2/internal/router/projects"
-	task_router "task-management2/internal/router/tasks"
-	user_router "task-management2/internal/router/users"
+	"task-management2/internal/repository/postgres/webhooks"
+	"task-management2/internal/repository/postgres/worklogs"
+	"task-management2/internal/router"
+	openapi_router "task-management2/internal/router/openapi"
+	"task-management2/internal/router/rpc"
 )
 
 func main() {
-	r := gin.Default()
-	r.MaxMultipartMemory = 16 << 20
-
-	r.Use(cors.New(cors.Config{
-		AllowOrigins:     []string{"*"},
-		AllowMethods:     []string{"*"},
-		AllowHeaders:     []string{"*"},
-		ExposeHeaders:    []string{"*"},
-		AllowCredentials: true,
-		AllowOriginFunc: func(origin string) bool {
-			return true
-		},
-		MaxAge: 12 * time.Hour,
-	}))
+	validation.Install()
 
 	postgresDB := postgres.NewPostgres()
 
@@ -44,44 +61,98 @@ func main() {
 	userRepo := users.NewRepository(postgresDB)
 	taskRepo := tasks.NewRepository(postgresDB)
 	projectRepo := projects.NewRepository(postgresDB)
+	calendarRepo := calendar.NewRepository(postgresDB)
+	worklogRepo := worklogs.NewRepository(postgresDB)
+	recurrenceRepo := recurrences.NewRepository(postgresDB)
+	notificationRepo := notifications.NewRepository(postgresDB)
+	commentRepo := comments.NewRepository(postgresDB)
+	webhookRepo := webhooks.NewRepository(postgresDB)
+	outboxRepo := outbox.NewRepository(postgresDB)
+	sprintRepo := sprints.NewRepository(postgresDB)
+	trashRepo := trash_repo.NewRepository(postgresDB)
+
+	// Domain events
+	bus := events.NewBus()
+	webhook.Subscribe(bus, webhookRepo)
+	hub := stream.NewHub(outboxRepo, time.Second)
+	watcher := stream.NewWatcher(hub, projectRepo)
+
+	// Mailer
+	conf := config.GetConf()
+	sender := mailer.New(conf.MailDriver, conf.MailDir, conf.SMTPHost, conf.SMTPPort, conf.SMTPUsername, conf.SMTPPassword)
+	retention := time.Duration(conf.TrashRetentionDays) * 24 * time.Hour
+	if retention <= 0 {
+		retention = trash.DefaultRetention
+	}
+
+	// Background jobs
+	recurrence.NewScheduler(recurrenceRepo, taskRepo, time.Minute).Start(context.Background())
+	notification.NewDueSoonJob(notificationRepo, 1, time.Hour).Start(context.Background())
+	notification.NewEmailJob(notificationRepo, userRepo, sender, conf.MailFrom, conf.AppURL, time.Minute).Start(context.Background())
+	events.NewDispatcher(outboxRepo, bus, time.Second).Start(context.Background())
+	webhook.NewDispatcher(webhookRepo, nil, 10*time.Second).Start(context.Background())
+	hub.Start(context.Background())
+	trash.NewPurger(trashRepo, retention, time.Hour).Start(context.Background())
 
 	// Controllers
 	userController := users_controller.NewController(userRepo)
 	taskController := tasks_controller.NewController(taskRepo)
 	projectsController := projects_controller.NewController(projectRepo)
-	exportController := export_controller.NewController(userRepo, taskRepo, projectRepo)
+	exportController := export_controller.NewController(userRepo, taskRepo, projectRepo, worklogRepo)
+	calendarController := calendar_controller.NewController(calendarRepo, projectRepo)
+	worklogsController := worklogs_controller.NewController(worklogRepo)
+	recurrencesController := recurrences_controller.NewController(recurrenceRepo)
+	notificationsController := notifications_controller.NewController(notificationRepo)
+	commentsController := comments_controller.NewController(commentRepo)
+	webhooksController := webhooks_controller.NewController(webhookRepo)
+	sprintsController := sprints_controller.NewController(sprintRepo)
+	trashController := trash_controller.NewController(trashRepo, retention)
+	streamController := stream_controller.NewController(watcher, calendarRepo)
+	graphqlController := graphql_controller.NewController(userRepo, projectRepo, taskRepo)
+
+	spec := openapi_router.Spec()
 
-	api := r.Group("api")
-	{
-		v1 := api.Group("v1")
+	r := router.New(spec, router.Controllers{
+		Users:         userController,
+		Tasks:         taskController,
+		Projects:      projectsController,
+		Export:        exportController,
+		Calendar:      calendarController,
+		Worklogs:      worklogsController,
+		Recurrences:   recurrencesController,
+		Notifications: notificationsController,
+		Comments:      commentsController,
+		Webhooks:      webhooksController,
+		Sprints:       sprintsController,
+		Stream:        streamController,
+		Trash:         trashController,
+		GraphQL:       graphqlController,
+	})
+
+	// the OpenAPI test catches routes without an entry, this is a reminder
+	if err := spec.Check(r.Routes()); err != nil {
+		log.Println(err)
+	}
 
-		v1.GET("/time", func(c *gin.Context) {
-			now := time.Now()
-			c.JSON(http.StatusOK, gin.H{
-				"message": "ok!",
-				"status":  true,
-				"data": map[string]interface{}{
-					"time":            now.Format("15:04"),
-					"time_in_seconds": now.Hour()*3600 + now.Minute()*60 + now.Second(),
-					"unix":            now.Unix(),
-					"date":            now.Format("02.01.2006"),
-					"week_day":        now.Weekday(),
-					"full_date":       now.Format("02.01.2006 15:04:06"),
-					"month":           now.Month(),
-					"day":             now.Day(),
-					"year":            now.Year(),
-					"hour":            now.Hour(),
-					"minute":          now.Minute(),
-					"second":          now.Second(),
-				},
-			})
-		})
+	// gRPC, on its own port, over the same repositories
+	if conf.GRPCPort != "" {
+		server := grpc.NewServer(
+			grpc.ChainUnaryInterceptor(grpc_basic_controller.Unary),
+			grpc.ChainStreamInterceptor(grpc_basic_controller.Stream),
+		)
+		rpc.Router(server,
+			grpc_users_controller.NewController(userRepo),
+			grpc_projects_controller.NewController(projectRepo),
+			grpc_tasks_controller.NewController(taskRepo, watcher, calendarRepo),
+		)
 
-		// Routers
-		user_router.Router(v1, userController)
-		task_router.Router(v1, taskController)
-		project_router.Router(v1, projectsController)
-		export.Router(v1, exportController)
+		lis, err := net.Listen("tcp", ":"+conf.GRPCPort)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		go func() {
+			log.Fatalln(server.Serve(lis))
+		}()
 	}
 
 	log.Fatalln(r.Run(":" + config.GetConf().Port))
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"task-management2/pkg/client"
+)
+
+// config is what login stores, in the user's config dir. TM_URL and
+// TM_TOKEN override it, for scripts.
+type config struct {
+	URL    string `json:"url"`
+	Token  string `json:"token,omitempty"`
+	UserId int    `json:"user_id"`
+}
+
+const defaultURL = "http://localhost:8080"
+
+func configPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "tm", "config.json"), nil
+}
+
+func loadConfig() (config, error) {
+	conf := config{URL: defaultURL}
+
+	path, err := configPath()
+	if err != nil {
+		return config{}, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return config{}, err
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, &conf); err != nil {
+			return config{}, errors.New("broken config " + path + ": " + err.Error())
+		}
+	}
+
+	if url := os.Getenv("TM_URL"); url != "" {
+		conf.URL = url
+	}
+	if token := os.Getenv("TM_TOKEN"); token != "" {
+		conf.Token = token
+	}
+
+	return conf, nil
+}
+
+// save writes the config readable by the user only, it holds the token.
+func (conf config) save() (string, error) {
+	path, err := configPath()
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", err
+	}
+
+	data, err := json.MarshalIndent(conf, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return path, os.WriteFile(path, append(data, '\n'), 0o600)
+}
+
+func (conf config) client() *client.Client {
+	var opts []client.Option
+	if conf.Token != "" {
+		opts = append(opts, client.WithHeader("Authorization", "Bearer "+conf.Token))
+	}
+
+	return client.New(conf.URL, opts...)
+}
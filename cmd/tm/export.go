@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+)
+
+func export(ctx context.Context, a *app, args []string) error {
+	var format, path string
+
+	_, err := flags("export", args, func(fs *flag.FlagSet) {
+		fs.StringVar(&format, "format", "xlsx", "file format, the API only exports xlsx")
+		fs.StringVar(&path, "file", "export.xlsx", "file to write, - for stdout")
+	})
+	if err != nil {
+		return err
+	}
+	if format != "xlsx" {
+		return usageError{"--format must be xlsx"}
+	}
+
+	if path == "-" {
+		return a.client.Export.Excel(ctx, os.Stdout)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := a.client.Export.Excel(ctx, f); err != nil {
+		f.Close()
+		os.Remove(path)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	a.printer.message("wrote %s", path)
+	return nil
+}
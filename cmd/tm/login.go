@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+)
+
+func login(ctx context.Context, a *app, args []string) error {
+	conf := a.config
+
+	_, err := flags("login", args, func(fs *flag.FlagSet) {
+		fs.StringVar(&conf.URL, "url", conf.URL, "base URL of the API")
+		fs.IntVar(&conf.UserId, "user", conf.UserId, "your user id, for --mine")
+		fs.StringVar(&conf.Token, "token", conf.Token, "API token")
+	})
+	if err != nil {
+		return err
+	}
+	if conf.UserId <= 0 {
+		return usageError{"login needs --user"}
+	}
+
+	// check the URL, token and user before storing them
+	user, err := conf.client().Users.Get(ctx, conf.UserId)
+	if err != nil {
+		return fmt.Errorf("logging in: %w", err)
+	}
+
+	path, err := conf.save()
+	if err != nil {
+		return err
+	}
+
+	a.printer.message("logged in to %s as %s, config saved to %s", conf.URL, str(user.FullName), path)
+	return nil
+}
+
+func logout(_ context.Context, a *app, args []string) error {
+	if _, err := flags("logout", args, func(*flag.FlagSet) {}); err != nil {
+		return err
+	}
+
+	conf := a.config
+	conf.Token = ""
+	conf.UserId = 0
+
+	if _, err := conf.save(); err != nil {
+		return err
+	}
+
+	a.printer.message("logged out of %s", conf.URL)
+	return nil
+}
@@ -0,0 +1,193 @@
+// Command tm manages tasks from the terminal through the API.
+//
+//	tm login --url http://localhost:8080 --user 3
+//	tm task list --mine --status in_progress
+//	tm task create --project 1 --name "Write docs" --due 2026-11-01
+//	tm task move 42 --status completed
+//	tm project stats 1
+//	tm export --format xlsx --file export.xlsx
+//
+// Every command prints a table, or JSON with --output json.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+
+	"task-management2/pkg/client"
+)
+
+type command struct {
+	usage string
+	run   func(ctx context.Context, app *app, args []string) error
+}
+
+var commands = map[string]map[string]command{
+	"login":  {"": {"login --url URL --user ID [--token TOKEN]", login}},
+	"logout": {"": {"logout", logout}},
+	"task": {
+		"list":   {"task list [--mine] [--status STATUS] [--project ID] [--sprint ID] [--limit N]", taskList},
+		"show":   {"task show ID", taskShow},
+		"create": {"task create --project ID --name NAME --due YYYY-MM-DD [--priority P] [--status S] [--assign ID] [--description D] [--points N] [--label L]...", taskCreate},
+		"move":   {"task move ID --status STATUS [--after ID] [--before ID]", taskMove},
+	},
+	"project": {
+		"list":  {"project list [--owner ID] [--limit N]", projectList},
+		"stats": {"project stats ID", projectStats},
+	},
+	"export": {"": {"export [--format xlsx] [--file PATH]", export}},
+}
+
+// app is what the commands share.
+type app struct {
+	config  config
+	client  *client.Client
+	printer printer
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "tm:", err)
+
+		var usageErr usageError
+		if errors.As(err, &usageErr) {
+			os.Exit(2)
+		}
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string) error {
+	global := flag.NewFlagSet("tm", flag.ContinueOnError)
+	output := global.String("output", "table", "output format, table or json")
+	global.Usage = usage
+	if err := global.Parse(args); err != nil {
+		return usageError{err.Error()}
+	}
+
+	args = global.Args()
+	if len(args) == 0 {
+		usage()
+		return usageError{"no command"}
+	}
+
+	group, ok := commands[args[0]]
+	if !ok {
+		return usageError{fmt.Sprintf("unknown command %q", args[0])}
+	}
+	args = args[1:]
+
+	cmd, ok := group[""]
+	if !ok {
+		if len(args) == 0 {
+			return usageError{fmt.Sprintf("%s needs a subcommand", global.Arg(0))}
+		}
+		if cmd, ok = group[args[0]]; !ok {
+			return usageError{fmt.Sprintf("unknown command %q", global.Arg(0)+" "+args[0])}
+		}
+		args = args[1:]
+	}
+
+	args, err := globalFlags(global, args)
+	if err != nil {
+		return err
+	}
+
+	p, err := newPrinter(*output)
+	if err != nil {
+		return usageError{err.Error()}
+	}
+
+	conf, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	a := &app{config: conf, client: conf.client(), printer: p}
+
+	return cmd.run(ctx, a, args)
+}
+
+// usageError is an error of the command line rather than of the API.
+type usageError struct {
+	message string
+}
+
+func (e usageError) Error() string {
+	return e.message
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: tm [--output table|json] COMMAND")
+	fmt.Fprintln(os.Stderr)
+
+	var lines []string
+	for _, group := range commands {
+		for _, cmd := range group {
+			lines = append(lines, "  tm "+cmd.usage)
+		}
+	}
+	sort.Strings(lines)
+	fmt.Fprintln(os.Stderr, strings.Join(lines, "\n"))
+}
+
+// globalFlags takes the global flags out of the arguments of a command and
+// sets them, so they may follow the command as well as precede it:
+// tm task list --output json.
+func globalFlags(global *flag.FlagSet, args []string) ([]string, error) {
+	var rest []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return append(rest, args[i:]...), nil
+		}
+
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || global.Lookup(name) == nil {
+			rest = append(rest, arg)
+			continue
+		}
+		if !hasValue {
+			if i+1 == len(args) {
+				return nil, usageError{"flag needs an argument: " + arg}
+			}
+			i++
+			value = args[i]
+		}
+
+		if err := global.Set(name, value); err != nil {
+			return nil, usageError{fmt.Sprintf("invalid value %q for flag %s: %v", value, arg, err)}
+		}
+	}
+
+	return rest, nil
+}
+
+// flags parses the flags of a command, positional arguments may come
+// before or after them.
+func flags(name string, args []string, define func(fs *flag.FlagSet)) ([]string, error) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	define(fs)
+
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, usageError{err.Error()}
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
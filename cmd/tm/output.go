@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// printer writes the result of a command. Tables are made of the given
+// rows, JSON of the value as the API returned it.
+type printer interface {
+	print(value interface{}, header []string, rows [][]string) error
+	message(format string, args ...interface{})
+}
+
+func newPrinter(format string) (printer, error) {
+	switch format {
+	case "table":
+		return tablePrinter{}, nil
+	case "json":
+		return jsonPrinter{}, nil
+	}
+
+	return nil, fmt.Errorf("unknown output %q, want table or json", format)
+}
+
+type tablePrinter struct{}
+
+func (tablePrinter) print(_ interface{}, header []string, rows [][]string) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+
+	return w.Flush()
+}
+
+func (tablePrinter) message(format string, args ...interface{}) {
+	fmt.Printf(format+"\n", args...)
+}
+
+type jsonPrinter struct{}
+
+func (jsonPrinter) print(value interface{}, _ []string, _ [][]string) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(value)
+}
+
+// message goes to stderr, so stdout stays valid JSON.
+func (jsonPrinter) message(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+}
+
+func str(s *string) string {
+	if s == nil {
+		return "-"
+	}
+
+	return *s
+}
+
+func num(n *int) string {
+	if n == nil {
+		return "-"
+	}
+
+	return strconv.Itoa(*n)
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+
+	"task-management2/pkg/client"
+)
+
+func projectList(ctx context.Context, a *app, args []string) error {
+	var ownerId, limit int
+
+	_, err := flags("project list", args, func(fs *flag.FlagSet) {
+		fs.IntVar(&ownerId, "owner", 0, "only projects of this owner")
+		fs.IntVar(&limit, "limit", 50, "show at most this many projects, 0 for all")
+	})
+	if err != nil {
+		return err
+	}
+
+	var opts client.ProjectListOptions
+	if ownerId > 0 {
+		opts.OwnerId = &ownerId
+	}
+
+	list := []client.ProjectListItem{}
+	for project, err := range a.client.Projects.All(ctx, opts, 100) {
+		if err != nil {
+			return err
+		}
+
+		list = append(list, project)
+		if limit > 0 && len(list) == limit {
+			break
+		}
+	}
+
+	rows := make([][]string, 0, len(list))
+	for _, p := range list {
+		rows = append(rows, []string{
+			strconv.Itoa(p.Id), p.Name, strconv.Itoa(p.OwnerId), strconv.Itoa(p.TotalTasks), percent(p.Progress),
+		})
+	}
+
+	return a.printer.print(list, []string{"ID", "NAME", "OWNER", "TASKS", "PROGRESS"}, rows)
+}
+
+func projectStats(ctx context.Context, a *app, args []string) error {
+	id, err := idArg("project stats", args, nil)
+	if err != nil {
+		return err
+	}
+
+	project, err := a.client.Projects.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	s := project.TaskStats
+	rows := [][]string{
+		{"project", fmt.Sprintf("%d %s", project.Id, project.Name)},
+		{"tasks", strconv.Itoa(s.TotalTasks)},
+		{"pending", strconv.Itoa(s.PendingTasks)},
+		{"in progress", strconv.Itoa(s.InProgressTasks)},
+		{"completed", strconv.Itoa(s.CompletedTasks)},
+		{"points", fmt.Sprintf("%d of %d", s.CompletedPoints, s.TotalPoints)},
+		{"estimate", fmt.Sprintf("%d of %d remaining", s.RemainingEstimate, s.OriginalEstimate)},
+		{"progress", percent(s.Progress) + " by " + s.Weighting},
+	}
+
+	return a.printer.print(project, []string{"STAT", "VALUE"}, rows)
+}
+
+func percent(progress float64) string {
+	return fmt.Sprintf("%.0f%%", progress)
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"task-management2/pkg/client"
+)
+
+var statuses = []string{"pending", "in_progress", "completed"}
+
+func taskList(ctx context.Context, a *app, args []string) error {
+	var (
+		mine                bool
+		status              string
+		projectId, sprintId int
+		limit               int
+	)
+
+	_, err := flags("task list", args, func(fs *flag.FlagSet) {
+		fs.BoolVar(&mine, "mine", false, "only tasks assigned to you")
+		fs.StringVar(&status, "status", "", "only tasks with this status: "+strings.Join(statuses, ", "))
+		fs.IntVar(&projectId, "project", 0, "only tasks of this project")
+		fs.IntVar(&sprintId, "sprint", 0, "only tasks of this sprint")
+		fs.IntVar(&limit, "limit", 50, "show at most this many tasks, 0 for all")
+	})
+	if err != nil {
+		return err
+	}
+	if status != "" && !oneOf(status, statuses) {
+		return usageError{"--status must be one of " + strings.Join(statuses, ", ")}
+	}
+	if mine && a.config.UserId == 0 {
+		return usageError{"--mine needs tm login --user ID first"}
+	}
+
+	var opts client.TaskListOptions
+	if projectId > 0 {
+		opts.ProjectId = &projectId
+	}
+	if sprintId > 0 {
+		opts.SprintId = &sprintId
+	}
+	if mine {
+		opts.AssignedTo = &a.config.UserId
+	}
+	opts.Status = status
+
+	pageSize := 100
+	if limit > 0 && limit < pageSize {
+		pageSize = limit
+	}
+
+	list := []client.Task{}
+	for task, err := range a.client.Tasks.All(ctx, opts, pageSize) {
+		if err != nil {
+			return err
+		}
+
+		list = append(list, task)
+		if limit > 0 && len(list) == limit {
+			break
+		}
+	}
+
+	rows := make([][]string, 0, len(list))
+	for _, task := range list {
+		rows = append(rows, []string{
+			strconv.Itoa(task.Id), str(task.Name), str(task.Status), str(task.Priority),
+			str(task.DueDate), num(task.AssignedTo), num(task.ProjectId),
+		})
+	}
+
+	return a.printer.print(list, []string{"ID", "NAME", "STATUS", "PRIORITY", "DUE", "ASSIGNEE", "PROJECT"}, rows)
+}
+
+func taskShow(ctx context.Context, a *app, args []string) error {
+	id, err := idArg("task show", args, nil)
+	if err != nil {
+		return err
+	}
+
+	task, err := a.client.Tasks.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return a.printer.print(task, []string{"FIELD", "VALUE"}, taskRows(task))
+}
+
+func taskCreate(ctx context.Context, a *app, args []string) error {
+	var (
+		projectId, assignTo, points int
+		name, description, due      string
+		status, priority            string
+		labels                      stringList
+	)
+
+	_, err := flags("task create", args, func(fs *flag.FlagSet) {
+		fs.IntVar(&projectId, "project", 0, "project of the task")
+		fs.StringVar(&name, "name", "", "name of the task")
+		fs.StringVar(&due, "due", "", "due date, YYYY-MM-DD")
+		fs.StringVar(&description, "description", "", "description")
+		fs.StringVar(&status, "status", "pending", "status: "+strings.Join(statuses, ", "))
+		fs.StringVar(&priority, "priority", "medium", "priority: low, medium, high")
+		fs.IntVar(&assignTo, "assign", 0, "user to assign the task to")
+		fs.IntVar(&points, "points", 0, "story points")
+		fs.Var(&labels, "label", "label, may be repeated")
+	})
+	if err != nil {
+		return err
+	}
+	if projectId <= 0 || name == "" || due == "" {
+		return usageError{"task create needs --project, --name and --due"}
+	}
+
+	data := client.CreateTask{
+		ProjectId: &projectId,
+		Name:      &name,
+		DueDate:   &due,
+		Status:    &status,
+		Priority:  &priority,
+		Labels:    labels,
+	}
+	if description != "" {
+		data.Description = &description
+	}
+	if assignTo > 0 {
+		data.AssignedTo = &assignTo
+	}
+	if points > 0 {
+		data.StoryPoints = &points
+	}
+
+	task, err := a.client.Tasks.Create(ctx, data)
+	if err != nil {
+		return err
+	}
+
+	a.printer.message("created task %d", task.Id)
+	return a.printer.print(task, []string{"FIELD", "VALUE"}, taskRows(task))
+}
+
+func taskMove(ctx context.Context, a *app, args []string) error {
+	var (
+		status        string
+		after, before int
+	)
+
+	id, err := idArg("task move", args, func(fs *flag.FlagSet) {
+		fs.StringVar(&status, "status", "", "column to move the task to: "+strings.Join(statuses, ", "))
+		fs.IntVar(&after, "after", 0, "place the task below this task")
+		fs.IntVar(&before, "before", 0, "place the task above this task")
+	})
+	if err != nil {
+		return err
+	}
+	if !oneOf(status, statuses) {
+		return usageError{"--status must be one of " + strings.Join(statuses, ", ")}
+	}
+
+	data := client.MoveTask{Status: &status}
+	if after > 0 {
+		data.AfterId = &after
+	}
+	if before > 0 {
+		data.BeforeId = &before
+	}
+
+	task, err := a.client.Tasks.Move(ctx, id, data)
+	if err != nil {
+		return err
+	}
+
+	a.printer.message("moved task %d to %s", task.Id, str(task.Status))
+	return a.printer.print(task, []string{"FIELD", "VALUE"}, taskRows(task))
+}
+
+func taskRows(task client.Task) [][]string {
+	return [][]string{
+		{"id", strconv.Itoa(task.Id)},
+		{"name", str(task.Name)},
+		{"description", str(task.Description)},
+		{"project", num(task.ProjectId)},
+		{"status", str(task.Status)},
+		{"priority", str(task.Priority)},
+		{"assignee", num(task.AssignedTo)},
+		{"start", str(task.StartDate)},
+		{"due", str(task.DueDate)},
+		{"points", num(task.StoryPoints)},
+		{"sprint", num(task.SprintId)},
+		{"milestone", num(task.MilestoneId)},
+		{"labels", strings.Join(task.Labels, ", ")},
+		{"version", strconv.Itoa(task.Version)},
+	}
+}
+
+// idArg parses the flags of a command taking a single record id.
+func idArg(name string, args []string, define func(fs *flag.FlagSet)) (int, error) {
+	if define == nil {
+		define = func(*flag.FlagSet) {}
+	}
+
+	positional, err := flags(name, args, define)
+	if err != nil {
+		return 0, err
+	}
+	if len(positional) != 1 {
+		return 0, usageError{name + " needs one id"}
+	}
+
+	id, err := strconv.Atoi(positional[0])
+	if err != nil || id <= 0 {
+		return 0, usageError{fmt.Sprintf("%s: %q is not an id", name, positional[0])}
+	}
+
+	return id, nil
+}
+
+func oneOf(value string, values []string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// stringList is a flag that may be repeated.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
@@ -0,0 +1,40 @@
+package basic_controller
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	http_basic_controller "task-management2/internal/controller/http/v1/_basic_controller"
+)
+
+// Authenticate returns the user whose token the call carries in the
+// authorization metadata, "Bearer " and the token, like the Authorization
+// header of the REST API.
+func Authenticate(ctx context.Context, tokens http_basic_controller.TokenRepository) (int, error) {
+	var token string
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		if t, ok := strings.CutPrefix(value, "Bearer "); ok {
+			token = t
+			break
+		}
+	}
+	if token == "" {
+		return 0, status.Error(codes.Unauthenticated, "token is required")
+	}
+
+	userId, err := tokens.GetUserIdByToken(ctx, token)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, status.Error(codes.Unauthenticated, "invalid or revoked token")
+	}
+	if err != nil {
+		return 0, Error(ctx, err)
+	}
+
+	return userId, nil
+}
@@ -0,0 +1,100 @@
+package basic_controller
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"task-management2/internal/grpc/pb"
+)
+
+// Limit and Offset read a page of a list request. Offset is the number of
+// rows to skip, nil for the first page.
+func Limit(page *pb.Page) *int {
+	if page.GetLimit() <= 0 {
+		return nil
+	}
+
+	limit := int(page.GetLimit())
+	return &limit
+}
+
+func Offset(page *pb.Page, defaultLimit int) *int {
+	if page.GetPage() <= 1 {
+		return nil
+	}
+
+	limit := defaultLimit
+	if page.GetLimit() > 0 {
+		limit = int(page.GetLimit())
+	}
+
+	offset := (int(page.GetPage()) - 1) * limit
+	return &offset
+}
+
+// Id converts an optional id of a message, nil stays nil.
+func Id(id *int64) *int {
+	if id == nil {
+		return nil
+	}
+
+	v := int(*id)
+	return &v
+}
+
+func Int(n *int32) *int {
+	if n == nil {
+		return nil
+	}
+
+	v := int(*n)
+	return &v
+}
+
+func Int64(id *int) *int64 {
+	if id == nil {
+		return nil
+	}
+
+	v := int64(*id)
+	return &v
+}
+
+func Int32(n *int) *int32 {
+	if n == nil {
+		return nil
+	}
+
+	v := int32(*n)
+	return &v
+}
+
+// String is the value of an optional string, "" when it is nil.
+func String(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
+
+// NonEmpty is nil for "", for the optional strings of requests.
+func NonEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
+
+func Timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+
+	return timestamppb.New(*t)
+}
+
+func Ptr[T any](v T) *T {
+	return &v
+}
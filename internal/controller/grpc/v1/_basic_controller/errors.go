@@ -0,0 +1,84 @@
+package basic_controller
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/uptrace/bun/driver/pgdriver"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	http_basic_controller "task-management2/internal/controller/http/v1/_basic_controller"
+)
+
+// statusCodes maps the statuses of the REST API's errors to gRPC codes.
+var statusCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.FailedPrecondition,
+	http.StatusPreconditionFailed:  codes.Aborted,
+	http.StatusUnprocessableEntity: codes.InvalidArgument,
+	http.StatusServiceUnavailable:  codes.Unavailable,
+}
+
+// Error converts an error of the repositories to a gRPC status, classified
+// like the error responses of the REST API. Validation errors carry the
+// offending fields as a BadRequest detail. Internal errors are logged and
+// not shown to the client.
+func Error(ctx context.Context, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	e := http_basic_controller.ToError(err)
+
+	code, ok := statusCodes[e.Status]
+	if !ok {
+		code = codes.Internal
+	}
+
+	var pgErr pgdriver.Error
+	if errors.As(err, &pgErr) && pgErr.Field('C') == "23505" {
+		code = codes.AlreadyExists
+	}
+
+	if code == codes.Internal {
+		method, _ := grpc.Method(ctx)
+		log.Printf("%s: %v", method, err)
+	}
+
+	st := status.New(code, e.Message)
+	if len(e.Fields) == 0 {
+		return st.Err()
+	}
+
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: f.Field, Description: f.Message})
+	}
+
+	detailed, detailErr := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if detailErr != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
+
+// Invalid is the error of a request the controller rejects itself.
+func Invalid(field, message string) error {
+	st := status.New(codes.InvalidArgument, field+" "+message)
+	detailed, err := st.WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: field, Description: message}},
+	})
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
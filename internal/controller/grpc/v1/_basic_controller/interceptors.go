@@ -0,0 +1,40 @@
+package basic_controller
+
+import (
+	"context"
+	"log"
+	"runtime/debug"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Unary and Stream log every call and turn a panic into an Internal error,
+// what gin.Default's logger and recovery do for the REST API.
+func Unary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (res any, err error) {
+	start := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("%s: panic: %v\n%s", info.FullMethod, r, debug.Stack())
+			err = status.Error(codes.Internal, "internal server error")
+		}
+		log.Printf("[GRPC] %13v | %-16s | %s", time.Since(start), status.Code(err), info.FullMethod)
+	}()
+
+	return handler(ctx, req)
+}
+
+func Stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	start := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("%s: panic: %v\n%s", info.FullMethod, r, debug.Stack())
+			err = status.Error(codes.Internal, "internal server error")
+		}
+		log.Printf("[GRPC] %13v | %-16s | %s", time.Since(start), status.Code(err), info.FullMethod)
+	}()
+
+	return handler(srv, ss)
+}
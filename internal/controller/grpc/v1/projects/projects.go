@@ -0,0 +1,186 @@
+package projects
+
+import (
+	"context"
+	"google.golang.org/protobuf/types/known/emptypb"
+	basic_controller "task-management2/internal/controller/grpc/v1/_basic_controller"
+	"task-management2/internal/entity"
+	"task-management2/internal/grpc/pb"
+	"task-management2/internal/pkg/validation"
+	basic_repo "task-management2/internal/repository/postgres/_basic_repo"
+	"task-management2/internal/repository/postgres/projects"
+)
+
+type Controller struct {
+	pb.UnimplementedProjectServiceServer
+
+	useCase Repository
+}
+
+func NewController(useCase Repository) *Controller {
+	return &Controller{useCase: useCase}
+}
+
+func (cl *Controller) ListProjects(ctx context.Context, req *pb.ListProjectsRequest) (*pb.ListProjectsResponse, error) {
+	filter := projects.Filter{
+		Limit:   basic_controller.Limit(req.GetPage()),
+		Offset:  basic_controller.Offset(req.GetPage(), 10),
+		OwnerId: basic_controller.Id(req.OwnerId),
+	}
+	if filter.Offset != nil && filter.Limit == nil {
+		filter.Limit = basic_controller.Ptr(10)
+	}
+
+	weighting, err := weighting(req.GetWeighting())
+	if err != nil {
+		return nil, err
+	}
+	filter.Weighting = weighting
+
+	list, err := cl.useCase.GetProjectsWithStats(ctx, filter)
+	if err != nil {
+		return nil, basic_controller.Error(ctx, err)
+	}
+
+	count, err := cl.useCase.GetProjectsCount(ctx, filter)
+	if err != nil {
+		return nil, basic_controller.Error(ctx, err)
+	}
+
+	res := &pb.ListProjectsResponse{Projects: make([]*pb.Project, 0, len(list)), Count: int32(count)}
+	for _, p := range list {
+		res.Projects = append(res.Projects, &pb.Project{
+			Id:          int64(p.Id),
+			Name:        p.Name,
+			Description: p.Description,
+			OwnerId:     int64(p.OwnerId),
+			TaskStats: &pb.TaskStats{
+				TotalTasks: int32(p.TotalTasks),
+				Progress:   p.Progress,
+			},
+		})
+	}
+
+	return res, nil
+}
+
+func (cl *Controller) GetProject(ctx context.Context, req *pb.GetProjectRequest) (*pb.Project, error) {
+	filter := projects.DetailFilter{Id: int(req.GetId())}
+
+	weighting, err := weighting(req.GetWeighting())
+	if err != nil {
+		return nil, err
+	}
+	filter.Weighting = weighting
+
+	detail, err := cl.useCase.GetDetail(ctx, filter)
+	if err != nil {
+		return nil, basic_controller.Error(ctx, err)
+	}
+
+	stats := detail.TaskStats
+
+	return &pb.Project{
+		Id:          int64(detail.Id),
+		Name:        detail.Name,
+		Description: detail.Description,
+		OwnerId:     int64(detail.Owner_id),
+		Version:     int32(detail.Version),
+		TaskStats: &pb.TaskStats{
+			TotalTasks:        int32(stats.TotalTasks),
+			CompletedTasks:    int32(stats.CompletedTasks),
+			InProgressTasks:   int32(stats.InProgressTasks),
+			PendingTasks:      int32(stats.PendingTasks),
+			TotalPoints:       int32(stats.TotalPoints),
+			CompletedPoints:   int32(stats.CompletedPoints),
+			OriginalEstimate:  int32(stats.OriginalEstimate),
+			RemainingEstimate: int32(stats.RemainingEstimate),
+			Weighting:         stats.Weighting,
+			Progress:          stats.Progress,
+		},
+	}, nil
+}
+
+func (cl *Controller) CreateProject(ctx context.Context, req *pb.CreateProjectRequest) (*pb.Project, error) {
+	data := projects.Create{
+		Name:        basic_controller.Ptr(req.GetName()),
+		Description: req.Description,
+		Owner_id:    basic_controller.Ptr(int(req.GetOwnerId())),
+	}
+	if err := validation.Struct(data); err != nil {
+		return nil, basic_controller.Error(ctx, err)
+	}
+
+	project, err := cl.useCase.Create(ctx, data)
+	if err != nil {
+		return nil, basic_controller.Error(ctx, err)
+	}
+
+	return toProject(project), nil
+}
+
+func (cl *Controller) UpdateProject(ctx context.Context, req *pb.UpdateProjectRequest) (*pb.Project, error) {
+	data := projects.Update{
+		Id:          basic_controller.Ptr(int(req.GetId())),
+		Name:        req.Name,
+		Description: req.Description,
+		Owner_id:    basic_controller.Id(req.OwnerId),
+		Version:     basic_controller.Int(req.Version),
+	}
+	if err := validation.Struct(data); err != nil {
+		return nil, basic_controller.Error(ctx, err)
+	}
+
+	project, err := cl.useCase.Update(ctx, data)
+	if err != nil {
+		return nil, basic_controller.Error(ctx, err)
+	}
+
+	return toProject(project), nil
+}
+
+func (cl *Controller) DeleteProject(ctx context.Context, req *pb.DeleteProjectRequest) (*emptypb.Empty, error) {
+	err := cl.useCase.Delete(ctx, basic_repo.Delete{
+		Id:      basic_controller.Ptr(int(req.GetId())),
+		Version: basic_controller.Int(req.Version),
+	})
+	if err != nil {
+		return nil, basic_controller.Error(ctx, err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (cl *Controller) RestoreProject(ctx context.Context, req *pb.RestoreProjectRequest) (*pb.Project, error) {
+	project, err := cl.useCase.Restore(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, basic_controller.Error(ctx, err)
+	}
+
+	return toProject(project), nil
+}
+
+func weighting(q string) (*string, error) {
+	if q == "" {
+		return nil, nil
+	}
+	if !basic_repo.ValidWeighting(q) {
+		return nil, basic_controller.Invalid("weighting", "must be one of count, points, estimate!")
+	}
+
+	return &q, nil
+}
+
+func toProject(project entity.Projects) *pb.Project {
+	res := &pb.Project{
+		Id:          int64(project.Id),
+		Name:        basic_controller.String(project.Name),
+		Description: basic_controller.String(project.Description),
+		Version:     int32(project.Version),
+	}
+	if project.OwnerId != nil {
+		res.OwnerId = int64(*project.OwnerId)
+	}
+
+	return res
+}
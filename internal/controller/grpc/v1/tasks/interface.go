@@ -0,0 +1,29 @@
+package tasks
+
+import (
+	"context"
+	"task-management2/internal/entity"
+	"task-management2/internal/pkg/stream"
+	basic_repo "task-management2/internal/repository/postgres/_basic_repo"
+	"task-management2/internal/repository/postgres/outbox"
+	"task-management2/internal/repository/postgres/tasks"
+)
+
+type Repository interface {
+	GetAll(ctx context.Context, filter tasks.Filter) ([]entity.Tasks, int, error)
+	GetTaskStats(ctx context.Context, filter tasks.Filter) (tasks.TaskStats, error)
+	GetById(ctx context.Context, id int) (entity.Tasks, error)
+	Create(ctx context.Context, data tasks.Create) (entity.Tasks, error)
+	Update(ctx context.Context, data tasks.Update) (entity.Tasks, error)
+	Delete(ctx context.Context, data basic_repo.Delete) error
+	Move(ctx context.Context, data tasks.Move) (entity.Tasks, error)
+	Restore(ctx context.Context, id int) (entity.Tasks, error)
+}
+
+type Watcher interface {
+	Open(ctx context.Context, userId int, match func(outbox.Event) bool) (*stream.Watch, error)
+}
+
+type TokenRepository interface {
+	GetUserIdByToken(ctx context.Context, token string) (int, error)
+}
@@ -0,0 +1,223 @@
+package tasks
+
+import (
+	"context"
+	"google.golang.org/protobuf/types/known/emptypb"
+	basic_controller "task-management2/internal/controller/grpc/v1/_basic_controller"
+	"task-management2/internal/entity"
+	"task-management2/internal/grpc/pb"
+	"task-management2/internal/pkg/validation"
+	basic_repo "task-management2/internal/repository/postgres/_basic_repo"
+	"task-management2/internal/repository/postgres/tasks"
+)
+
+const defaultLimit = 10
+
+type Controller struct {
+	pb.UnimplementedTaskServiceServer
+
+	useCase Repository
+	watcher Watcher
+	tokens  TokenRepository
+}
+
+func NewController(useCase Repository, watcher Watcher, tokens TokenRepository) *Controller {
+	return &Controller{
+		useCase: useCase,
+		watcher: watcher,
+		tokens:  tokens,
+	}
+}
+
+func (cl *Controller) ListTasks(ctx context.Context, req *pb.ListTasksRequest) (*pb.ListTasksResponse, error) {
+	filter := tasks.Filter{
+		Limit:       basic_controller.Ptr(defaultLimit),
+		Offset:      basic_controller.Ptr(0),
+		ProjectId:   basic_controller.Id(req.ProjectId),
+		SprintId:    basic_controller.Id(req.SprintId),
+		MilestoneId: basic_controller.Id(req.MilestoneId),
+	}
+	if limit := basic_controller.Limit(req.GetPage()); limit != nil {
+		filter.Limit = limit
+	}
+	if offset := basic_controller.Offset(req.GetPage(), defaultLimit); offset != nil {
+		filter.Offset = offset
+	}
+
+	if q := req.GetWeighting(); q != "" {
+		if !basic_repo.ValidWeighting(q) {
+			return nil, basic_controller.Invalid("weighting", "must be one of count, points, estimate!")
+		}
+		filter.Weighting = &q
+	}
+
+	list, count, err := cl.useCase.GetAll(ctx, filter)
+	if err != nil {
+		return nil, basic_controller.Error(ctx, err)
+	}
+
+	stats, err := cl.useCase.GetTaskStats(ctx, filter)
+	if err != nil {
+		return nil, basic_controller.Error(ctx, err)
+	}
+
+	res := &pb.ListTasksResponse{
+		Tasks: make([]*pb.Task, 0, len(list)),
+		Count: int32(count),
+		Stats: &pb.TaskStats{
+			TotalTasks:        int32(stats.TotalTasks),
+			CompletedTasks:    int32(stats.CompletedTasks),
+			InProgressTasks:   int32(stats.InProgressTasks),
+			PendingTasks:      int32(stats.PendingTasks),
+			TotalPoints:       int32(stats.TotalPoints),
+			CompletedPoints:   int32(stats.CompletedPoints),
+			OriginalEstimate:  int32(stats.OriginalEstimate),
+			RemainingEstimate: int32(stats.RemainingEstimate),
+			Weighting:         stats.Weighting,
+			Progress:          stats.Progress,
+		},
+	}
+	for _, task := range list {
+		res.Tasks = append(res.Tasks, toTask(task))
+	}
+
+	return res, nil
+}
+
+func (cl *Controller) GetTask(ctx context.Context, req *pb.GetTaskRequest) (*pb.Task, error) {
+	task, err := cl.useCase.GetById(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, basic_controller.Error(ctx, err)
+	}
+
+	return toTask(task), nil
+}
+
+func (cl *Controller) CreateTask(ctx context.Context, req *pb.CreateTaskRequest) (*pb.Task, error) {
+	data := tasks.Create{
+		ProjectId:         basic_controller.Ptr(int(req.GetProjectId())),
+		Name:              basic_controller.Ptr(req.GetName()),
+		Description:       req.Description,
+		AssignedTo:        basic_controller.Id(req.AssignedTo),
+		Status:            basic_controller.Ptr(req.GetStatus()),
+		Priority:          basic_controller.Ptr(req.GetPriority()),
+		StartDate:         req.StartDate,
+		DueDate:           basic_controller.Ptr(req.GetDueDate()),
+		OriginalEstimate:  basic_controller.Int(req.OriginalEstimate),
+		RemainingEstimate: basic_controller.Int(req.RemainingEstimate),
+		StoryPoints:       basic_controller.Int(req.StoryPoints),
+		Labels:            req.GetLabels(),
+	}
+	if err := validation.Struct(data); err != nil {
+		return nil, basic_controller.Error(ctx, err)
+	}
+
+	task, err := cl.useCase.Create(ctx, data)
+	if err != nil {
+		return nil, basic_controller.Error(ctx, err)
+	}
+
+	return toTask(task), nil
+}
+
+func (cl *Controller) UpdateTask(ctx context.Context, req *pb.UpdateTaskRequest) (*pb.Task, error) {
+	data := tasks.Update{
+		Id:                basic_controller.Ptr(int(req.GetId())),
+		ProjectId:         basic_controller.Id(req.ProjectId),
+		Name:              req.Name,
+		Description:       req.Description,
+		AssignedTo:        basic_controller.Id(req.AssignedTo),
+		Status:            req.Status,
+		Priority:          req.Priority,
+		StartDate:         req.StartDate,
+		DueDate:           req.DueDate,
+		OriginalEstimate:  basic_controller.Int(req.OriginalEstimate),
+		RemainingEstimate: basic_controller.Int(req.RemainingEstimate),
+		StoryPoints:       basic_controller.Int(req.StoryPoints),
+		Version:           basic_controller.Int(req.Version),
+	}
+	if req.Labels != nil {
+		labels := req.Labels.GetLabels()
+		if labels == nil {
+			labels = []string{}
+		}
+		data.Labels = &labels
+	}
+	if err := validation.Struct(data); err != nil {
+		return nil, basic_controller.Error(ctx, err)
+	}
+
+	task, err := cl.useCase.Update(ctx, data)
+	if err != nil {
+		return nil, basic_controller.Error(ctx, err)
+	}
+
+	return toTask(task), nil
+}
+
+func (cl *Controller) DeleteTask(ctx context.Context, req *pb.DeleteTaskRequest) (*emptypb.Empty, error) {
+	err := cl.useCase.Delete(ctx, basic_repo.Delete{
+		Id:      basic_controller.Ptr(int(req.GetId())),
+		Version: basic_controller.Int(req.Version),
+	})
+	if err != nil {
+		return nil, basic_controller.Error(ctx, err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (cl *Controller) MoveTask(ctx context.Context, req *pb.MoveTaskRequest) (*pb.Task, error) {
+	data := tasks.Move{
+		Id:       basic_controller.Ptr(int(req.GetId())),
+		Status:   basic_controller.Ptr(req.GetStatus()),
+		AfterId:  basic_controller.Id(req.AfterId),
+		BeforeId: basic_controller.Id(req.BeforeId),
+	}
+	if err := validation.Struct(data); err != nil {
+		return nil, basic_controller.Error(ctx, err)
+	}
+
+	task, err := cl.useCase.Move(ctx, data)
+	if err != nil {
+		return nil, basic_controller.Error(ctx, err)
+	}
+
+	return toTask(task), nil
+}
+
+func (cl *Controller) RestoreTask(ctx context.Context, req *pb.RestoreTaskRequest) (*pb.Task, error) {
+	task, err := cl.useCase.Restore(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, basic_controller.Error(ctx, err)
+	}
+
+	return toTask(task), nil
+}
+
+func toTask(task entity.Tasks) *pb.Task {
+	res := &pb.Task{
+		Id:                int64(task.Id),
+		Name:              basic_controller.String(task.Name),
+		Description:       task.Description,
+		AssignedTo:        basic_controller.Int64(task.AssignedTo),
+		Status:            basic_controller.String(task.Status),
+		Priority:          basic_controller.String(task.Priority),
+		StartDate:         task.StartDate,
+		DueDate:           basic_controller.String(task.DueDate),
+		OriginalEstimate:  basic_controller.Int32(task.OriginalEstimate),
+		RemainingEstimate: basic_controller.Int32(task.RemainingEstimate),
+		StoryPoints:       basic_controller.Int32(task.StoryPoints),
+		SprintId:          basic_controller.Int64(task.SprintId),
+		MilestoneId:       basic_controller.Int64(task.MilestoneId),
+		Labels:            task.Labels,
+		Version:           int32(task.Version),
+		CreatedAt:         basic_controller.Timestamp(task.CreatedAt),
+		UpdatedAt:         basic_controller.Timestamp(task.UpdateAt),
+	}
+	if task.ProjectId != nil {
+		res.ProjectId = int64(*task.ProjectId)
+	}
+
+	return res
+}
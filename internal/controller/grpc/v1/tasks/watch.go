@@ -0,0 +1,66 @@
+package tasks
+
+import (
+	"errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	basic_controller "task-management2/internal/controller/grpc/v1/_basic_controller"
+	"task-management2/internal/grpc/pb"
+	"task-management2/internal/pkg/stream"
+	"task-management2/internal/repository/postgres/outbox"
+)
+
+// WatchTasks streams the task events of the outbox, the same events the
+// /stream endpoint pushes as Server-Sent Events. A client that reconnects
+// with after_event_id first gets what it missed. Events of projects the user
+// of the authorization metadata may not see are left out.
+func (cl *Controller) WatchTasks(req *pb.WatchTasksRequest, srv grpc.ServerStreamingServer[pb.TaskEvent]) error {
+	ctx := srv.Context()
+
+	userId, err := basic_controller.Authenticate(ctx, cl.tokens)
+	if err != nil {
+		return err
+	}
+
+	projectIds := map[int]bool{}
+	for _, id := range req.GetProjectIds() {
+		projectIds[int(id)] = true
+	}
+
+	match := func(event outbox.Event) bool {
+		if event.AggregateType != outbox.AggregateTask {
+			return false
+		}
+		if len(projectIds) == 0 {
+			return true
+		}
+
+		projectId := event.ProjectId()
+		return projectId != nil && projectIds[*projectId]
+	}
+
+	watch, err := cl.watcher.Open(ctx, userId, match)
+	if err != nil {
+		return basic_controller.Error(ctx, err)
+	}
+	defer watch.Close()
+
+	send := func(event outbox.Event) error {
+		return srv.Send(&pb.TaskEvent{
+			Id:        event.Id,
+			Type:      event.Type,
+			TaskId:    int64(event.AggregateId),
+			ProjectId: basic_controller.Int64(event.ProjectId()),
+			Payload:   string(event.Payload),
+			CreatedAt: basic_controller.Timestamp(&event.CreatedAt),
+		})
+	}
+
+	err = watch.Run(ctx, req.GetAfterEventId(), send, nil)
+	if errors.Is(err, stream.ErrDropped) {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+
+	return err
+}
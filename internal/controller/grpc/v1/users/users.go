@@ -0,0 +1,165 @@
+package users
+
+import (
+	"context"
+	"google.golang.org/protobuf/types/known/emptypb"
+	basic_controller "task-management2/internal/controller/grpc/v1/_basic_controller"
+	"task-management2/internal/entity"
+	"task-management2/internal/grpc/pb"
+	"task-management2/internal/pkg/validation"
+	basic_repo "task-management2/internal/repository/postgres/_basic_repo"
+	"task-management2/internal/repository/postgres/users"
+)
+
+type Controller struct {
+	pb.UnimplementedUserServiceServer
+
+	useCase Repository
+}
+
+func NewController(useCase Repository) *Controller {
+	return &Controller{useCase: useCase}
+}
+
+func (cl *Controller) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	filter := users.Filter{
+		Limit:  basic_controller.Limit(req.GetPage()),
+		Offset: basic_controller.Offset(req.GetPage(), 10),
+	}
+	if filter.Offset != nil && filter.Limit == nil {
+		filter.Limit = basic_controller.Ptr(10)
+	}
+
+	list, count, err := cl.useCase.GetAll(ctx, filter)
+	if err != nil {
+		return nil, basic_controller.Error(ctx, err)
+	}
+
+	res := &pb.ListUsersResponse{Users: make([]*pb.User, 0, len(list)), Count: int32(count)}
+	for _, u := range list {
+		res.Users = append(res.Users, &pb.User{
+			Id:       deref64(u.Id),
+			FullName: basic_controller.String(u.FullName),
+			Email:    basic_controller.String(u.Email),
+			Role:     basic_controller.String(u.Role),
+			Tasks:    taskCounts(u.PendingTasks, u.InProgressTasks, u.CompletedTasks, u.TaskCount),
+		})
+	}
+
+	return res, nil
+}
+
+func (cl *Controller) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.User, error) {
+	detail, err := cl.useCase.GetById(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, basic_controller.Error(ctx, err)
+	}
+
+	return toDetail(detail), nil
+}
+
+func (cl *Controller) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.User, error) {
+	data := users.Create{
+		FullName: basic_controller.Ptr(req.GetFullName()),
+		Email:    basic_controller.Ptr(req.GetEmail()),
+		Role:     basic_controller.Ptr(req.GetRole()),
+		Password: basic_controller.Ptr(req.GetPassword()),
+	}
+	if err := validation.Struct(data); err != nil {
+		return nil, basic_controller.Error(ctx, err)
+	}
+
+	user, err := cl.useCase.Create(ctx, data)
+	if err != nil {
+		return nil, basic_controller.Error(ctx, err)
+	}
+
+	return toUser(user), nil
+}
+
+func (cl *Controller) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.User, error) {
+	data := users.Update{
+		Id:       basic_controller.Ptr(int(req.GetId())),
+		FullName: req.FullName,
+		Email:    req.Email,
+		Role:     req.Role,
+		Password: req.Password,
+		Version:  basic_controller.Int(req.Version),
+	}
+	if err := validation.Struct(data); err != nil {
+		return nil, basic_controller.Error(ctx, err)
+	}
+
+	user, err := cl.useCase.Update(ctx, data)
+	if err != nil {
+		return nil, basic_controller.Error(ctx, err)
+	}
+
+	return toUser(user), nil
+}
+
+func (cl *Controller) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*emptypb.Empty, error) {
+	err := cl.useCase.Delete(ctx, basic_repo.Delete{
+		Id:      basic_controller.Ptr(int(req.GetId())),
+		Version: basic_controller.Int(req.Version),
+	})
+	if err != nil {
+		return nil, basic_controller.Error(ctx, err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (cl *Controller) RestoreUser(ctx context.Context, req *pb.RestoreUserRequest) (*pb.User, error) {
+	detail, err := cl.useCase.Restore(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, basic_controller.Error(ctx, err)
+	}
+
+	return toDetail(detail), nil
+}
+
+func toUser(user entity.User) *pb.User {
+	return &pb.User{
+		Id:       int64(user.Id),
+		FullName: basic_controller.String(user.FullName),
+		Email:    basic_controller.String(user.Email),
+		Role:     basic_controller.String(user.Role),
+		Version:  int32(user.Version),
+	}
+}
+
+func toDetail(detail users.Detail) *pb.User {
+	return &pb.User{
+		Id:       deref64(detail.Id),
+		FullName: basic_controller.String(detail.FullName),
+		Email:    basic_controller.String(detail.Email),
+		Role:     basic_controller.String(detail.Role),
+		Version:  int32(detail.Version),
+		Tasks:    taskCounts(detail.PendingTasks, detail.InProgressTasks, detail.CompletedTasks, detail.TaskCount),
+	}
+}
+
+func taskCounts(pending, inProgress, completed, total *int) *pb.UserTaskCounts {
+	count := func(n *int) int32 {
+		if n == nil {
+			return 0
+		}
+		return int32(*n)
+	}
+
+	return &pb.UserTaskCounts{
+		Pending:    count(pending),
+		InProgress: count(inProgress),
+		Completed:  count(completed),
+		Total:      count(total),
+	}
+}
+
+func deref64(id *int64) int64 {
+	if id == nil {
+		return 0
+	}
+
+	return *id
+}
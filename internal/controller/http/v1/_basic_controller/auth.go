@@ -0,0 +1,43 @@
+package basic_controller
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"task-management2/internal/util/request_header"
+)
+
+// TokenRepository resolves the tokens users authenticate with, the ones
+// issued by POST /calendar/token/:user_id.
+type TokenRepository interface {
+	GetUserIdByToken(ctx context.Context, token string) (int, error)
+}
+
+// Authenticate returns the user whose token the request carries, as a
+// bearer token in the Authorization header or in the token query parameter
+// for clients that can't send headers, such as calendar apps and
+// EventSource. It writes the error response itself.
+func Authenticate(c *gin.Context, tokens TokenRepository) (int, bool) {
+	token := request_header.GetTokenFromHeader(c)
+	if token == "" {
+		token = c.Query("token")
+	}
+	if token == "" {
+		Fail(c, http.StatusUnauthorized, "token is required")
+		return 0, false
+	}
+
+	userId, err := tokens.GetUserIdByToken(c.Request.Context(), token)
+	if errors.Is(err, sql.ErrNoRows) {
+		Fail(c, http.StatusUnauthorized, "invalid or revoked token")
+		return 0, false
+	}
+	if err != nil {
+		Abort(c, err)
+		return 0, false
+	}
+
+	return userId, true
+}
@@ -2,6 +2,7 @@ package basic_controller
 
 import (
 	"context"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"strconv"
@@ -15,26 +16,20 @@ func BasicDelete(c *gin.Context) (context.Context, basic_repo.Delete, error) {
 
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
-		c.JSON(http.StatusOK, gin.H{
-			"message": "id must be number!",
-			"status":  false,
-		})
+		Fail(c, http.StatusBadRequest, "id must be number!")
 
 		return ctx, basic_repo.Delete{}, err
 	}
 
 	var data basic_repo.Delete
+	var ok bool
 
-	if err != nil {
-		c.JSON(http.StatusOK, gin.H{
-			"message": err.Error(),
-			"status":  false,
-		})
+	data.Id = &id
 
-		return ctx, basic_repo.Delete{}, err
+	data.Version, ok = IfMatch(c)
+	if !ok {
+		return ctx, basic_repo.Delete{}, fmt.Errorf("invalid If-Match header")
 	}
 
-	data.Id = &id
-
 	return ctx, data, nil
 }
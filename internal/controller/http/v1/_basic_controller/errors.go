@@ -0,0 +1,241 @@
+package basic_controller
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/uptrace/bun/driver/pgdriver"
+	"log"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+	basic_repo "task-management2/internal/repository/postgres/_basic_repo"
+)
+
+// Error is the body of every error response:
+//
+//	{"status": false, "code": "not_found", "message": "task not found"}
+//
+// Validation errors also list the offending fields.
+type Error struct {
+	Status  int          `json:"-"`
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Fields  []FieldError `json:"fields,omitempty"`
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+var codes = map[int]string{
+	http.StatusBadRequest:           "bad_request",
+	http.StatusUnauthorized:         "unauthorized",
+	http.StatusForbidden:            "forbidden",
+	http.StatusNotFound:             "not_found",
+	http.StatusConflict:             "conflict",
+	http.StatusPreconditionFailed:   "precondition_failed",
+	http.StatusUnsupportedMediaType: "unsupported_media_type",
+	http.StatusUnprocessableEntity:  "validation_failed",
+	http.StatusInternalServerError:  "internal",
+	http.StatusServiceUnavailable:   "unavailable",
+}
+
+func NewError(status int, message string) *Error {
+	code, ok := codes[status]
+	if !ok {
+		code = strings.ToLower(strings.ReplaceAll(http.StatusText(status), " ", "_"))
+	}
+
+	return &Error{Status: status, Code: code, Message: message}
+}
+
+// NotFound is the 404 of a missing record, what names the record.
+func NotFound(what string) *Error {
+	return NewError(http.StatusNotFound, what+" not found")
+}
+
+// Invalid is a 422 listing the given fields.
+func Invalid(message string, fields ...FieldError) *Error {
+	e := NewError(http.StatusUnprocessableEntity, message)
+	e.Fields = fields
+
+	return e
+}
+
+// Fail writes an error response with the given status and message.
+func Fail(c *gin.Context, status int, message string) {
+	Abort(c, NewError(status, message))
+}
+
+// Abort writes the error response for err:
+//
+//   - *Error as it is
+//   - sql.ErrNoRows as 404
+//   - validation errors of the repositories and of request binding as 422
+//   - conflicts and unique violations as 409
+//   - basic_repo.ErrVersionMismatch as 412
+//
+// Anything else is logged and answered with a plain 500, so database errors
+// don't reach clients.
+func Abort(c *gin.Context, err error) {
+	e := ToError(err)
+	if e.Status >= http.StatusInternalServerError {
+		log.Printf("%s %s: %v", c.Request.Method, c.FullPath(), err)
+	}
+
+	c.AbortWithStatusJSON(e.Status, struct {
+		Status bool `json:"status"`
+		*Error
+	}{false, e})
+}
+
+// BindError wraps an error of ShouldBind*: malformed bodies are a 400,
+// bodies failing the binding rules a 422.
+func BindError(err error) error {
+	var (
+		validation validator.ValidationErrors
+		typeErr    *json.UnmarshalTypeError
+	)
+
+	switch {
+	case errors.As(err, &validation):
+		fields := make([]FieldError, 0, len(validation))
+		for _, fe := range validation {
+			fields = append(fields, FieldError{Field: fieldPath(fe), Message: ruleMessage(fe)})
+		}
+		return Invalid("request validation failed", fields...)
+	case errors.As(err, &typeErr):
+		return Invalid("request validation failed", FieldError{
+			Field:   typeErr.Field,
+			Message: "must be " + typeErr.Type.String(),
+		})
+	default:
+		return NewError(http.StatusBadRequest, "invalid request: "+err.Error())
+	}
+}
+
+// ToError classifies err the way Abort answers it, for the transports
+// other than JSON over HTTP.
+func ToError(err error) *Error {
+	var (
+		e          *Error
+		invalid    *basic_repo.ValidationError
+		conflict   *basic_repo.ConflictError
+		pgErr      pgdriver.Error
+		validation validator.ValidationErrors
+	)
+
+	switch {
+	case errors.As(err, &e):
+		return e
+	case errors.Is(err, sql.ErrNoRows):
+		return NotFound("record")
+	case errors.Is(err, basic_repo.ErrVersionMismatch):
+		return NewError(http.StatusPreconditionFailed, err.Error())
+	case errors.As(err, &invalid):
+		if invalid.Field == "" {
+			return Invalid(invalid.Message)
+		}
+		return Invalid(invalid.Message, FieldError{Field: invalid.Field, Message: invalid.Message})
+	case errors.As(err, &conflict):
+		return NewError(http.StatusConflict, conflict.Message)
+	case errors.As(err, &validation):
+		return ToError(BindError(err))
+	case errors.As(err, &pgErr):
+		return fromPostgres(pgErr)
+	}
+
+	return NewError(http.StatusInternalServerError, "internal server error")
+}
+
+// keyDetail matches the detail of unique and foreign key violations,
+// "Key (email)=(a@b.c) already exists."
+var keyDetail = regexp.MustCompile(`^Key \(([^)]+)\)=`)
+
+func fromPostgres(pgErr pgdriver.Error) *Error {
+	field := pgErr.Field('c')
+	if m := keyDetail.FindStringSubmatch(pgErr.Field('D')); m != nil {
+		field = m[1]
+	}
+
+	switch pgErr.Field('C') {
+	case "23505":
+		e := NewError(http.StatusConflict, "a record with this "+orRecord(field)+" already exists")
+		e.Fields = fieldErrors(field, "already exists")
+		return e
+	case "23503":
+		return Invalid("referenced record does not exist", fieldErrors(field, "references a missing record")...)
+	case "23502":
+		return Invalid(orRecord(field)+" is required", fieldErrors(field, "is required")...)
+	case "23514", "22P02", "22007", "22008":
+		return Invalid("invalid value", fieldErrors(field, "is invalid")...)
+	}
+
+	return NewError(http.StatusInternalServerError, "internal server error")
+}
+
+func orRecord(field string) string {
+	if field == "" {
+		return "value"
+	}
+
+	return field
+}
+
+func fieldErrors(field, message string) []FieldError {
+	if field == "" {
+		return nil
+	}
+
+	return []FieldError{{Field: field, Message: message}}
+}
+
+// fieldPath is the path of the field from the request body, such as
+// "items[2].due_date" for fields of nested structs.
+func fieldPath(fe validator.FieldError) string {
+	_, path, found := strings.Cut(fe.Namespace(), ".")
+	if !found {
+		return fe.Field()
+	}
+
+	return path
+}
+
+func ruleMessage(fe validator.FieldError) string {
+	unit := ""
+	switch fe.Kind() {
+	case reflect.String:
+		unit = " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		unit = " items"
+	}
+
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "oneof":
+		return "must be one of " + strings.ReplaceAll(fe.Param(), " ", ", ")
+	case "min", "gte":
+		return "must be at least " + fe.Param() + unit
+	case "max", "lte":
+		return "must be at most " + fe.Param() + unit
+	case "email":
+		return "must be an email address"
+	case "date":
+		return "must be a date (YYYY-MM-DD)"
+	case "url", "http_url":
+		return "must be an absolute url"
+	}
+
+	return fmt.Sprintf("failed the %s rule", fe.Tag())
+}
@@ -0,0 +1,60 @@
+package basic_controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ETag returns the entity tag of a detail response. It holds the record
+// version, which If-Match is checked against, and a digest of the body, so
+// derived data such as task stats also invalidates cached copies.
+func ETag(version int, body interface{}) string {
+	data, _ := json.Marshal(body)
+	sum := sha256.Sum256(data)
+
+	return fmt.Sprintf(`"%d-%s"`, version, hex.EncodeToString(sum[:8]))
+}
+
+// NotModified sets the ETag header and answers 304 when it matches
+// If-None-Match. It reports whether the response was written.
+func NotModified(c *gin.Context, etag string) bool {
+	c.Header("ETag", etag)
+
+	for _, tag := range strings.Split(c.GetHeader("If-None-Match"), ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == etag {
+			c.Status(http.StatusNotModified)
+			return true
+		}
+	}
+
+	return false
+}
+
+// IfMatch returns the version held by the If-Match header, nil when it is
+// missing or "*". It writes the error response itself.
+func IfMatch(c *gin.Context) (*int, bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return nil, true
+	}
+
+	tag := strings.Trim(strings.TrimPrefix(header, "W/"), `"`)
+	if i := strings.IndexByte(tag, '-'); i >= 0 {
+		tag = tag[:i]
+	}
+
+	version, err := strconv.Atoi(tag)
+	if err != nil || strings.Contains(header, ",") {
+		Fail(c, http.StatusBadRequest, "If-Match must be a single entity tag of this record!")
+		return nil, false
+	}
+
+	return &version, true
+}
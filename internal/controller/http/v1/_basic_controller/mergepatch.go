@@ -0,0 +1,43 @@
+package basic_controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	basic_repo "task-management2/internal/repository/postgres/_basic_repo"
+	"task-management2/internal/util/mergepatch"
+)
+
+// BasicMergePatch reads a merge patch request: the id path parameter, a body
+// sent as application/merge-patch+json or application/json and If-Match.
+// It writes the error response itself.
+func BasicMergePatch(c *gin.Context) (basic_repo.MergePatch, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		Fail(c, http.StatusBadRequest, "id must be number!")
+		return basic_repo.MergePatch{}, false
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(c.ContentType())
+	if mediaType != mergepatch.ContentType && mediaType != gin.MIMEJSON {
+		Fail(c, http.StatusUnsupportedMediaType, "Content-Type must be "+mergepatch.ContentType+"!")
+		return basic_repo.MergePatch{}, false
+	}
+
+	patch, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		Fail(c, http.StatusBadRequest, "invalid request: "+err.Error())
+		return basic_repo.MergePatch{}, false
+	}
+
+	data := basic_repo.MergePatch{Id: &id, Patch: patch}
+
+	var ok bool
+	if data.Version, ok = IfMatch(c); !ok {
+		return basic_repo.MergePatch{}, false
+	}
+
+	return data, true
+}
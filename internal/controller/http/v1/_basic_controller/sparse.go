@@ -0,0 +1,71 @@
+package basic_controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"slices"
+	"strings"
+	basic_repo "task-management2/internal/repository/postgres/_basic_repo"
+)
+
+// Sparse reads the fields and include query parameters of a read, comma
+// separated names such as ?fields=id,name,status&include=assignee.
+func Sparse(c *gin.Context) basic_repo.Sparse {
+	return basic_repo.Sparse{
+		Fields:  names(c.Query("fields")),
+		Include: names(c.Query("include")),
+	}
+}
+
+func names(value string) []string {
+	var result []string
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" && !slices.Contains(result, name) {
+			result = append(result, name)
+		}
+	}
+
+	return result
+}
+
+// Project drops the keys of v, a record or a list of records, that sparse
+// didn't ask for, so the response holds only the fields and the embedded
+// relations. v is returned as it is when every field was asked for.
+func Project(v interface{}, sparse basic_repo.Sparse) interface{} {
+	if len(sparse.Fields) == 0 {
+		return v
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+
+	keep := func(record map[string]json.RawMessage) map[string]json.RawMessage {
+		for key := range record {
+			if !slices.Contains(sparse.Fields, key) && !slices.Contains(sparse.Include, key) {
+				delete(record, key)
+			}
+		}
+		return record
+	}
+
+	if bytes.HasPrefix(data, []byte("[")) {
+		var records []map[string]json.RawMessage
+		if err := json.Unmarshal(data, &records); err != nil {
+			return v
+		}
+		for i := range records {
+			records[i] = keep(records[i])
+		}
+		return records
+	}
+
+	var record map[string]json.RawMessage
+	if err := json.Unmarshal(data, &record); err != nil || record == nil {
+		return v
+	}
+
+	return keep(record)
+}
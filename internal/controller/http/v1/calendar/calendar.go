@@ -0,0 +1,260 @@
+package calendar
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	basic_controller "task-management2/internal/controller/http/v1/_basic_controller"
+	"task-management2/internal/repository/postgres/calendar"
+	"task-management2/internal/util/ical"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const prodId = "-//task-management2//Task Calendar//EN"
+
+type Controller struct {
+	useCase        Repository
+	projectUseCase ProjectRepository
+}
+
+func NewController(useCase Repository, projectUseCase ProjectRepository) *Controller {
+	return &Controller{
+		useCase:        useCase,
+		projectUseCase: projectUseCase,
+	}
+}
+
+func (cl *Controller) CreateToken(c *gin.Context) {
+	var data calendar.CreateToken
+	if err := c.ShouldBindUri(&data); err != nil {
+		basic_controller.Fail(c, http.StatusBadRequest, "user_id must be number!")
+		return
+	}
+
+	if !cl.owner(c, *data.UserId) {
+		return
+	}
+
+	token, err := cl.useCase.CreateToken(c.Request.Context(), data)
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "ok!",
+		"status":  true,
+		"data": gin.H{
+			"token":        token,
+			"user_feed":    fmt.Sprintf("/api/v1/calendar/user/%d.ics?token=%s", token.UserId, token.Token),
+			"project_feed": fmt.Sprintf("/api/v1/calendar/project/{project_id}.ics?token=%s", token.Token),
+		},
+	})
+}
+
+func (cl *Controller) RevokeToken(c *gin.Context) {
+	userId, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		basic_controller.Fail(c, http.StatusBadRequest, "user_id must be number!")
+		return
+	}
+
+	if !cl.owner(c, userId) {
+		return
+	}
+
+	err = cl.useCase.RevokeToken(c.Request.Context(), userId)
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "ok!",
+		"status":  true,
+	})
+}
+
+func (cl *Controller) UserFeed(c *gin.Context) {
+	id, ok := feedId(c)
+	if !ok {
+		return
+	}
+
+	tokenUserId, ok := basic_controller.Authenticate(c, cl.useCase)
+	if !ok {
+		return
+	}
+
+	if tokenUserId != id {
+		basic_controller.Fail(c, http.StatusForbidden, "token does not belong to this user")
+		return
+	}
+
+	items, err := cl.useCase.GetFeed(c.Request.Context(), calendar.Filter{UserId: &id})
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	writeFeed(c, "My tasks", items)
+}
+
+func (cl *Controller) ProjectFeed(c *gin.Context) {
+	id, ok := feedId(c)
+	if !ok {
+		return
+	}
+
+	userId, ok := basic_controller.Authenticate(c, cl.useCase)
+	if !ok {
+		return
+	}
+
+	access, err := cl.projectUseCase.GetAccess(c.Request.Context(), userId)
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+	if !access.CanView(&id) {
+		basic_controller.Fail(c, http.StatusForbidden, "token does not give access to this project")
+		return
+	}
+
+	project, err := cl.projectUseCase.GetById(c.Request.Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		basic_controller.Fail(c, http.StatusNotFound, "project not found")
+		return
+	}
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	items, err := cl.useCase.GetFeed(c.Request.Context(), calendar.Filter{ProjectId: &id})
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	writeFeed(c, project.Name, items)
+}
+
+// owner checks the request is made by userId, with their token or, to
+// get the first one, their email and password as basic auth. It writes the
+// error response itself.
+func (cl *Controller) owner(c *gin.Context, userId int) bool {
+	var id int
+	if email, password, ok := c.Request.BasicAuth(); ok {
+		var err error
+		id, err = cl.useCase.GetUserIdByPassword(c.Request.Context(), email, password)
+		if errors.Is(err, sql.ErrNoRows) {
+			basic_controller.Fail(c, http.StatusUnauthorized, "invalid email or password")
+			return false
+		}
+		if err != nil {
+			basic_controller.Abort(c, err)
+			return false
+		}
+	} else if id, ok = basic_controller.Authenticate(c, cl.useCase); !ok {
+		return false
+	}
+
+	if id != userId {
+		basic_controller.Fail(c, http.StatusForbidden, "the token of another user can't be managed")
+		return false
+	}
+
+	return true
+}
+
+func feedId(c *gin.Context) (int, bool) {
+	file := c.Param("file")
+	if !strings.HasSuffix(file, ".ics") {
+		basic_controller.Fail(c, http.StatusNotFound, "feed not found")
+		return 0, false
+	}
+
+	id, err := strconv.Atoi(strings.TrimSuffix(file, ".ics"))
+	if err != nil {
+		basic_controller.Fail(c, http.StatusBadRequest, "id must be number!")
+		return 0, false
+	}
+
+	return id, true
+}
+
+func writeFeed(c *gin.Context, name string, items []calendar.FeedItem) {
+	cal := ical.NewCalendar(prodId, name)
+	now := time.Now()
+
+	for _, item := range items {
+		due, err := time.Parse("2006-01-02", item.DueDate)
+		if err != nil {
+			continue
+		}
+
+		cal.Begin("VTODO")
+		cal.Raw("UID", fmt.Sprintf("task-%d@task-management2", item.Id))
+		cal.DateTime("DTSTAMP", now)
+		if item.CreatedAt != nil {
+			cal.DateTime("CREATED", *item.CreatedAt)
+		}
+		if item.UpdatedAt != nil {
+			cal.DateTime("LAST-MODIFIED", *item.UpdatedAt)
+		}
+		cal.Date("DUE", due)
+		cal.Text("SUMMARY", item.Name)
+		cal.Text("DESCRIPTION", description(item))
+		cal.Text("CATEGORIES", item.ProjectName)
+		cal.Raw("STATUS", todoStatus(item.Status))
+		cal.Raw("PRIORITY", strconv.Itoa(todoPriority(item.Priority)))
+		if item.Status == "completed" {
+			cal.Raw("PERCENT-COMPLETE", "100")
+		}
+		cal.Text("X-TASK-STATUS", item.Status)
+		cal.Text("X-TASK-PRIORITY", item.Priority)
+		cal.Text("X-TASK-PROJECT", item.ProjectName)
+		cal.End("VTODO")
+	}
+
+	c.Header("Content-Disposition", "inline; filename=tasks.ics")
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", cal.Bytes())
+}
+
+func description(item calendar.FeedItem) string {
+	text := fmt.Sprintf("Project: %s\nStatus: %s\nPriority: %s", item.ProjectName, item.Status, item.Priority)
+	if item.Description != "" {
+		text += "\n\n" + item.Description
+	}
+
+	return text
+}
+
+func todoStatus(status string) string {
+	switch status {
+	case "completed":
+		return "COMPLETED"
+	case "in_progress":
+		return "IN-PROCESS"
+	default:
+		return "NEEDS-ACTION"
+	}
+}
+
+// todoPriority maps task priority onto the 1 (highest) - 9 (lowest) scale.
+func todoPriority(priority string) int {
+	switch priority {
+	case "high":
+		return 1
+	case "low":
+		return 9
+	default:
+		return 5
+	}
+}
@@ -0,0 +1,20 @@
+package calendar
+
+import (
+	"context"
+	"task-management2/internal/repository/postgres/calendar"
+	"task-management2/internal/repository/postgres/projects"
+)
+
+type Repository interface {
+	CreateToken(ctx context.Context, data calendar.CreateToken) (calendar.Token, error)
+	RevokeToken(ctx context.Context, userId int) error
+	GetUserIdByToken(ctx context.Context, token string) (int, error)
+	GetUserIdByPassword(ctx context.Context, email, password string) (int, error)
+	GetFeed(ctx context.Context, filter calendar.Filter) ([]calendar.FeedItem, error)
+}
+
+type ProjectRepository interface {
+	GetById(ctx context.Context, id int) (projects.Detail, error)
+	GetAccess(ctx context.Context, userId int) (projects.Access, error)
+}
@@ -0,0 +1,117 @@
+package comments
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	basic_controller "task-management2/internal/controller/http/v1/_basic_controller"
+	basic_repo "task-management2/internal/repository/postgres/_basic_repo"
+	"task-management2/internal/repository/postgres/comments"
+)
+
+type Controller struct {
+	useCase Repository
+}
+
+func NewController(useCase Repository) *Controller {
+	return &Controller{useCase: useCase}
+}
+
+func (cl *Controller) GetList(c *gin.Context) {
+	var filter comments.Filter
+	query := c.Request.URL.Query()
+
+	taskId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		basic_controller.Fail(c, http.StatusBadRequest, "id must be number!")
+		return
+	}
+	filter.TaskId = &taskId
+
+	defaultOffset := 0
+	defaultLimit := 50
+	filter.Offset = &defaultOffset
+	filter.Limit = &defaultLimit
+
+	limitQ := query["limit"]
+	if len(limitQ) > 0 {
+		queryInt, err := strconv.Atoi(limitQ[0])
+		if err != nil {
+			basic_controller.Fail(c, http.StatusBadRequest, "limit must be number!")
+			return
+		}
+		filter.Limit = &queryInt
+	}
+
+	offsetQ := query["offset"]
+	if len(offsetQ) > 0 {
+		page, err := strconv.Atoi(offsetQ[0])
+		if err != nil {
+			basic_controller.Fail(c, http.StatusBadRequest, "offset must be number!")
+			return
+		}
+		offset := (page - 1) * *filter.Limit
+		filter.Offset = &offset
+	}
+
+	list, count, err := cl.useCase.GetAll(c.Request.Context(), filter)
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  list,
+		"count": count,
+	})
+}
+
+func (cl *Controller) Create(c *gin.Context) {
+	taskId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		basic_controller.Fail(c, http.StatusBadRequest, "id must be number!")
+		return
+	}
+
+	var request comments.Create
+	if err := c.ShouldBindJSON(&request); err != nil {
+		basic_controller.Abort(c, basic_controller.BindError(err))
+		return
+	}
+	request.TaskId = &taskId
+
+	detail, err := cl.useCase.Create(c.Request.Context(), request)
+	if errors.Is(err, sql.ErrNoRows) {
+		basic_controller.Fail(c, http.StatusNotFound, "task not found")
+		return
+	}
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data": detail,
+	})
+}
+
+func (cl *Controller) Delete(c *gin.Context) {
+	commentId, err := strconv.Atoi(c.Param("comment_id"))
+	if err != nil {
+		basic_controller.Fail(c, http.StatusBadRequest, "comment_id must be number!")
+		return
+	}
+
+	err = cl.useCase.Delete(c.Request.Context(), basic_repo.Delete{Id: &commentId})
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "ok!",
+		"status":  true,
+	})
+}
@@ -0,0 +1,14 @@
+package comments
+
+import (
+	"context"
+	"task-management2/internal/entity"
+	basic_repo "task-management2/internal/repository/postgres/_basic_repo"
+	"task-management2/internal/repository/postgres/comments"
+)
+
+type Repository interface {
+	GetAll(ctx context.Context, filter comments.Filter) ([]comments.List, int, error)
+	Create(ctx context.Context, data comments.Create) (entity.TaskComments, error)
+	Delete(ctx context.Context, data basic_repo.Delete) error
+}
@@ -2,14 +2,18 @@ package export
 
 import (
 	"fmt"
+	"math"
 	"net/http"
 	"strconv"
+	basic_controller "task-management2/internal/controller/http/v1/_basic_controller"
 	"task-management2/internal/controller/http/v1/projects"
 	"task-management2/internal/controller/http/v1/tasks"
 	"task-management2/internal/controller/http/v1/users"
+	"task-management2/internal/controller/http/v1/worklogs"
 	projects2 "task-management2/internal/repository/postgres/projects"
 	tasks2 "task-management2/internal/repository/postgres/tasks"
 	users2 "task-management2/internal/repository/postgres/users"
+	worklogs2 "task-management2/internal/repository/postgres/worklogs"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -20,13 +24,15 @@ type Controller struct {
 	userUseCase    users.Repository
 	taskUseCase    tasks.Repository
 	projectUseCase projects.Repository
+	worklogUseCase worklogs.Repository
 }
 
-func NewController(userUseCase users.Repository, taskUseCase tasks.Repository, projectUseCase projects.Repository) *Controller {
+func NewController(userUseCase users.Repository, taskUseCase tasks.Repository, projectUseCase projects.Repository, worklogUseCase worklogs.Repository) *Controller {
 	return &Controller{
 		userUseCase:    userUseCase,
 		taskUseCase:    taskUseCase,
 		projectUseCase: projectUseCase,
+		worklogUseCase: worklogUseCase,
 	}
 }
 
@@ -35,9 +41,7 @@ func (h *Controller) ExportToExcel(c *gin.Context) {
 
 	userList, _, err := h.userUseCase.GetAll(ctx, users2.Filter{})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": fmt.Sprintf("Error getting users: %v", err),
-		})
+		basic_controller.Abort(c, fmt.Errorf("error getting users: %w", err))
 		return
 	}
 
@@ -54,9 +58,7 @@ func (h *Controller) ExportToExcel(c *gin.Context) {
 
 	projectList, err := h.projectUseCase.GetProjectsWithStats(ctx, projects2.Filter{})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": fmt.Sprintf("Error getting projects: %v", err),
-		})
+		basic_controller.Abort(c, fmt.Errorf("error getting projects: %w", err))
 		return
 	}
 
@@ -98,9 +100,7 @@ func (h *Controller) ExportToExcel(c *gin.Context) {
 
 	taskList, _, err := h.taskUseCase.GetAll(ctx, tasks2.Filter{})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": fmt.Sprintf("Error getting tasks: %v", err),
-		})
+		basic_controller.Abort(c, fmt.Errorf("error getting tasks: %w", err))
 		return
 	}
 
@@ -194,6 +194,35 @@ func (h *Controller) ExportToExcel(c *gin.Context) {
 		f.SetCellValue(projectSheet, fmt.Sprintf("F%d", row), fmt.Sprintf("%.2f%%", p.Progress))
 	}
 
+	timesheet, err := h.worklogUseCase.GetTimesheet(ctx, worklogs2.Filter{})
+	if err != nil {
+		basic_controller.Abort(c, fmt.Errorf("error getting timesheet: %w", err))
+		return
+	}
+
+	timesheetSheet := "Timesheet"
+	f.NewSheet(timesheetSheet)
+	f.SetCellValue(timesheetSheet, "A1", "Date")
+	f.SetCellValue(timesheetSheet, "B1", "User")
+	f.SetCellValue(timesheetSheet, "C1", "Project")
+	f.SetCellValue(timesheetSheet, "D1", "Task ID")
+	f.SetCellValue(timesheetSheet, "E1", "Task")
+	f.SetCellValue(timesheetSheet, "F1", "Hours")
+
+	for i, t := range timesheet.Rows {
+		row := i + 2
+		f.SetCellValue(timesheetSheet, fmt.Sprintf("A%d", row), t.Date)
+		f.SetCellValue(timesheetSheet, fmt.Sprintf("B%d", row), t.UserName)
+		f.SetCellValue(timesheetSheet, fmt.Sprintf("C%d", row), t.ProjectName)
+		f.SetCellValue(timesheetSheet, fmt.Sprintf("D%d", row), t.TaskId)
+		f.SetCellValue(timesheetSheet, fmt.Sprintf("E%d", row), t.TaskName)
+		f.SetCellValue(timesheetSheet, fmt.Sprintf("F%d", row), hours(t.Duration))
+	}
+
+	totalRow := len(timesheet.Rows) + 2
+	f.SetCellValue(timesheetSheet, fmt.Sprintf("E%d", totalRow), "Total")
+	f.SetCellValue(timesheetSheet, fmt.Sprintf("F%d", totalRow), hours(timesheet.Total))
+
 	f.SetActiveSheet(0)
 
 	filename := fmt.Sprintf("task_management_export_%s.xlsx", time.Now().Format("2006-01-02_15-04-05"))
@@ -202,9 +231,7 @@ func (h *Controller) ExportToExcel(c *gin.Context) {
 	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
 
 	if err := f.Write(c.Writer); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": fmt.Sprintf("Error writing file: %v", err),
-		})
+		basic_controller.Abort(c, fmt.Errorf("error writing file: %w", err))
 		return
 	}
 }
@@ -212,19 +239,24 @@ func (h *Controller) ExportToExcel(c *gin.Context) {
 func (h *Controller) ExportProject(c *gin.Context) {
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID"})
+		basic_controller.Fail(c, http.StatusBadRequest, "Invalid project ID")
 		return
 	}
 
 	project, err := h.projectUseCase.GetById(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		basic_controller.Abort(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, project)
 }
 
+// hours converts seconds to hours rounded to two decimals.
+func hours(seconds int) float64 {
+	return math.Round(float64(seconds)/36) / 100
+}
+
 func getValueOrEmpty(v interface{}) interface{} {
 	if v == nil {
 		return ""
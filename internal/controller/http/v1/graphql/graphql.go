@@ -0,0 +1,83 @@
+package graphql
+
+import (
+	_ "embed"
+	"github.com/gin-gonic/gin"
+	"github.com/graph-gophers/graphql-go"
+	"log"
+	"net/http"
+	basic_controller "task-management2/internal/controller/http/v1/_basic_controller"
+)
+
+const maxDepth = 10
+
+//go:embed schema.graphql
+var Schema string
+
+type Controller struct {
+	users    UserRepository
+	projects ProjectRepository
+	tasks    TaskRepository
+
+	schema *graphql.Schema
+}
+
+func NewController(users UserRepository, projects ProjectRepository, tasks TaskRepository) *Controller {
+	cl := &Controller{
+		users:    users,
+		projects: projects,
+		tasks:    tasks,
+	}
+	cl.schema = graphql.MustParseSchema(Schema, &resolver{cl: cl}, graphql.MaxDepth(maxDepth))
+
+	return cl
+}
+
+type Request struct {
+	Query         string                 `json:"query" binding:"required"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Query runs a GraphQL query. The relations of a response are loaded in
+// batches, one query per relation and level rather than one per row. Errors
+// of the query are part of the 200 response, as GraphQL clients expect.
+func (cl *Controller) Query(c *gin.Context) {
+	var request Request
+	if err := c.ShouldBindJSON(&request); err != nil {
+		basic_controller.Abort(c, basic_controller.BindError(err))
+		return
+	}
+
+	ctx := withLoaders(c.Request.Context(), cl)
+
+	c.JSON(http.StatusOK, cl.schema.Exec(ctx, request.Query, request.OperationName, request.Variables))
+}
+
+// queryError is a resolver error, classified like the error responses of
+// the REST API. Its code and fields are the extensions of the GraphQL error.
+type queryError struct {
+	err *basic_controller.Error
+}
+
+func (e queryError) Error() string {
+	return e.err.Message
+}
+
+func (e queryError) Extensions() map[string]interface{} {
+	extensions := map[string]interface{}{"code": e.err.Code}
+	if len(e.err.Fields) > 0 {
+		extensions["fields"] = e.err.Fields
+	}
+
+	return extensions
+}
+
+func fail(err error) error {
+	e := basic_controller.ToError(err)
+	if e.Status >= http.StatusInternalServerError {
+		log.Printf("POST /api/graphql: %v", err)
+	}
+
+	return queryError{e}
+}
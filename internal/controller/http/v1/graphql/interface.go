@@ -0,0 +1,27 @@
+package graphql
+
+import (
+	"context"
+	"task-management2/internal/entity"
+	"task-management2/internal/repository/postgres/projects"
+	"task-management2/internal/repository/postgres/tasks"
+	"task-management2/internal/repository/postgres/users"
+)
+
+type UserRepository interface {
+	GetAllUsers(ctx context.Context, filter users.Filter) ([]users.User, int, error)
+	GetByIds(ctx context.Context, ids []int) ([]users.User, error)
+}
+
+type ProjectRepository interface {
+	GetProjectsWithStats(ctx context.Context, filter projects.Filter) ([]projects.List, error)
+	GetProjectsCount(ctx context.Context, filter projects.Filter) (int, error)
+	GetByIds(ctx context.Context, ids []int) ([]entity.Projects, error)
+}
+
+type TaskRepository interface {
+	GetAll(ctx context.Context, filter tasks.Filter) ([]entity.Tasks, int, error)
+	GetById(ctx context.Context, id int) (entity.Tasks, error)
+	GetByProjects(ctx context.Context, projectIds []int, limit *int) ([]entity.Tasks, error)
+	GetByAssignees(ctx context.Context, userIds []int, limit *int) ([]entity.Tasks, error)
+}
@@ -0,0 +1,152 @@
+package graphql
+
+import (
+	"context"
+	"sync"
+	"task-management2/internal/entity"
+	"task-management2/internal/pkg/dataloader"
+	"task-management2/internal/repository/postgres/users"
+)
+
+type loadersKey struct{}
+
+// loaders batch the relations of one request. Every user or project id a
+// response refers to is loaded along with the first one asked for, and the
+// tasks of every user or project in the response along with the first
+// user's or project's.
+type loaders struct {
+	cl *Controller
+
+	userIds    *dataloader.Keys[int]
+	projectIds *dataloader.Keys[int]
+	users      *dataloader.Loader[int, users.User]
+	projects   *dataloader.Loader[int, entity.Projects]
+
+	// the users and projects of the response, whose tasks may be asked for
+	userNodes    *dataloader.Keys[int]
+	projectNodes *dataloader.Keys[int]
+
+	mu           sync.Mutex
+	userTasks    map[int]*dataloader.Loader[int, []entity.Tasks]
+	projectTasks map[int]*dataloader.Loader[int, []entity.Tasks]
+}
+
+func withLoaders(ctx context.Context, cl *Controller) context.Context {
+	l := &loaders{
+		cl:           cl,
+		userIds:      dataloader.NewKeys[int](),
+		projectIds:   dataloader.NewKeys[int](),
+		userNodes:    dataloader.NewKeys[int](),
+		projectNodes: dataloader.NewKeys[int](),
+		userTasks:    map[int]*dataloader.Loader[int, []entity.Tasks]{},
+		projectTasks: map[int]*dataloader.Loader[int, []entity.Tasks]{},
+	}
+
+	l.users = dataloader.New(l.userIds, func(ctx context.Context, ids []int) (map[int]users.User, error) {
+		list, err := cl.users.GetByIds(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		result := make(map[int]users.User, len(list))
+		for _, user := range list {
+			result[int(*user.Id)] = user
+		}
+		l.addUsers(list...)
+
+		return result, nil
+	})
+
+	l.projects = dataloader.New(l.projectIds, func(ctx context.Context, ids []int) (map[int]entity.Projects, error) {
+		list, err := cl.projects.GetByIds(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		result := make(map[int]entity.Projects, len(list))
+		for _, project := range list {
+			result[project.Id] = project
+		}
+		l.addProjects(list...)
+
+		return result, nil
+	})
+
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+func (l *loaders) addUsers(list ...users.User) {
+	for _, user := range list {
+		l.userNodes.Add(int(*user.Id))
+	}
+}
+
+func (l *loaders) addProjects(list ...entity.Projects) {
+	for _, project := range list {
+		l.projectNodes.Add(project.Id)
+		if project.OwnerId != nil {
+			l.userIds.Add(*project.OwnerId)
+		}
+	}
+}
+
+func (l *loaders) addTasks(list ...entity.Tasks) {
+	for _, task := range list {
+		if task.ProjectId != nil {
+			l.projectIds.Add(*task.ProjectId)
+		}
+		if task.AssignedTo != nil {
+			l.userIds.Add(*task.AssignedTo)
+		}
+	}
+}
+
+// tasksOf is the loader of the tasks of users or projects, one per limit,
+// 0 for none.
+func (l *loaders) tasksOf(byUser bool, limit int) *dataloader.Loader[int, []entity.Tasks] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	loaders, siblings, column := l.projectTasks, l.projectNodes, func(task entity.Tasks) *int { return task.ProjectId }
+	if byUser {
+		loaders, siblings, column = l.userTasks, l.userNodes, func(task entity.Tasks) *int { return task.AssignedTo }
+	}
+
+	if loader, ok := loaders[limit]; ok {
+		return loader
+	}
+
+	loader := dataloader.New(siblings, func(ctx context.Context, ids []int) (map[int][]entity.Tasks, error) {
+		var max *int
+		if limit > 0 {
+			max = &limit
+		}
+
+		get := l.cl.tasks.GetByProjects
+		if byUser {
+			get = l.cl.tasks.GetByAssignees
+		}
+
+		list, err := get(ctx, ids, max)
+		if err != nil {
+			return nil, err
+		}
+
+		result := make(map[int][]entity.Tasks, len(ids))
+		for _, task := range list {
+			if id := column(task); id != nil {
+				result[*id] = append(result[*id], task)
+			}
+		}
+		l.addTasks(list...)
+
+		return result, nil
+	})
+	loaders[limit] = loader
+
+	return loader
+}
@@ -0,0 +1,370 @@
+package graphql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/graph-gophers/graphql-go"
+	"net/http"
+	"strconv"
+	basic_controller "task-management2/internal/controller/http/v1/_basic_controller"
+	"task-management2/internal/entity"
+	"task-management2/internal/repository/postgres/projects"
+	"task-management2/internal/repository/postgres/tasks"
+	"task-management2/internal/repository/postgres/users"
+	"time"
+)
+
+const defaultLimit = 50
+
+// resolver resolves the Query type of schema.graphql.
+type resolver struct {
+	cl *Controller
+}
+
+func (r *resolver) Users(ctx context.Context, args struct{ Limit, Page *int32 }) (*userList, error) {
+	limit, offset, err := page(args.Limit, args.Page)
+	if err != nil {
+		return nil, err
+	}
+
+	list, count, err := r.cl.users.GetAllUsers(ctx, users.Filter{Limit: &limit, Offset: &offset})
+	if err != nil {
+		return nil, fail(err)
+	}
+	loadersFrom(ctx).addUsers(list...)
+
+	nodes := make([]*userResolver, 0, len(list))
+	for _, user := range list {
+		nodes = append(nodes, &userResolver{user})
+	}
+
+	return &userList{count: count, nodes: nodes}, nil
+}
+
+func (r *resolver) User(ctx context.Context, args struct{ Id graphql.ID }) (*userResolver, error) {
+	id, err := parseId("id", args.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return loadUser(ctx, &id)
+}
+
+func (r *resolver) Projects(ctx context.Context, args struct {
+	Limit, Page *int32
+	OwnerId     *graphql.ID
+}) (*projectList, error) {
+	limit, offset, err := page(args.Limit, args.Page)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := projects.Filter{Limit: &limit, Offset: &offset}
+	if args.OwnerId != nil {
+		ownerId, err := parseId("ownerId", *args.OwnerId)
+		if err != nil {
+			return nil, err
+		}
+		filter.OwnerId = &ownerId
+	}
+
+	list, err := r.cl.projects.GetProjectsWithStats(ctx, filter)
+	if err != nil {
+		return nil, fail(err)
+	}
+
+	count, err := r.cl.projects.GetProjectsCount(ctx, filter)
+	if err != nil {
+		return nil, fail(err)
+	}
+
+	nodes := make([]*projectResolver, 0, len(list))
+	for _, item := range list {
+		project := entity.Projects{Name: &item.Name, Description: &item.Description, OwnerId: &item.OwnerId}
+		project.Id = item.Id
+
+		loadersFrom(ctx).addProjects(project)
+		nodes = append(nodes, &projectResolver{project})
+	}
+
+	return &projectList{count: count, nodes: nodes}, nil
+}
+
+func (r *resolver) Project(ctx context.Context, args struct{ Id graphql.ID }) (*projectResolver, error) {
+	id, err := parseId("id", args.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return loadProject(ctx, &id)
+}
+
+func (r *resolver) Tasks(ctx context.Context, args struct {
+	Limit, Page                      *int32
+	ProjectId, SprintId, MilestoneId *graphql.ID
+}) (*taskList, error) {
+	limit, offset, err := page(args.Limit, args.Page)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := tasks.Filter{Limit: &limit, Offset: &offset}
+	for _, arg := range []struct {
+		name  string
+		value *graphql.ID
+		id    **int
+	}{
+		{"projectId", args.ProjectId, &filter.ProjectId},
+		{"sprintId", args.SprintId, &filter.SprintId},
+		{"milestoneId", args.MilestoneId, &filter.MilestoneId},
+	} {
+		if arg.value == nil {
+			continue
+		}
+		id, err := parseId(arg.name, *arg.value)
+		if err != nil {
+			return nil, err
+		}
+		*arg.id = &id
+	}
+
+	list, count, err := r.cl.tasks.GetAll(ctx, filter)
+	if err != nil {
+		return nil, fail(err)
+	}
+
+	return &taskList{count: count, nodes: taskNodes(ctx, list)}, nil
+}
+
+func (r *resolver) Task(ctx context.Context, args struct{ Id graphql.ID }) (*taskResolver, error) {
+	id, err := parseId("id", args.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := r.cl.tasks.GetById(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fail(err)
+	}
+	loadersFrom(ctx).addTasks(task)
+
+	return &taskResolver{task}, nil
+}
+
+type userList struct {
+	count int
+	nodes []*userResolver
+}
+
+func (l *userList) Count() int32           { return int32(l.count) }
+func (l *userList) Nodes() []*userResolver { return l.nodes }
+
+type projectList struct {
+	count int
+	nodes []*projectResolver
+}
+
+func (l *projectList) Count() int32              { return int32(l.count) }
+func (l *projectList) Nodes() []*projectResolver { return l.nodes }
+
+type taskList struct {
+	count int
+	nodes []*taskResolver
+}
+
+func (l *taskList) Count() int32           { return int32(l.count) }
+func (l *taskList) Nodes() []*taskResolver { return l.nodes }
+
+type userResolver struct {
+	user users.User
+}
+
+func (r *userResolver) ID() graphql.ID {
+	return graphql.ID(strconv.FormatInt(*r.user.Id, 10))
+}
+
+func (r *userResolver) FullName() string { return value(r.user.FullName) }
+func (r *userResolver) Email() string    { return value(r.user.Email) }
+func (r *userResolver) Role() string     { return value(r.user.Role) }
+
+func (r *userResolver) Tasks(ctx context.Context, args struct{ Limit *int32 }) ([]*taskResolver, error) {
+	return loadTasks(ctx, true, int(*r.user.Id), args.Limit)
+}
+
+type projectResolver struct {
+	project entity.Projects
+}
+
+func (r *projectResolver) ID() graphql.ID {
+	return graphql.ID(strconv.Itoa(r.project.Id))
+}
+
+func (r *projectResolver) Name() string         { return value(r.project.Name) }
+func (r *projectResolver) Description() *string { return r.project.Description }
+
+func (r *projectResolver) Owner(ctx context.Context) (*userResolver, error) {
+	return loadUser(ctx, r.project.OwnerId)
+}
+
+func (r *projectResolver) Tasks(ctx context.Context, args struct{ Limit *int32 }) ([]*taskResolver, error) {
+	return loadTasks(ctx, false, r.project.Id, args.Limit)
+}
+
+type taskResolver struct {
+	task entity.Tasks
+}
+
+func (r *taskResolver) ID() graphql.ID {
+	return graphql.ID(strconv.Itoa(r.task.Id))
+}
+
+func (r *taskResolver) Name() string              { return value(r.task.Name) }
+func (r *taskResolver) Description() *string      { return r.task.Description }
+func (r *taskResolver) Status() string            { return value(r.task.Status) }
+func (r *taskResolver) Priority() string          { return value(r.task.Priority) }
+func (r *taskResolver) StartDate() *string        { return r.task.StartDate }
+func (r *taskResolver) DueDate() *string          { return r.task.DueDate }
+func (r *taskResolver) OriginalEstimate() *int32  { return int32Ptr(r.task.OriginalEstimate) }
+func (r *taskResolver) RemainingEstimate() *int32 { return int32Ptr(r.task.RemainingEstimate) }
+func (r *taskResolver) StoryPoints() *int32       { return int32Ptr(r.task.StoryPoints) }
+func (r *taskResolver) Version() int32            { return int32(r.task.Version) }
+
+func (r *taskResolver) Labels() []string {
+	if r.task.Labels == nil {
+		return []string{}
+	}
+
+	return r.task.Labels
+}
+
+func (r *taskResolver) CreatedAt() *string {
+	if r.task.CreatedAt == nil {
+		return nil
+	}
+
+	createdAt := r.task.CreatedAt.Format(time.RFC3339)
+	return &createdAt
+}
+
+func (r *taskResolver) Project(ctx context.Context) (*projectResolver, error) {
+	return loadProject(ctx, r.task.ProjectId)
+}
+
+func (r *taskResolver) Assignee(ctx context.Context) (*userResolver, error) {
+	return loadUser(ctx, r.task.AssignedTo)
+}
+
+func loadUser(ctx context.Context, id *int) (*userResolver, error) {
+	if id == nil {
+		return nil, nil
+	}
+
+	l := loadersFrom(ctx)
+	l.userIds.Add(*id)
+
+	user, err := l.users.Load(ctx, *id)
+	if err != nil {
+		return nil, fail(err)
+	}
+	if user.Id == nil {
+		return nil, nil
+	}
+
+	return &userResolver{user}, nil
+}
+
+func loadProject(ctx context.Context, id *int) (*projectResolver, error) {
+	if id == nil {
+		return nil, nil
+	}
+
+	l := loadersFrom(ctx)
+	l.projectIds.Add(*id)
+
+	project, err := l.projects.Load(ctx, *id)
+	if err != nil {
+		return nil, fail(err)
+	}
+	if project.Id == 0 {
+		return nil, nil
+	}
+
+	return &projectResolver{project}, nil
+}
+
+func loadTasks(ctx context.Context, byUser bool, id int, limit *int32) ([]*taskResolver, error) {
+	max := 0
+	if limit != nil {
+		if *limit < 1 {
+			return nil, fail(basic_controller.NewError(http.StatusBadRequest, "limit must be positive!"))
+		}
+		max = int(*limit)
+	}
+
+	list, err := loadersFrom(ctx).tasksOf(byUser, max).Load(ctx, id)
+	if err != nil {
+		return nil, fail(err)
+	}
+
+	return taskNodes(ctx, list), nil
+}
+
+func taskNodes(ctx context.Context, list []entity.Tasks) []*taskResolver {
+	loadersFrom(ctx).addTasks(list...)
+
+	nodes := make([]*taskResolver, 0, len(list))
+	for _, task := range list {
+		nodes = append(nodes, &taskResolver{task})
+	}
+
+	return nodes
+}
+
+// page converts the limit and page arguments of a list to a row offset.
+func page(limit, page *int32) (int, int, error) {
+	l, p := defaultLimit, 1
+	if limit != nil {
+		if *limit < 1 {
+			return 0, 0, fail(basic_controller.NewError(http.StatusBadRequest, "limit must be positive!"))
+		}
+		l = int(*limit)
+	}
+	if page != nil {
+		if *page < 1 {
+			return 0, 0, fail(basic_controller.NewError(http.StatusBadRequest, "page must be positive!"))
+		}
+		p = int(*page)
+	}
+
+	return l, (p - 1) * l, nil
+}
+
+func parseId(name string, id graphql.ID) (int, error) {
+	n, err := strconv.Atoi(string(id))
+	if err != nil {
+		return 0, fail(basic_controller.NewError(http.StatusBadRequest, name+" must be a number!"))
+	}
+
+	return n, nil
+}
+
+func value(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
+
+func int32Ptr(n *int) *int32 {
+	if n == nil {
+		return nil
+	}
+
+	v := int32(*n)
+	return &v
+}
@@ -0,0 +1,19 @@
+package notifications
+
+import (
+	"context"
+	"task-management2/internal/entity"
+	"task-management2/internal/repository/postgres/notifications"
+)
+
+type Repository interface {
+	GetAll(ctx context.Context, filter notifications.Filter) ([]entity.Notifications, int, error)
+	UnreadCount(ctx context.Context, userId int) (int, error)
+	MarkRead(ctx context.Context, id int) (entity.Notifications, error)
+	MarkAllRead(ctx context.Context, data notifications.MarkAllRead) (int, error)
+	GetWatchers(ctx context.Context, taskId int) ([]int, error)
+	AddWatcher(ctx context.Context, data notifications.Watcher) error
+	RemoveWatcher(ctx context.Context, data notifications.Watcher) error
+	GetPreferences(ctx context.Context, userId int) (entity.NotificationPreferences, error)
+	UpdatePreferences(ctx context.Context, data notifications.UpdatePreferences) (entity.NotificationPreferences, error)
+}
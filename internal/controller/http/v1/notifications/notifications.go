@@ -0,0 +1,249 @@
+package notifications
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	basic_controller "task-management2/internal/controller/http/v1/_basic_controller"
+	"task-management2/internal/repository/postgres/notifications"
+)
+
+type Controller struct {
+	useCase Repository
+}
+
+func NewController(useCase Repository) *Controller {
+	return &Controller{useCase: useCase}
+}
+
+func (cl *Controller) GetList(c *gin.Context) {
+	var filter notifications.Filter
+	query := c.Request.URL.Query()
+
+	defaultOffset := 0
+	defaultLimit := 20
+	filter.Offset = &defaultOffset
+	filter.Limit = &defaultLimit
+
+	userId, err := strconv.Atoi(c.Query("user_id"))
+	if err != nil {
+		basic_controller.Fail(c, http.StatusBadRequest, "user_id must be integer!")
+		return
+	}
+	filter.UserId = &userId
+	filter.Unread = c.Query("unread") == "true"
+
+	limitQ := query["limit"]
+	if len(limitQ) > 0 {
+		queryInt, err := strconv.Atoi(limitQ[0])
+		if err != nil {
+			basic_controller.Fail(c, http.StatusBadRequest, "limit must be number!")
+			return
+		}
+		filter.Limit = &queryInt
+	}
+
+	offsetQ := query["offset"]
+	if len(offsetQ) > 0 {
+		page, err := strconv.Atoi(offsetQ[0])
+		if err != nil {
+			basic_controller.Fail(c, http.StatusBadRequest, "offset must be number!")
+			return
+		}
+		offset := (page - 1) * *filter.Limit
+		filter.Offset = &offset
+	}
+
+	list, count, err := cl.useCase.GetAll(c.Request.Context(), filter)
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	unread, err := cl.useCase.UnreadCount(c.Request.Context(), userId)
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":   list,
+		"count":  count,
+		"unread": unread,
+	})
+}
+
+func (cl *Controller) UnreadCount(c *gin.Context) {
+	userId, err := strconv.Atoi(c.Query("user_id"))
+	if err != nil {
+		basic_controller.Fail(c, http.StatusBadRequest, "user_id must be integer!")
+		return
+	}
+
+	unread, err := cl.useCase.UnreadCount(c.Request.Context(), userId)
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"unread": unread,
+	})
+}
+
+func (cl *Controller) MarkRead(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		basic_controller.Fail(c, http.StatusBadRequest, "id must be number!")
+		return
+	}
+
+	detail, err := cl.useCase.MarkRead(c.Request.Context(), id)
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": detail,
+	})
+}
+
+func (cl *Controller) MarkAllRead(c *gin.Context) {
+	var request notifications.MarkAllRead
+	if err := c.ShouldBindJSON(&request); err != nil {
+		basic_controller.Abort(c, basic_controller.BindError(err))
+		return
+	}
+
+	updated, err := cl.useCase.MarkAllRead(c.Request.Context(), request)
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "ok!",
+		"status":  true,
+		"updated": updated,
+	})
+}
+
+func (cl *Controller) GetWatchers(c *gin.Context) {
+	taskId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		basic_controller.Fail(c, http.StatusBadRequest, "id must be number!")
+		return
+	}
+
+	watchers, err := cl.useCase.GetWatchers(c.Request.Context(), taskId)
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	if watchers == nil {
+		watchers = []int{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": watchers,
+	})
+}
+
+func (cl *Controller) AddWatcher(c *gin.Context) {
+	taskId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		basic_controller.Fail(c, http.StatusBadRequest, "id must be number!")
+		return
+	}
+
+	var request notifications.Watcher
+	if err := c.ShouldBindJSON(&request); err != nil {
+		basic_controller.Abort(c, basic_controller.BindError(err))
+		return
+	}
+	request.TaskId = &taskId
+
+	err = cl.useCase.AddWatcher(c.Request.Context(), request)
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "ok!",
+		"status":  true,
+	})
+}
+
+func (cl *Controller) RemoveWatcher(c *gin.Context) {
+	taskId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		basic_controller.Fail(c, http.StatusBadRequest, "id must be number!")
+		return
+	}
+
+	userId, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		basic_controller.Fail(c, http.StatusBadRequest, "user_id must be number!")
+		return
+	}
+
+	err = cl.useCase.RemoveWatcher(c.Request.Context(), notifications.Watcher{
+		TaskId: &taskId,
+		UserId: &userId,
+	})
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "ok!",
+		"status":  true,
+	})
+}
+
+func (cl *Controller) GetPreferences(c *gin.Context) {
+	userId, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		basic_controller.Fail(c, http.StatusBadRequest, "user_id must be number!")
+		return
+	}
+
+	detail, err := cl.useCase.GetPreferences(c.Request.Context(), userId)
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": detail,
+	})
+}
+
+func (cl *Controller) UpdatePreferences(c *gin.Context) {
+	userId, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		basic_controller.Fail(c, http.StatusBadRequest, "user_id must be number!")
+		return
+	}
+
+	var request notifications.UpdatePreferences
+	if err := c.ShouldBindJSON(&request); err != nil {
+		basic_controller.Abort(c, basic_controller.BindError(err))
+		return
+	}
+	request.UserId = &userId
+
+	detail, err := cl.useCase.UpdatePreferences(c.Request.Context(), request)
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": detail,
+	})
+}
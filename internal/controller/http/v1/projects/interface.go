@@ -11,7 +11,20 @@ type Repository interface {
 	GetProjectsWithStats(ctx context.Context, filter projects.Filter) ([]projects.List, error)
 	GetProjectsCount(ctx context.Context, filter projects.Filter) (int, error)
 	GetById(ctx context.Context, id int) (projects.Detail, error)
+	GetDetail(ctx context.Context, filter projects.DetailFilter) (projects.Detail, error)
+	GetTimeline(ctx context.Context, filter projects.TimelineFilter) (projects.Timeline, error)
 	Create(ctx context.Context, data projects.Create) (entity.Projects, error)
 	Update(ctx context.Context, data projects.Update) (entity.Projects, error)
+	Patch(ctx context.Context, data basic_repo.MergePatch) (entity.Projects, error)
 	Delete(ctx context.Context, data basic_repo.Delete) error
+	GetBoard(ctx context.Context, projectId int) (projects.Board, error)
+	SetWipLimits(ctx context.Context, data projects.WipLimits) (map[string]int, error)
+	GetMilestones(ctx context.Context, filter projects.MilestoneFilter) ([]projects.Milestone, error)
+	GetMilestone(ctx context.Context, filter projects.MilestoneFilter, id int) (projects.Milestone, error)
+	CreateMilestone(ctx context.Context, data projects.MilestoneCreate) (entity.Milestones, error)
+	UpdateMilestone(ctx context.Context, data projects.MilestoneUpdate) (entity.Milestones, error)
+	DeleteMilestone(ctx context.Context, projectId, id int) error
+	AddMilestoneTasks(ctx context.Context, data projects.MilestoneTasks) ([]entity.Tasks, error)
+	RemoveMilestoneTask(ctx context.Context, projectId, milestoneId, taskId int) error
+	Restore(ctx context.Context, id int) (entity.Projects, error)
 }
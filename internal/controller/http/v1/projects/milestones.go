@@ -0,0 +1,259 @@
+package projects
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	basic_controller "task-management2/internal/controller/http/v1/_basic_controller"
+	basic_repo "task-management2/internal/repository/postgres/_basic_repo"
+	"task-management2/internal/repository/postgres/projects"
+	"task-management2/internal/util/request_header"
+)
+
+// pathIds reads the named ids from the path, in order. It writes the error
+// response itself.
+func pathIds(c *gin.Context, names ...string) ([]int, bool) {
+	var result []int
+
+	for _, name := range names {
+		id, err := strconv.Atoi(c.Param(name))
+		if err != nil {
+			basic_controller.Fail(c, http.StatusBadRequest, name+" must be a number!")
+
+			return nil, false
+		}
+		result = append(result, id)
+	}
+
+	return result, true
+}
+
+// milestoneFilter reads the weighting, at_risk_days and the caller's time
+// zone, which decides what today is.
+func milestoneFilter(c *gin.Context, projectId int) (projects.MilestoneFilter, bool) {
+	filter := projects.MilestoneFilter{ProjectId: projectId}
+
+	if q := c.Query("weighting"); q != "" {
+		if !basic_repo.ValidWeighting(q) {
+			basic_controller.Fail(c, http.StatusBadRequest, "weighting must be one of count, points, estimate!")
+
+			return filter, false
+		}
+		filter.Weighting = &q
+	}
+
+	if q := c.Query("at_risk_days"); q != "" {
+		days, err := strconv.Atoi(q)
+		if err != nil || days < 0 {
+			basic_controller.Fail(c, http.StatusBadRequest, "at_risk_days must be a positive number!")
+
+			return filter, false
+		}
+		filter.AtRiskDays = days
+	}
+
+	loc, err := request_header.GetLocation(c)
+	if err != nil {
+		basic_controller.Fail(c, http.StatusBadRequest, "unknown time zone!")
+
+		return filter, false
+	}
+	filter.Location = loc
+
+	return filter, true
+}
+
+func milestoneError(c *gin.Context, err error, notFound string) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		basic_controller.Fail(c, http.StatusNotFound, notFound)
+	default:
+		basic_controller.Abort(c, err)
+	}
+}
+
+func (cl Controller) MilestoneList(c *gin.Context) {
+	ids, ok := pathIds(c, "id")
+	if !ok {
+		return
+	}
+
+	filter, ok := milestoneFilter(c, ids[0])
+	if !ok {
+		return
+	}
+
+	list, err := cl.useCase.GetMilestones(c.Request.Context(), filter)
+	if errors.Is(err, sql.ErrNoRows) {
+		milestoneError(c, err, "project not found")
+
+		return
+	}
+	if err != nil {
+		basic_controller.Abort(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "ok!",
+		"status":  true,
+		"data":    list,
+	})
+}
+
+func (cl Controller) MilestoneGetDetail(c *gin.Context) {
+	ids, ok := pathIds(c, "id", "milestone_id")
+	if !ok {
+		return
+	}
+
+	filter, ok := milestoneFilter(c, ids[0])
+	if !ok {
+		return
+	}
+
+	detail, err := cl.useCase.GetMilestone(c.Request.Context(), filter, ids[1])
+	if errors.Is(err, sql.ErrNoRows) {
+		milestoneError(c, err, "milestone not found")
+
+		return
+	}
+	if err != nil {
+		basic_controller.Abort(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "ok!",
+		"status":  true,
+		"data":    detail,
+	})
+}
+
+func (cl Controller) MilestoneCreate(c *gin.Context) {
+	ids, ok := pathIds(c, "id")
+	if !ok {
+		return
+	}
+
+	var data projects.MilestoneCreate
+	if err := c.ShouldBindJSON(&data); err != nil {
+		basic_controller.Abort(c, basic_controller.BindError(err))
+
+		return
+	}
+	data.ProjectId = &ids[0]
+
+	detail, err := cl.useCase.CreateMilestone(c.Request.Context(), data)
+	if err != nil {
+		milestoneError(c, err, "project not found")
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "ok!",
+		"status":  true,
+		"data":    detail,
+	})
+}
+
+func (cl Controller) MilestoneUpdate(c *gin.Context) {
+	ids, ok := pathIds(c, "id", "milestone_id")
+	if !ok {
+		return
+	}
+
+	var data projects.MilestoneUpdate
+	if err := c.ShouldBindJSON(&data); err != nil {
+		basic_controller.Abort(c, basic_controller.BindError(err))
+
+		return
+	}
+	data.ProjectId = &ids[0]
+	data.Id = &ids[1]
+
+	detail, err := cl.useCase.UpdateMilestone(c.Request.Context(), data)
+	if err != nil {
+		milestoneError(c, err, "milestone not found")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "ok!",
+		"status":  true,
+		"data":    detail,
+	})
+}
+
+func (cl Controller) MilestoneDelete(c *gin.Context) {
+	ids, ok := pathIds(c, "id", "milestone_id")
+	if !ok {
+		return
+	}
+
+	err := cl.useCase.DeleteMilestone(c.Request.Context(), ids[0], ids[1])
+	if err != nil {
+		milestoneError(c, err, "milestone not found")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "ok!",
+		"status":  true,
+	})
+}
+
+func (cl Controller) MilestoneAddTasks(c *gin.Context) {
+	ids, ok := pathIds(c, "id", "milestone_id")
+	if !ok {
+		return
+	}
+
+	var data projects.MilestoneTasks
+	if err := c.ShouldBindJSON(&data); err != nil {
+		basic_controller.Abort(c, basic_controller.BindError(err))
+
+		return
+	}
+	data.ProjectId = &ids[0]
+	data.MilestoneId = &ids[1]
+
+	list, err := cl.useCase.AddMilestoneTasks(c.Request.Context(), data)
+	if err != nil {
+		milestoneError(c, err, "milestone not found")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "ok!",
+		"status":  true,
+		"data":    list,
+	})
+}
+
+func (cl Controller) MilestoneRemoveTask(c *gin.Context) {
+	ids, ok := pathIds(c, "id", "milestone_id", "task_id")
+	if !ok {
+		return
+	}
+
+	err := cl.useCase.RemoveMilestoneTask(c.Request.Context(), ids[0], ids[1], ids[2])
+	if err != nil {
+		milestoneError(c, err, "task not found in milestone")
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "ok!",
+		"status":  true,
+	})
+}
@@ -2,11 +2,15 @@ package projects
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"strconv"
 	basic_controller "task-management2/internal/controller/http/v1/_basic_controller"
+	basic_repo "task-management2/internal/repository/postgres/_basic_repo"
 	"task-management2/internal/repository/postgres/projects"
+	"task-management2/internal/util/request_header"
 )
 
 type Controller struct {
@@ -25,10 +29,7 @@ func (cl Controller) GetProjectsWithStats(c *gin.Context) {
 	if len(ownerIdQ) > 0 {
 		queryInt, err := strconv.Atoi(ownerIdQ[0])
 		if err != nil {
-			c.JSON(http.StatusOK, gin.H{
-				"message": "owner_id must be integer!",
-				"status":  false,
-			})
+			basic_controller.Fail(c, http.StatusBadRequest, "owner_id must be integer!")
 
 			return
 		}
@@ -39,10 +40,7 @@ func (cl Controller) GetProjectsWithStats(c *gin.Context) {
 	if len(limitQ) > 0 {
 		queryInt, err := strconv.Atoi(limitQ[0])
 		if err != nil {
-			c.JSON(http.StatusOK, gin.H{
-				"message": "Limit must be a number",
-				"status":  false,
-			})
+			basic_controller.Fail(c, http.StatusBadRequest, "Limit must be a number")
 			return
 		}
 
@@ -53,33 +51,34 @@ func (cl Controller) GetProjectsWithStats(c *gin.Context) {
 	if len(offsetQ) > 0 {
 		page, err := strconv.Atoi(offsetQ[0])
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"message": "offset must be number!",
-				"status":  false,
-			})
+			basic_controller.Fail(c, http.StatusBadRequest, "offset must be number!")
 			return
 		}
 		offset := (page - 1) * *filter.Limit
 		filter.Offset = &offset
 	}
 
+	if q := c.Query("weighting"); q != "" {
+		if !basic_repo.ValidWeighting(q) {
+			basic_controller.Fail(c, http.StatusBadRequest, "weighting must be one of count, points, estimate!")
+			return
+		}
+		filter.Weighting = &q
+	}
+
+	filter.Sparse = basic_controller.Sparse(c)
+
 	ctx := context.Background()
 
 	list, err := cl.useCase.GetProjectsWithStats(ctx, filter)
 	if err != nil {
-		c.JSON(http.StatusOK, gin.H{
-			"message": err.Error(),
-			"status":  false,
-		})
+		basic_controller.Abort(c, err)
 		return
 	}
 
 	count, err := cl.useCase.GetProjectsCount(ctx, filter)
 	if err != nil {
-		c.JSON(http.StatusOK, gin.H{
-			"message": err.Error(),
-			"status":  false,
-		})
+		basic_controller.Abort(c, err)
 		return
 	}
 
@@ -87,7 +86,7 @@ func (cl Controller) GetProjectsWithStats(c *gin.Context) {
 		"message": "ok!",
 		"status":  true,
 		"data": map[string]interface{}{
-			"results": list,
+			"results": basic_controller.Project(list, filter.Sparse),
 			"count":   count,
 		},
 	})
@@ -98,30 +97,79 @@ func (cl Controller) ProjectGetDetail(c *gin.Context) {
 
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
-		c.JSON(http.StatusOK, gin.H{
-			"message": "id must be a number!",
-			"status":  false,
-		})
+		basic_controller.Fail(c, http.StatusBadRequest, "id must be a number!")
 
 		return
 	}
 
+	filter := projects.DetailFilter{Id: id}
+
+	if q := c.Query("weighting"); q != "" {
+		if !basic_repo.ValidWeighting(q) {
+			basic_controller.Fail(c, http.StatusBadRequest, "weighting must be one of count, points, estimate!")
+			return
+		}
+		filter.Weighting = &q
+	}
+
+	filter.Sparse = basic_controller.Sparse(c)
+
 	ctx := context.Background()
 
-	detail, err := cl.useCase.GetById(ctx, id)
+	detail, err := cl.useCase.GetDetail(ctx, filter)
 	if err != nil {
-		c.JSON(http.StatusOK, gin.H{
-			"message": err.Error(),
-			"status":  false,
-		})
+		basic_controller.Abort(c, err)
+
+		return
+	}
 
+	data := basic_controller.Project(detail, filter.Sparse)
+
+	if basic_controller.NotModified(c, basic_controller.ETag(detail.Version, data)) {
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "ok!",
 		"status":  true,
-		"data":    detail,
+		"data":    data,
+	})
+}
+
+func (cl Controller) ProjectTimeline(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		basic_controller.Fail(c, http.StatusBadRequest, "id must be a number!")
+
+		return
+	}
+
+	loc, err := request_header.GetLocation(c)
+	if err != nil {
+		basic_controller.Fail(c, http.StatusBadRequest, "unknown time zone!")
+
+		return
+	}
+
+	timeline, err := cl.useCase.GetTimeline(c.Request.Context(), projects.TimelineFilter{
+		ProjectId: id,
+		Location:  loc,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		basic_controller.Fail(c, http.StatusNotFound, "project not found")
+
+		return
+	}
+	if err != nil {
+		basic_controller.Abort(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "ok!",
+		"status":  true,
+		"data":    timeline,
 	})
 }
 
@@ -130,19 +178,13 @@ func (cl Controller) ProjectCreate(c *gin.Context) {
 
 	err := c.ShouldBind(&data)
 	if err != nil {
-		c.JSON(http.StatusOK, gin.H{
-			"message": err.Error(),
-			"status":  false,
-		})
+		basic_controller.Abort(c, basic_controller.BindError(err))
 
 		return
 	}
 
 	if err != nil {
-		c.JSON(http.StatusOK, gin.H{
-			"message": err.Error(),
-			"status":  false,
-		})
+		basic_controller.Abort(c, err)
 
 		return
 	}
@@ -151,10 +193,7 @@ func (cl Controller) ProjectCreate(c *gin.Context) {
 
 	detail, err := cl.useCase.Create(ctx, data)
 	if err != nil {
-		c.JSON(http.StatusOK, gin.H{
-			"message": err.Error(),
-			"status":  false,
-		})
+		basic_controller.Abort(c, err)
 
 		return
 	}
@@ -171,10 +210,7 @@ func (cl Controller) ProjectUpdate(c *gin.Context) {
 
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
-		c.JSON(http.StatusOK, gin.H{
-			"message": "Id must be a number!",
-			"status":  false,
-		})
+		basic_controller.Fail(c, http.StatusBadRequest, "Id must be a number!")
 
 		return
 	}
@@ -183,10 +219,7 @@ func (cl Controller) ProjectUpdate(c *gin.Context) {
 
 	err = c.ShouldBind(&data)
 	if err != nil {
-		c.JSON(http.StatusOK, gin.H{
-			"message": err.Error(),
-			"status":  false,
-		})
+		basic_controller.Abort(c, basic_controller.BindError(err))
 
 		return
 	}
@@ -194,14 +227,17 @@ func (cl Controller) ProjectUpdate(c *gin.Context) {
 	if data.Id == nil {
 		data.Id = &id
 	}
+
+	var ok bool
+	if data.Version, ok = basic_controller.IfMatch(c); !ok {
+		return
+	}
+
 	ctx := context.Background()
 
 	detail, err := cl.useCase.Update(ctx, data)
 	if err != nil {
-		c.JSON(http.StatusOK, gin.H{
-			"message": err.Error(),
-			"status":  false,
-		})
+		basic_controller.Abort(c, err)
 
 		return
 	}
@@ -221,10 +257,7 @@ func (cl Controller) ProjectDelete(c *gin.Context) {
 
 	err = cl.useCase.Delete(ctx, data)
 	if err != nil {
-		c.JSON(http.StatusOK, gin.H{
-			"message": err.Error(),
-			"status":  false,
-		})
+		basic_controller.Abort(c, err)
 
 		return
 	}
@@ -234,3 +267,112 @@ func (cl Controller) ProjectDelete(c *gin.Context) {
 		"status":  true,
 	})
 }
+
+func (cl Controller) ProjectBoard(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		basic_controller.Fail(c, http.StatusBadRequest, "id must be a number!")
+
+		return
+	}
+
+	board, err := cl.useCase.GetBoard(c.Request.Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		basic_controller.Fail(c, http.StatusNotFound, "project not found")
+
+		return
+	}
+	if err != nil {
+		basic_controller.Abort(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "ok!",
+		"status":  true,
+		"data":    board,
+	})
+}
+
+func (cl Controller) ProjectWipLimits(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		basic_controller.Fail(c, http.StatusBadRequest, "id must be a number!")
+
+		return
+	}
+
+	var data projects.WipLimits
+	if err := c.ShouldBindJSON(&data); err != nil {
+		basic_controller.Abort(c, basic_controller.BindError(err))
+
+		return
+	}
+	data.ProjectId = &id
+
+	limits, err := cl.useCase.SetWipLimits(c.Request.Context(), data)
+	if errors.Is(err, sql.ErrNoRows) {
+		basic_controller.Fail(c, http.StatusNotFound, "project not found")
+
+		return
+	}
+	if err != nil {
+		basic_controller.Abort(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "ok!",
+		"status":  true,
+		"data":    limits,
+	})
+}
+
+func (cl Controller) ProjectRestore(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		basic_controller.Fail(c, http.StatusBadRequest, "id must be number!")
+
+		return
+	}
+
+	detail, err := cl.useCase.Restore(c.Request.Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		basic_controller.Fail(c, http.StatusNotFound, "deleted project not found")
+
+		return
+	}
+	if err != nil {
+		basic_controller.Abort(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "ok!",
+		"status":  true,
+		"data":    detail,
+	})
+}
+
+// ProjectPatch applies a JSON merge patch (RFC 7396) to a project.
+func (cl Controller) ProjectPatch(c *gin.Context) {
+	data, ok := basic_controller.BasicMergePatch(c)
+	if !ok {
+		return
+	}
+
+	detail, err := cl.useCase.Patch(c.Request.Context(), data)
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "ok!",
+		"status":  true,
+		"data":    detail,
+	})
+}
@@ -0,0 +1,12 @@
+package recurrences
+
+import (
+	"context"
+	"task-management2/internal/repository/postgres/recurrences"
+)
+
+type Repository interface {
+	Upsert(ctx context.Context, data recurrences.Create) (recurrences.Detail, error)
+	GetByTaskId(ctx context.Context, taskId int) (recurrences.Detail, error)
+	Delete(ctx context.Context, taskId int) error
+}
@@ -0,0 +1,90 @@
+package recurrences
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	basic_controller "task-management2/internal/controller/http/v1/_basic_controller"
+	"task-management2/internal/repository/postgres/recurrences"
+)
+
+type Controller struct {
+	useCase Repository
+}
+
+func NewController(useCase Repository) *Controller {
+	return &Controller{useCase: useCase}
+}
+
+func (cl *Controller) GetDetail(c *gin.Context) {
+	taskId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		basic_controller.Fail(c, http.StatusBadRequest, "id must be number!")
+		return
+	}
+
+	detail, err := cl.useCase.GetByTaskId(c.Request.Context(), taskId)
+	if errors.Is(err, sql.ErrNoRows) {
+		basic_controller.Fail(c, http.StatusNotFound, "recurrence not found")
+		return
+	}
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": detail,
+	})
+}
+
+func (cl *Controller) Upsert(c *gin.Context) {
+	taskId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		basic_controller.Fail(c, http.StatusBadRequest, "id must be number!")
+		return
+	}
+
+	var request recurrences.Create
+	if err := c.ShouldBindJSON(&request); err != nil {
+		basic_controller.Abort(c, basic_controller.BindError(err))
+		return
+	}
+
+	request.TaskId = &taskId
+
+	detail, err := cl.useCase.Upsert(c.Request.Context(), request)
+	if errors.Is(err, sql.ErrNoRows) {
+		basic_controller.Fail(c, http.StatusNotFound, "task not found")
+		return
+	}
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": detail,
+	})
+}
+
+func (cl *Controller) Delete(c *gin.Context) {
+	taskId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		basic_controller.Fail(c, http.StatusBadRequest, "id must be number!")
+		return
+	}
+
+	err = cl.useCase.Delete(c.Request.Context(), taskId)
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "ok!",
+		"status":  true,
+	})
+}
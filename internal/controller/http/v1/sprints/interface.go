@@ -0,0 +1,21 @@
+package sprints
+
+import (
+	"context"
+	"task-management2/internal/entity"
+	"task-management2/internal/repository/postgres/sprints"
+)
+
+type Repository interface {
+	GetAll(ctx context.Context, filter sprints.Filter) ([]entity.Sprints, int, error)
+	GetById(ctx context.Context, id int) (entity.Sprints, error)
+	Create(ctx context.Context, data sprints.Create) (entity.Sprints, error)
+	Update(ctx context.Context, data sprints.Update) (entity.Sprints, error)
+	Delete(ctx context.Context, id int) error
+	AddTasks(ctx context.Context, data sprints.AssignTasks) ([]entity.Tasks, error)
+	RemoveTask(ctx context.Context, sprintId, taskId int) error
+	Start(ctx context.Context, id int) (entity.Sprints, error)
+	Close(ctx context.Context, data sprints.Close) (entity.Sprints, error)
+	GetReport(ctx context.Context, id int) (sprints.Report, error)
+	GetVelocity(ctx context.Context, projectId int) (sprints.Velocity, error)
+}
@@ -0,0 +1,305 @@
+package sprints
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	basic_controller "task-management2/internal/controller/http/v1/_basic_controller"
+	"task-management2/internal/repository/postgres/sprints"
+)
+
+type Controller struct {
+	useCase Repository
+}
+
+func NewController(useCase Repository) *Controller {
+	return &Controller{useCase: useCase}
+}
+
+func paramId(c *gin.Context, name string) (int, bool) {
+	id, err := strconv.Atoi(c.Param(name))
+	if err != nil {
+		basic_controller.Fail(c, http.StatusBadRequest, name+" must be number!")
+		return 0, false
+	}
+
+	return id, true
+}
+
+// writeError is basic_controller.Abort naming the sprint when it is missing.
+func writeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		basic_controller.Fail(c, http.StatusNotFound, "sprint not found")
+	default:
+		basic_controller.Abort(c, err)
+	}
+}
+
+func (cl *Controller) GetList(c *gin.Context) {
+	var filter sprints.Filter
+
+	defaultLimit := 20
+	defaultOffset := 0
+	filter.Limit = &defaultLimit
+	filter.Offset = &defaultOffset
+
+	if q := c.Query("project_id"); q != "" {
+		projectId, err := strconv.Atoi(q)
+		if err != nil {
+			basic_controller.Fail(c, http.StatusBadRequest, "project_id must be integer!")
+			return
+		}
+		filter.ProjectId = &projectId
+	}
+
+	if q := c.Query("state"); q != "" {
+		if q != sprints.StatePlanned && q != sprints.StateActive && q != sprints.StateClosed {
+			basic_controller.Fail(c, http.StatusBadRequest, "state must be one of planned, active, closed!")
+			return
+		}
+		filter.State = &q
+	}
+
+	if q := c.Query("limit"); q != "" {
+		queryInt, err := strconv.Atoi(q)
+		if err != nil {
+			basic_controller.Fail(c, http.StatusBadRequest, "limit must be number!")
+			return
+		}
+		filter.Limit = &queryInt
+	}
+
+	if q := c.Query("offset"); q != "" {
+		page, err := strconv.Atoi(q)
+		if err != nil {
+			basic_controller.Fail(c, http.StatusBadRequest, "offset must be number!")
+			return
+		}
+		offset := (page - 1) * *filter.Limit
+		filter.Offset = &offset
+	}
+
+	list, count, err := cl.useCase.GetAll(c.Request.Context(), filter)
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  list,
+		"count": count,
+	})
+}
+
+func (cl *Controller) GetDetail(c *gin.Context) {
+	id, ok := paramId(c, "id")
+	if !ok {
+		return
+	}
+
+	detail, err := cl.useCase.GetById(c.Request.Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		writeError(c, err)
+		return
+	}
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": detail,
+	})
+}
+
+func (cl *Controller) Create(c *gin.Context) {
+	var request sprints.Create
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		basic_controller.Abort(c, basic_controller.BindError(err))
+		return
+	}
+
+	detail, err := cl.useCase.Create(c.Request.Context(), request)
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data": detail,
+	})
+}
+
+func (cl *Controller) Update(c *gin.Context) {
+	id, ok := paramId(c, "id")
+	if !ok {
+		return
+	}
+
+	var request sprints.Update
+	if err := c.ShouldBindJSON(&request); err != nil {
+		basic_controller.Abort(c, basic_controller.BindError(err))
+		return
+	}
+	request.Id = &id
+
+	detail, err := cl.useCase.Update(c.Request.Context(), request)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": detail,
+	})
+}
+
+func (cl *Controller) Delete(c *gin.Context) {
+	id, ok := paramId(c, "id")
+	if !ok {
+		return
+	}
+
+	err := cl.useCase.Delete(c.Request.Context(), id)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "ok!",
+		"status":  true,
+	})
+}
+
+func (cl *Controller) Start(c *gin.Context) {
+	id, ok := paramId(c, "id")
+	if !ok {
+		return
+	}
+
+	detail, err := cl.useCase.Start(c.Request.Context(), id)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": detail,
+	})
+}
+
+func (cl *Controller) Close(c *gin.Context) {
+	id, ok := paramId(c, "id")
+	if !ok {
+		return
+	}
+
+	var request sprints.Close
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&request); err != nil {
+			basic_controller.Abort(c, basic_controller.BindError(err))
+			return
+		}
+	}
+	request.Id = &id
+
+	detail, err := cl.useCase.Close(c.Request.Context(), request)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": detail,
+	})
+}
+
+func (cl *Controller) AddTasks(c *gin.Context) {
+	id, ok := paramId(c, "id")
+	if !ok {
+		return
+	}
+
+	var request sprints.AssignTasks
+	if err := c.ShouldBindJSON(&request); err != nil {
+		basic_controller.Abort(c, basic_controller.BindError(err))
+		return
+	}
+	request.SprintId = &id
+
+	list, err := cl.useCase.AddTasks(c.Request.Context(), request)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": list,
+	})
+}
+
+func (cl *Controller) RemoveTask(c *gin.Context) {
+	id, ok := paramId(c, "id")
+	if !ok {
+		return
+	}
+	taskId, ok := paramId(c, "task_id")
+	if !ok {
+		return
+	}
+
+	err := cl.useCase.RemoveTask(c.Request.Context(), id, taskId)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "ok!",
+		"status":  true,
+	})
+}
+
+func (cl *Controller) GetReport(c *gin.Context) {
+	id, ok := paramId(c, "id")
+	if !ok {
+		return
+	}
+
+	report, err := cl.useCase.GetReport(c.Request.Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		writeError(c, err)
+		return
+	}
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": report,
+	})
+}
+
+func (cl *Controller) GetVelocity(c *gin.Context) {
+	projectId, err := strconv.Atoi(c.Query("project_id"))
+	if err != nil {
+		basic_controller.Fail(c, http.StatusBadRequest, "project_id must be integer!")
+		return
+	}
+
+	velocity, err := cl.useCase.GetVelocity(c.Request.Context(), projectId)
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": velocity,
+	})
+}
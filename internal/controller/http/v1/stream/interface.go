@@ -0,0 +1,11 @@
+package stream
+
+import (
+	"context"
+	"task-management2/internal/pkg/stream"
+	"task-management2/internal/repository/postgres/outbox"
+)
+
+type Watcher interface {
+	Open(ctx context.Context, userId int, match func(outbox.Event) bool) (*stream.Watch, error)
+}
@@ -0,0 +1,146 @@
+package stream
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"strings"
+	basic_controller "task-management2/internal/controller/http/v1/_basic_controller"
+	"task-management2/internal/repository/postgres/outbox"
+	"time"
+)
+
+const (
+	retryMillis     = 3000
+	lastEventHeader = "Last-Event-ID"
+)
+
+type Controller struct {
+	watcher Watcher
+	tokens  basic_controller.TokenRepository
+}
+
+func NewController(watcher Watcher, tokens basic_controller.TokenRepository) *Controller {
+	return &Controller{
+		watcher: watcher,
+		tokens:  tokens,
+	}
+}
+
+// message is the data of a streamed event.
+type message struct {
+	AggregateType string          `json:"aggregate_type"`
+	AggregateId   int             `json:"aggregate_id"`
+	ProjectId     *int            `json:"project_id"`
+	Payload       json.RawMessage `json:"payload"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// Stream pushes task and project events as Server-Sent Events. The event id
+// is the outbox id: a client that reconnects with Last-Event-ID (or
+// ?last_event_id=) first gets what it missed. Events of projects the user
+// of the token may not see are left out.
+func (cl *Controller) Stream(c *gin.Context) {
+	userId, ok := basic_controller.Authenticate(c, cl.tokens)
+	if !ok {
+		return
+	}
+
+	projectIds := map[int]bool{}
+	if q := c.Query("project_id"); q != "" {
+		for _, part := range strings.Split(q, ",") {
+			id, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				basic_controller.Fail(c, http.StatusBadRequest, "project_id must be a comma separated list of integers!")
+				return
+			}
+			projectIds[id] = true
+		}
+	}
+
+	var lastEventId int64
+	lastQ := c.GetHeader(lastEventHeader)
+	if lastQ == "" {
+		lastQ = c.Query("last_event_id")
+	}
+	if lastQ != "" {
+		var err error
+		lastEventId, err = strconv.ParseInt(lastQ, 10, 64)
+		if err != nil {
+			basic_controller.Fail(c, http.StatusBadRequest, "last event id must be integer!")
+			return
+		}
+	}
+
+	ctx := c.Request.Context()
+
+	match := func(event outbox.Event) bool {
+		if event.AggregateType != outbox.AggregateTask && event.AggregateType != outbox.AggregateProject {
+			return false
+		}
+		if len(projectIds) == 0 {
+			return true
+		}
+
+		projectId := event.ProjectId()
+		return projectId != nil && projectIds[*projectId]
+	}
+
+	watch, err := cl.watcher.Open(ctx, userId, match)
+	if errors.Is(err, sql.ErrNoRows) {
+		basic_controller.Fail(c, http.StatusNotFound, "user not found")
+		return
+	}
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+	defer watch.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	if _, err := c.Writer.WriteString("retry: " + strconv.Itoa(retryMillis) + "\n\n"); err != nil {
+		return
+	}
+	c.Writer.Flush()
+
+	send := func(event outbox.Event) error {
+		err := sse.Encode(c.Writer, sse.Event{
+			Id:    strconv.FormatInt(event.Id, 10),
+			Event: event.Type,
+			Data: message{
+				AggregateType: event.AggregateType,
+				AggregateId:   event.AggregateId,
+				ProjectId:     event.ProjectId(),
+				Payload:       event.Payload,
+				CreatedAt:     event.CreatedAt,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		c.Writer.Flush()
+		return nil
+	}
+
+	ping := func() error {
+		if _, err := c.Writer.WriteString(": keep-alive\n\n"); err != nil {
+			return err
+		}
+
+		c.Writer.Flush()
+		return nil
+	}
+
+	// a dropped client reconnects with Last-Event-ID by itself
+	_ = watch.Run(ctx, lastEventId, send, ping)
+}
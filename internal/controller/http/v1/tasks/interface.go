@@ -10,8 +10,14 @@ import (
 type Repository interface {
 	GetAll(ctx context.Context, filter tasks.Filter) ([]entity.Tasks, int, error)
 	GetTaskStats(ctx context.Context, filter tasks.Filter) (tasks.TaskStats, error)
-	GetById(ctx context.Context, id int) (entity.Tasks, error)
+	GetCalendar(ctx context.Context, filter tasks.CalendarFilter) (tasks.Calendar, error)
+	GetDetail(ctx context.Context, filter tasks.DetailFilter) (entity.Tasks, error)
 	Create(ctx context.Context, data tasks.Create) (entity.Tasks, error)
 	Update(ctx context.Context, data tasks.Update) (entity.Tasks, error)
+	Patch(ctx context.Context, data basic_repo.MergePatch) (entity.Tasks, error)
 	Delete(ctx context.Context, data basic_repo.Delete) error
+	Move(ctx context.Context, data tasks.Move) (entity.Tasks, error)
+	Restore(ctx context.Context, id int) (entity.Tasks, error)
+	BulkUpdate(ctx context.Context, data tasks.Bulk) ([]entity.Tasks, error)
+	BulkCreate(ctx context.Context, data tasks.BulkCreate) ([]entity.Tasks, error)
 }
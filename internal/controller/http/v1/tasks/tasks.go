@@ -1,13 +1,21 @@
 package tasks
 
 import (
+	"database/sql"
+	"errors"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"strconv"
 	basic_controller "task-management2/internal/controller/http/v1/_basic_controller"
+	basic_repo "task-management2/internal/repository/postgres/_basic_repo"
 	"task-management2/internal/repository/postgres/tasks"
+	"task-management2/internal/util/request_header"
+	"time"
 )
 
+const maxCalendarDays = 366
+
 type Controller struct {
 	useCase Repository
 }
@@ -29,23 +37,54 @@ func (cl *Controller) GetList(c *gin.Context) {
 	if len(projectIdQ) > 0 {
 		queryInt, err := strconv.Atoi(projectIdQ[0])
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"message": "project_id must be integer!",
-				"status":  false,
-			})
+			basic_controller.Fail(c, http.StatusBadRequest, "project_id must be integer!")
 			return
 		}
 		filter.ProjectId = &queryInt
 	}
 
+	sprintIdQ := query["sprint_id"]
+	if len(sprintIdQ) > 0 {
+		queryInt, err := strconv.Atoi(sprintIdQ[0])
+		if err != nil {
+			basic_controller.Fail(c, http.StatusBadRequest, "sprint_id must be integer!")
+			return
+		}
+		filter.SprintId = &queryInt
+	}
+
+	milestoneIdQ := query["milestone_id"]
+	if len(milestoneIdQ) > 0 {
+		queryInt, err := strconv.Atoi(milestoneIdQ[0])
+		if err != nil {
+			basic_controller.Fail(c, http.StatusBadRequest, "milestone_id must be integer!")
+			return
+		}
+		filter.MilestoneId = &queryInt
+	}
+
+	if q := c.Query("status"); q != "" {
+		if !tasks.ValidStatus(q) {
+			basic_controller.Fail(c, http.StatusBadRequest, "status must be one of pending, in_progress, completed!")
+			return
+		}
+		filter.Status = &q
+	}
+
+	if q := c.Query("assigned_to"); q != "" {
+		queryInt, err := strconv.Atoi(q)
+		if err != nil {
+			basic_controller.Fail(c, http.StatusBadRequest, "assigned_to must be integer!")
+			return
+		}
+		filter.AssignedTo = &queryInt
+	}
+
 	limitQ := query["limit"]
 	if len(limitQ) > 0 {
 		queryInt, err := strconv.Atoi(limitQ[0])
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"message": "limit must be number!",
-				"status":  false,
-			})
+			basic_controller.Fail(c, http.StatusBadRequest, "limit must be number!")
 			return
 		}
 		filter.Limit = &queryInt
@@ -55,57 +94,162 @@ func (cl *Controller) GetList(c *gin.Context) {
 	if len(offsetQ) > 0 {
 		page, err := strconv.Atoi(offsetQ[0])
 		if err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"message": "offset must be number!",
-				"status":  false,
-			})
+			basic_controller.Fail(c, http.StatusBadRequest, "offset must be number!")
 			return
 		}
 		offset := (page - 1) * *filter.Limit
 		filter.Offset = &offset
 	}
 
+	if q := c.Query("weighting"); q != "" {
+		if !basic_repo.ValidWeighting(q) {
+			basic_controller.Fail(c, http.StatusBadRequest, "weighting must be one of count, points, estimate!")
+			return
+		}
+		filter.Weighting = &q
+	}
+
+	filter.Sparse = basic_controller.Sparse(c)
+
 	list, count, err := cl.useCase.GetAll(c.Request.Context(), filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": err.Error(),
-			"status":  false,
-		})
+		basic_controller.Abort(c, err)
 		return
 	}
 
 	taskStats, err := cl.useCase.GetTaskStats(c.Request.Context(), filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"message": err.Error(),
-			"status":  false,
-		})
+		basic_controller.Abort(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"data":       list,
+		"data":       basic_controller.Project(list, filter.Sparse),
 		"count":      count,
 		"task_stats": taskStats,
 	})
 }
 
+func (cl *Controller) GetCalendar(c *gin.Context) {
+	loc, err := request_header.GetLocation(c)
+	if err != nil {
+		basic_controller.Fail(c, http.StatusBadRequest, "unknown time zone!")
+		return
+	}
+
+	now := time.Now().In(loc)
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, -1)
+
+	if q := c.Query("from"); q != "" {
+		from, err = parseDay(q, loc)
+		if err != nil {
+			basic_controller.Fail(c, http.StatusBadRequest, "from must be a date (YYYY-MM-DD) or RFC 3339 time!")
+			return
+		}
+		if c.Query("to") == "" {
+			to = from.AddDate(0, 1, -1)
+		}
+	}
+
+	if q := c.Query("to"); q != "" {
+		to, err = parseDay(q, loc)
+		if err != nil {
+			basic_controller.Fail(c, http.StatusBadRequest, "to must be a date (YYYY-MM-DD) or RFC 3339 time!")
+			return
+		}
+	}
+
+	if to.Before(from) {
+		basic_controller.Fail(c, http.StatusBadRequest, "to must not be before from!")
+		return
+	}
+
+	if to.Sub(from) > maxCalendarDays*24*time.Hour {
+		basic_controller.Fail(c, http.StatusBadRequest, fmt.Sprintf("range must not exceed %d days!", maxCalendarDays))
+		return
+	}
+
+	filter := tasks.CalendarFilter{
+		From:     from.Format("2006-01-02"),
+		To:       to.Format("2006-01-02"),
+		Location: loc,
+	}
+
+	if q := c.Query("project_id"); q != "" {
+		projectId, err := strconv.Atoi(q)
+		if err != nil {
+			basic_controller.Fail(c, http.StatusBadRequest, "project_id must be integer!")
+			return
+		}
+		filter.ProjectId = &projectId
+	}
+
+	if q := c.Query("assigned_to"); q != "" {
+		assignedTo, err := strconv.Atoi(q)
+		if err != nil {
+			basic_controller.Fail(c, http.StatusBadRequest, "assigned_to must be integer!")
+			return
+		}
+		filter.AssignedTo = &assignedTo
+	}
+
+	calendar, err := cl.useCase.GetCalendar(c.Request.Context(), filter)
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": calendar,
+	})
+}
+
+// parseDay accepts either a calendar date or an RFC 3339 timestamp. A
+// timestamp is converted to the caller's time zone before taking its date,
+// so the day boundaries follow the caller and not the server.
+func parseDay(value string, loc *time.Location) (time.Time, error) {
+	if day, err := time.Parse("2006-01-02", value); err == nil {
+		return day, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+}
+
 func (cl *Controller) GetDetail(c *gin.Context) {
 	var uri tasks.DetailUri
 
 	if err := c.ShouldBindUri(&uri); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		basic_controller.Abort(c, basic_controller.BindError(err))
 		return
 	}
 
-	detail, err := cl.useCase.GetById(c.Request.Context(), uri.Id)
+	filter := tasks.DetailFilter{Id: uri.Id, Sparse: basic_controller.Sparse(c)}
+
+	detail, err := cl.useCase.GetDetail(c.Request.Context(), filter)
+	if errors.Is(err, sql.ErrNoRows) {
+		basic_controller.Fail(c, http.StatusNotFound, "task not found")
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	data := basic_controller.Project(detail, filter.Sparse)
+
+	if basic_controller.NotModified(c, basic_controller.ETag(detail.Version, data)) {
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"data": detail,
+		"data": data,
 	})
 }
 
@@ -113,13 +257,13 @@ func (cl *Controller) Create(c *gin.Context) {
 	var request tasks.Create
 
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		basic_controller.Abort(c, basic_controller.BindError(err))
 		return
 	}
 
 	detail, err := cl.useCase.Create(c.Request.Context(), request)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		basic_controller.Abort(c, err)
 		return
 	}
 
@@ -131,22 +275,76 @@ func (cl *Controller) Create(c *gin.Context) {
 func (cl *Controller) Update(c *gin.Context) {
 	var uri tasks.DetailUri
 	if err := c.ShouldBindUri(&uri); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		basic_controller.Abort(c, basic_controller.BindError(err))
 		return
 	}
 
 	var request tasks.Update
 	if err := c.ShouldBindJSON(&request); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		basic_controller.Abort(c, basic_controller.BindError(err))
 		return
 	}
 
 	id := uri.Id
 	request.Id = &id
 
+	var ok bool
+	if request.Version, ok = basic_controller.IfMatch(c); !ok {
+		return
+	}
+
 	detail, err := cl.useCase.Update(c.Request.Context(), request)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": detail,
+	})
+}
+
+// Patch applies a JSON merge patch (RFC 7396) to a task.
+func (cl *Controller) Patch(c *gin.Context) {
+	data, ok := basic_controller.BasicMergePatch(c)
+	if !ok {
+		return
+	}
+
+	detail, err := cl.useCase.Patch(c.Request.Context(), data)
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": detail,
+	})
+}
+
+func (cl *Controller) Move(c *gin.Context) {
+	var uri tasks.DetailUri
+	if err := c.ShouldBindUri(&uri); err != nil {
+		basic_controller.Abort(c, basic_controller.BindError(err))
+		return
+	}
+
+	var request tasks.Move
+	if err := c.ShouldBindJSON(&request); err != nil {
+		basic_controller.Abort(c, basic_controller.BindError(err))
+		return
+	}
+
+	id := uri.Id
+	request.Id = &id
+
+	detail, err := cl.useCase.Move(c.Request.Context(), request)
+	if errors.Is(err, sql.ErrNoRows) {
+		basic_controller.Fail(c, http.StatusNotFound, "task not found")
+		return
+	}
+	if err != nil {
+		basic_controller.Abort(c, err)
 		return
 	}
 
@@ -163,12 +361,97 @@ func (cl *Controller) Delete(c *gin.Context) {
 
 	err = cl.useCase.Delete(ctx, data)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "ok!",
+		"status":  true,
+	})
+}
+
+func (cl *Controller) Restore(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		basic_controller.Fail(c, http.StatusBadRequest, "id must be number!")
+
+		return
+	}
+
+	detail, err := cl.useCase.Restore(c.Request.Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		basic_controller.Fail(c, http.StatusNotFound, "deleted task not found")
+
+		return
+	}
+	if err != nil {
+		basic_controller.Abort(c, err)
+
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"message": "ok!",
 		"status":  true,
+		"data":    detail,
 	})
 }
+
+func (cl *Controller) Bulk(c *gin.Context) {
+	var request tasks.Bulk
+	if err := c.ShouldBindJSON(&request); err != nil {
+		basic_controller.Abort(c, basic_controller.BindError(err))
+		return
+	}
+
+	list, err := cl.useCase.BulkUpdate(c.Request.Context(), request)
+	if err != nil {
+		bulkError(c, err, "ids")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  list,
+		"count": len(list),
+	})
+}
+
+func (cl *Controller) BulkCreate(c *gin.Context) {
+	var request tasks.BulkCreate
+	if err := c.ShouldBindJSON(&request); err != nil {
+		basic_controller.Abort(c, basic_controller.BindError(err))
+		return
+	}
+
+	list, err := cl.useCase.BulkCreate(c.Request.Context(), request)
+	if err != nil {
+		bulkError(c, err, "items")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":  list,
+		"count": len(list),
+	})
+}
+
+// bulkError answers 422 listing the failed items, field is the request
+// array they were taken from.
+func bulkError(c *gin.Context, err error, field string) {
+	var bulkErr *tasks.BulkError
+	if !errors.As(err, &bulkErr) {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	fields := make([]basic_controller.FieldError, 0, len(bulkErr.Items))
+	for _, item := range bulkErr.Items {
+		fields = append(fields, basic_controller.FieldError{
+			Field:   fmt.Sprintf("%s[%d]", field, item.Index),
+			Message: item.Error,
+		})
+	}
+
+	basic_controller.Abort(c, basic_controller.Invalid(err.Error(), fields...))
+}
@@ -0,0 +1,10 @@
+package trash
+
+import (
+	"context"
+	"task-management2/internal/repository/postgres/trash"
+)
+
+type Repository interface {
+	GetAll(ctx context.Context, filter trash.Filter) ([]trash.Item, int, error)
+}
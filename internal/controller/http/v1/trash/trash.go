@@ -0,0 +1,75 @@
+package trash
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	basic_controller "task-management2/internal/controller/http/v1/_basic_controller"
+	"task-management2/internal/repository/postgres/trash"
+	"time"
+)
+
+type Controller struct {
+	useCase   Repository
+	retention time.Duration
+}
+
+// NewController takes the retention of the purge job, used to tell when
+// each record will be purged.
+func NewController(useCase Repository, retention time.Duration) *Controller {
+	return &Controller{useCase: useCase, retention: retention}
+}
+
+func (cl *Controller) GetList(c *gin.Context) {
+	var filter trash.Filter
+
+	defaultLimit := 20
+	defaultOffset := 0
+	filter.Limit = &defaultLimit
+	filter.Offset = &defaultOffset
+
+	if q := c.Query("type"); q != "" {
+		if !trash.ValidType(q) {
+			basic_controller.Fail(c, http.StatusBadRequest, "type must be one of user, project, task!")
+			return
+		}
+		filter.Type = &q
+	}
+
+	if q := c.Query("limit"); q != "" {
+		queryInt, err := strconv.Atoi(q)
+		if err != nil {
+			basic_controller.Fail(c, http.StatusBadRequest, "limit must be number!")
+			return
+		}
+		filter.Limit = &queryInt
+	}
+
+	if q := c.Query("offset"); q != "" {
+		page, err := strconv.Atoi(q)
+		if err != nil {
+			basic_controller.Fail(c, http.StatusBadRequest, "offset must be number!")
+			return
+		}
+		offset := (page - 1) * *filter.Limit
+		filter.Offset = &offset
+	}
+
+	list, count, err := cl.useCase.GetAll(c.Request.Context(), filter)
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	if cl.retention > 0 {
+		for i := range list {
+			purgeAt := list[i].DeletedAt.Add(cl.retention)
+			list[i].PurgeAt = &purgeAt
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  list,
+		"count": count,
+	})
+}
@@ -9,8 +9,10 @@ import (
 
 type Repository interface {
 	GetAll(ctx context.Context, filter users.Filter) ([]users.List, int, error)
-	GetById(ctx context.Context, id int) (users.Detail, error)
+	GetDetail(ctx context.Context, filter users.DetailFilter) (users.Detail, error)
 	Create(ctx context.Context, data users.Create) (entity.User, error)
 	Update(ctx context.Context, data users.Update) (entity.User, error)
+	Patch(ctx context.Context, data basic_repo.MergePatch) (users.Detail, error)
 	Delete(ctx context.Context, data basic_repo.Delete) error
+	Restore(ctx context.Context, id int) (users.Detail, error)
 }
@@ -2,6 +2,8 @@ package users
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"strconv"
@@ -20,18 +22,20 @@ func NewController(useCase Repository) *Controller {
 func (cl Controller) GetList(c *gin.Context) {
 	var filter users.Filter
 	if err := c.ShouldBindQuery(&filter); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		basic_controller.Abort(c, basic_controller.BindError(err))
 		return
 	}
 
+	filter.Sparse = basic_controller.Sparse(c)
+
 	list, count, err := cl.useCase.GetAll(c.Request.Context(), filter)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		basic_controller.Abort(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"data":  list,
+		"data":  basic_controller.Project(list, filter.Sparse),
 		"count": count,
 	})
 }
@@ -41,43 +45,50 @@ func (cl Controller) GetDetail(c *gin.Context) {
 
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
-		c.JSON(http.StatusOK, gin.H{
-			"message": "id must be a number!",
-			"status":  false,
-		})
+		basic_controller.Fail(c, http.StatusBadRequest, "id must be a number!")
 
 		return
 	}
 
+	filter := users.DetailFilter{Id: id, Sparse: basic_controller.Sparse(c)}
+
 	ctx := context.Background()
 
-	detail, err := cl.useCase.GetById(ctx, id)
+	detail, err := cl.useCase.GetDetail(ctx, filter)
+	if errors.Is(err, sql.ErrNoRows) {
+		basic_controller.Fail(c, http.StatusNotFound, "user not found")
+
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusOK, gin.H{
-			"message": err.Error(),
-			"status":  false,
-		})
+		basic_controller.Abort(c, err)
 
 		return
 	}
 
+	data := basic_controller.Project(detail, filter.Sparse)
+
+	if basic_controller.NotModified(c, basic_controller.ETag(detail.Version, data)) {
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"message": "ok!",
 		"status":  true,
-		"data":    detail,
+		"data":    data,
 	})
 }
 
 func (cl Controller) Create(c *gin.Context) {
 	var data users.Create
 	if err := c.ShouldBindJSON(&data); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		basic_controller.Abort(c, basic_controller.BindError(err))
 		return
 	}
 
 	user, err := cl.useCase.Create(c.Request.Context(), data)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		basic_controller.Abort(c, err)
 		return
 	}
 
@@ -88,15 +99,29 @@ func (cl Controller) Create(c *gin.Context) {
 }
 
 func (cl Controller) Update(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		basic_controller.Fail(c, http.StatusBadRequest, "id must be number!")
+		return
+	}
+
 	var data users.Update
 	if err := c.ShouldBindJSON(&data); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		basic_controller.Abort(c, basic_controller.BindError(err))
+		return
+	}
+
+	// the user is the one of the path, not of the body
+	data.Id = &id
+
+	var ok bool
+	if data.Version, ok = basic_controller.IfMatch(c); !ok {
 		return
 	}
 
 	user, err := cl.useCase.Update(c.Request.Context(), data)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		basic_controller.Abort(c, err)
 		return
 	}
 
@@ -114,10 +139,7 @@ func (cl Controller) Delete(c *gin.Context) {
 
 	err = cl.useCase.Delete(ctx, data)
 	if err != nil {
-		c.JSON(http.StatusOK, gin.H{
-			"message": err.Error(),
-			"status":  false,
-		})
+		basic_controller.Abort(c, err)
 
 		return
 	}
@@ -127,3 +149,49 @@ func (cl Controller) Delete(c *gin.Context) {
 		"status":  true,
 	})
 }
+
+func (cl Controller) Restore(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		basic_controller.Fail(c, http.StatusBadRequest, "id must be number!")
+
+		return
+	}
+
+	detail, err := cl.useCase.Restore(c.Request.Context(), id)
+	if errors.Is(err, sql.ErrNoRows) {
+		basic_controller.Fail(c, http.StatusNotFound, "deleted user not found")
+
+		return
+	}
+	if err != nil {
+		basic_controller.Abort(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "ok!",
+		"status":  true,
+		"data":    detail,
+	})
+}
+
+// Patch applies a JSON merge patch (RFC 7396) to a user.
+func (cl Controller) Patch(c *gin.Context) {
+	data, ok := basic_controller.BasicMergePatch(c)
+	if !ok {
+		return
+	}
+
+	user, err := cl.useCase.Patch(c.Request.Context(), data)
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "success",
+		"data":    user,
+	})
+}
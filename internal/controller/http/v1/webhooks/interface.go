@@ -0,0 +1,18 @@
+package webhooks
+
+import (
+	"context"
+	"task-management2/internal/entity"
+	"task-management2/internal/repository/postgres/webhooks"
+)
+
+type Repository interface {
+	GetAll(ctx context.Context, filter webhooks.Filter) ([]entity.Webhooks, int, error)
+	GetById(ctx context.Context, id int) (entity.Webhooks, error)
+	Create(ctx context.Context, data webhooks.Create) (entity.Webhooks, string, error)
+	Update(ctx context.Context, data webhooks.Update) (entity.Webhooks, error)
+	Delete(ctx context.Context, id int) error
+	Ping(ctx context.Context, id int) (webhooks.Delivery, error)
+	GetDeliveries(ctx context.Context, filter webhooks.DeliveryFilter) ([]webhooks.Delivery, int, error)
+	Redeliver(ctx context.Context, webhookId, deliveryId int) (webhooks.Delivery, error)
+}
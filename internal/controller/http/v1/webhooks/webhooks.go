@@ -0,0 +1,261 @@
+package webhooks
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	basic_controller "task-management2/internal/controller/http/v1/_basic_controller"
+	"task-management2/internal/repository/postgres/webhooks"
+)
+
+type Controller struct {
+	useCase Repository
+}
+
+func NewController(useCase Repository) *Controller {
+	return &Controller{useCase: useCase}
+}
+
+// paginate reads limit and offset (a page number) from the query. It writes
+// the error response itself and reports whether the caller may continue.
+func paginate(c *gin.Context, defaultLimit int) (limit, offset int, ok bool) {
+	limit = defaultLimit
+
+	if q := c.Query("limit"); q != "" {
+		queryInt, err := strconv.Atoi(q)
+		if err != nil {
+			basic_controller.Fail(c, http.StatusBadRequest, "limit must be number!")
+			return 0, 0, false
+		}
+		limit = queryInt
+	}
+
+	if q := c.Query("offset"); q != "" {
+		page, err := strconv.Atoi(q)
+		if err != nil {
+			basic_controller.Fail(c, http.StatusBadRequest, "offset must be number!")
+			return 0, 0, false
+		}
+		offset = (page - 1) * limit
+	}
+
+	return limit, offset, true
+}
+
+func paramId(c *gin.Context, name string) (int, bool) {
+	id, err := strconv.Atoi(c.Param(name))
+	if err != nil {
+		basic_controller.Fail(c, http.StatusBadRequest, name+" must be number!")
+		return 0, false
+	}
+
+	return id, true
+}
+
+func notFound(c *gin.Context, err error) bool {
+	if !errors.Is(err, sql.ErrNoRows) {
+		return false
+	}
+
+	basic_controller.Fail(c, http.StatusNotFound, "webhook not found")
+	return true
+}
+
+func (cl *Controller) GetList(c *gin.Context) {
+	var filter webhooks.Filter
+
+	if q := c.Query("project_id"); q != "" {
+		projectId, err := strconv.Atoi(q)
+		if err != nil {
+			basic_controller.Fail(c, http.StatusBadRequest, "project_id must be integer!")
+			return
+		}
+		filter.ProjectId = &projectId
+	}
+
+	limit, offset, ok := paginate(c, 20)
+	if !ok {
+		return
+	}
+	filter.Limit = &limit
+	filter.Offset = &offset
+
+	list, count, err := cl.useCase.GetAll(c.Request.Context(), filter)
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  list,
+		"count": count,
+	})
+}
+
+func (cl *Controller) GetDetail(c *gin.Context) {
+	id, ok := paramId(c, "id")
+	if !ok {
+		return
+	}
+
+	detail, err := cl.useCase.GetById(c.Request.Context(), id)
+	if notFound(c, err) {
+		return
+	}
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": detail,
+	})
+}
+
+func (cl *Controller) Create(c *gin.Context) {
+	var request webhooks.Create
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		basic_controller.Abort(c, basic_controller.BindError(err))
+		return
+	}
+
+	detail, secret, err := cl.useCase.Create(c.Request.Context(), request)
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data":   detail,
+		"secret": secret,
+	})
+}
+
+func (cl *Controller) Update(c *gin.Context) {
+	id, ok := paramId(c, "id")
+	if !ok {
+		return
+	}
+
+	var request webhooks.Update
+	if err := c.ShouldBindJSON(&request); err != nil {
+		basic_controller.Abort(c, basic_controller.BindError(err))
+		return
+	}
+	request.Id = &id
+
+	detail, err := cl.useCase.Update(c.Request.Context(), request)
+	if notFound(c, err) {
+		return
+	}
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": detail,
+	})
+}
+
+func (cl *Controller) Delete(c *gin.Context) {
+	id, ok := paramId(c, "id")
+	if !ok {
+		return
+	}
+
+	err := cl.useCase.Delete(c.Request.Context(), id)
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "ok!",
+		"status":  true,
+	})
+}
+
+func (cl *Controller) Ping(c *gin.Context) {
+	id, ok := paramId(c, "id")
+	if !ok {
+		return
+	}
+
+	delivery, err := cl.useCase.Ping(c.Request.Context(), id)
+	if notFound(c, err) {
+		return
+	}
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"data": delivery,
+	})
+}
+
+func (cl *Controller) GetDeliveries(c *gin.Context) {
+	id, ok := paramId(c, "id")
+	if !ok {
+		return
+	}
+
+	filter := webhooks.DeliveryFilter{WebhookId: &id}
+
+	if q := c.Query("status"); q != "" {
+		if q != webhooks.StatusPending && q != webhooks.StatusSucceeded && q != webhooks.StatusFailed {
+			basic_controller.Fail(c, http.StatusBadRequest, "status must be one of pending, succeeded, failed!")
+			return
+		}
+		filter.Status = &q
+	}
+
+	limit, offset, ok := paginate(c, 20)
+	if !ok {
+		return
+	}
+	filter.Limit = &limit
+	filter.Offset = &offset
+
+	list, count, err := cl.useCase.GetDeliveries(c.Request.Context(), filter)
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  list,
+		"count": count,
+	})
+}
+
+func (cl *Controller) Redeliver(c *gin.Context) {
+	id, ok := paramId(c, "id")
+	if !ok {
+		return
+	}
+
+	deliveryId, ok := paramId(c, "delivery_id")
+	if !ok {
+		return
+	}
+
+	delivery, err := cl.useCase.Redeliver(c.Request.Context(), id, deliveryId)
+	if errors.Is(err, sql.ErrNoRows) {
+		basic_controller.Fail(c, http.StatusNotFound, "delivery not found")
+		return
+	}
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"data": delivery,
+	})
+}
@@ -0,0 +1,20 @@
+package worklogs
+
+import (
+	"context"
+	"task-management2/internal/entity"
+	basic_repo "task-management2/internal/repository/postgres/_basic_repo"
+	"task-management2/internal/repository/postgres/worklogs"
+)
+
+type Repository interface {
+	GetAll(ctx context.Context, filter worklogs.Filter) ([]worklogs.List, int, error)
+	GetTotals(ctx context.Context, groupBy string, filter worklogs.Filter) ([]worklogs.Total, error)
+	GetTimesheet(ctx context.Context, filter worklogs.Filter) (worklogs.Timesheet, error)
+	GetById(ctx context.Context, id int) (entity.Worklogs, error)
+	Create(ctx context.Context, data worklogs.Create) (entity.Worklogs, error)
+	Update(ctx context.Context, data worklogs.Update) (entity.Worklogs, error)
+	Delete(ctx context.Context, data basic_repo.Delete) error
+	StartTimer(ctx context.Context, data worklogs.StartTimer) (entity.Worklogs, error)
+	StopTimer(ctx context.Context, data worklogs.StopTimer) (entity.Worklogs, error)
+}
@@ -0,0 +1,280 @@
+package worklogs
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"time"
+
+	basic_controller "task-management2/internal/controller/http/v1/_basic_controller"
+	"task-management2/internal/repository/postgres/worklogs"
+	"task-management2/internal/util/request_header"
+)
+
+type Controller struct {
+	useCase Repository
+}
+
+func NewController(useCase Repository) *Controller {
+	return &Controller{useCase: useCase}
+}
+
+// parseFilter reads the query parameters shared by the list, totals and
+// timesheet endpoints. It writes the error response itself and reports
+// whether the caller may continue.
+func parseFilter(c *gin.Context) (worklogs.Filter, bool) {
+	var filter worklogs.Filter
+
+	for _, p := range []struct {
+		name string
+		dest **int
+	}{
+		{"task_id", &filter.TaskId},
+		{"user_id", &filter.UserId},
+		{"project_id", &filter.ProjectId},
+	} {
+		q := c.Query(p.name)
+		if q == "" {
+			continue
+		}
+
+		queryInt, err := strconv.Atoi(q)
+		if err != nil {
+			basic_controller.Fail(c, http.StatusBadRequest, p.name+" must be integer!")
+			return worklogs.Filter{}, false
+		}
+		*p.dest = &queryInt
+	}
+
+	for _, p := range []struct {
+		name string
+		dest **string
+	}{
+		{"from", &filter.From},
+		{"to", &filter.To},
+	} {
+		q := c.Query(p.name)
+		if q == "" {
+			continue
+		}
+
+		if _, err := time.Parse("2006-01-02", q); err != nil {
+			basic_controller.Fail(c, http.StatusBadRequest, p.name+" must be a date (YYYY-MM-DD)!")
+			return worklogs.Filter{}, false
+		}
+		*p.dest = &q
+	}
+
+	loc, err := request_header.GetLocation(c)
+	if err != nil {
+		basic_controller.Fail(c, http.StatusBadRequest, "unknown time zone!")
+		return worklogs.Filter{}, false
+	}
+	filter.Location = loc
+
+	return filter, true
+}
+
+func (cl *Controller) GetList(c *gin.Context) {
+	filter, ok := parseFilter(c)
+	if !ok {
+		return
+	}
+
+	defaultOffset := 0
+	defaultLimit := 10
+	filter.Offset = &defaultOffset
+	filter.Limit = &defaultLimit
+
+	if q := c.Query("limit"); q != "" {
+		queryInt, err := strconv.Atoi(q)
+		if err != nil {
+			basic_controller.Fail(c, http.StatusBadRequest, "limit must be number!")
+			return
+		}
+		filter.Limit = &queryInt
+	}
+
+	if q := c.Query("offset"); q != "" {
+		page, err := strconv.Atoi(q)
+		if err != nil {
+			basic_controller.Fail(c, http.StatusBadRequest, "offset must be number!")
+			return
+		}
+		offset := (page - 1) * *filter.Limit
+		filter.Offset = &offset
+	}
+
+	list, count, err := cl.useCase.GetAll(c.Request.Context(), filter)
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":  list,
+		"count": count,
+	})
+}
+
+func (cl *Controller) GetTotals(c *gin.Context) {
+	filter, ok := parseFilter(c)
+	if !ok {
+		return
+	}
+
+	groupBy := c.DefaultQuery("group_by", "task")
+
+	totals, err := cl.useCase.GetTotals(c.Request.Context(), groupBy, filter)
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	total := 0
+	for _, t := range totals {
+		total += t.Duration
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data":     totals,
+		"group_by": groupBy,
+		"total":    total,
+	})
+}
+
+func (cl *Controller) GetTimesheet(c *gin.Context) {
+	filter, ok := parseFilter(c)
+	if !ok {
+		return
+	}
+
+	timesheet, err := cl.useCase.GetTimesheet(c.Request.Context(), filter)
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": timesheet,
+	})
+}
+
+func (cl *Controller) GetDetail(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		basic_controller.Fail(c, http.StatusBadRequest, "id must be number!")
+		return
+	}
+
+	detail, err := cl.useCase.GetById(c.Request.Context(), id)
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": detail,
+	})
+}
+
+func (cl *Controller) Create(c *gin.Context) {
+	var request worklogs.Create
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		basic_controller.Abort(c, basic_controller.BindError(err))
+		return
+	}
+
+	detail, err := cl.useCase.Create(c.Request.Context(), request)
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data": detail,
+	})
+}
+
+func (cl *Controller) Update(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		basic_controller.Fail(c, http.StatusBadRequest, "id must be number!")
+		return
+	}
+
+	var request worklogs.Update
+	if err := c.ShouldBindJSON(&request); err != nil {
+		basic_controller.Abort(c, basic_controller.BindError(err))
+		return
+	}
+
+	request.Id = &id
+
+	detail, err := cl.useCase.Update(c.Request.Context(), request)
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": detail,
+	})
+}
+
+func (cl *Controller) Delete(c *gin.Context) {
+	ctx, data, err := basic_controller.BasicDelete(c)
+	if err != nil {
+		return
+	}
+
+	err = cl.useCase.Delete(ctx, data)
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "ok!",
+		"status":  true,
+	})
+}
+
+func (cl *Controller) StartTimer(c *gin.Context) {
+	var request worklogs.StartTimer
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		basic_controller.Abort(c, basic_controller.BindError(err))
+		return
+	}
+
+	detail, err := cl.useCase.StartTimer(c.Request.Context(), request)
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"data": detail,
+	})
+}
+
+func (cl *Controller) StopTimer(c *gin.Context) {
+	var request worklogs.StopTimer
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		basic_controller.Abort(c, basic_controller.BindError(err))
+		return
+	}
+
+	detail, err := cl.useCase.StopTimer(c.Request.Context(), request)
+	if err != nil {
+		basic_controller.Abort(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": detail,
+	})
+}
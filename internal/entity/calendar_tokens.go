@@ -0,0 +1,11 @@
+package entity
+
+import "github.com/uptrace/bun"
+
+type CalendarTokens struct {
+	bun.BaseModel `bun:"table:calendar_tokens"`
+
+	basicEntity
+	UserId    *int    `json:"user_id" bun:"user_id"`
+	TokenHash *string `json:"-" bun:"token_hash"`
+}
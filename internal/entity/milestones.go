@@ -0,0 +1,15 @@
+package entity
+
+import (
+	"github.com/uptrace/bun"
+)
+
+type Milestones struct {
+	bun.BaseModel `bun:"table:milestones"`
+
+	basicEntity
+	ProjectId   *int    `json:"project_id" bun:"project_id"`
+	Name        *string `json:"name" bun:"name"`
+	Description *string `json:"description" bun:"description"`
+	TargetDate  *string `json:"target_date" bun:"target_date"`
+}
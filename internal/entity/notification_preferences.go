@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type NotificationPreferences struct {
+	bun.BaseModel `bun:"table:notification_preferences"`
+
+	UserId           *int       `json:"user_id" bun:"user_id,pk"`
+	EmailAssignments *bool      `json:"email_assignments" bun:"email_assignments"`
+	EmailDigest      *bool      `json:"email_digest" bun:"email_digest"`
+	DigestHour       *int       `json:"digest_hour" bun:"digest_hour"`
+	LastDigestOn     *string    `json:"last_digest_on" bun:"last_digest_on"`
+	CreatedAt        *time.Time `json:"created_at" bun:"created_at"`
+	UpdateAt         *time.Time `json:"updated_at" bun:"updated_at"`
+}
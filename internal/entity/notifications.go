@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type Notifications struct {
+	bun.BaseModel `bun:"table:notifications"`
+
+	basicEntity
+	UserId    *int       `json:"user_id" bun:"user_id"`
+	Type      *string    `json:"type" bun:"type"`
+	Title     *string    `json:"title" bun:"title"`
+	Body      *string    `json:"body" bun:"body"`
+	TaskId    *int       `json:"task_id" bun:"task_id"`
+	ProjectId *int       `json:"project_id" bun:"project_id"`
+	DedupeKey *string    `json:"-" bun:"dedupe_key"`
+	ReadAt    *time.Time `json:"read_at" bun:"read_at"`
+	EmailedAt *time.Time `json:"-" bun:"emailed_at"`
+}
@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type OutboxEvents struct {
+	bun.BaseModel `bun:"table:outbox_events"`
+
+	Id            int64      `json:"id" bun:"id,pk,autoincrement"`
+	AggregateType *string    `json:"aggregate_type" bun:"aggregate_type"`
+	AggregateId   *int       `json:"aggregate_id" bun:"aggregate_id"`
+	Type          *string    `json:"type" bun:"type"`
+	Payload       *string    `json:"-" bun:"payload,type:jsonb"`
+	Attempts      *int       `json:"attempts" bun:"attempts"`
+	LastError     *string    `json:"last_error" bun:"last_error"`
+	NextAttemptAt *time.Time `json:"next_attempt_at" bun:"next_attempt_at"`
+	ProcessedAt   *time.Time `json:"processed_at" bun:"processed_at"`
+	DeadAt        *time.Time `json:"dead_at" bun:"dead_at"`
+	CreatedAt     *time.Time `json:"created_at" bun:"created_at"`
+}
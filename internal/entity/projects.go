@@ -9,4 +9,5 @@ type Projects struct {
 	Name        *string `json:"name" bun:"name"`
 	Description *string `json:"description" bun:"description"`
 	OwnerId     *int    `json:"owner_id" bun:"owner_id"`
+	Version     int     `json:"version" bun:"version"`
 }
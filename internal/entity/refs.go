@@ -0,0 +1,17 @@
+package entity
+
+// UserRef and ProjectRef are the records embedded in others on request, the
+// include parameter of the REST API.
+type UserRef struct {
+	Id       int     `json:"id"`
+	FullName *string `json:"full_name"`
+	Email    *string `json:"email"`
+	Role     *string `json:"role"`
+}
+
+type ProjectRef struct {
+	Id          int     `json:"id"`
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+	OwnerId     *int    `json:"owner_id"`
+}
@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type Sprints struct {
+	bun.BaseModel `bun:"table:sprints"`
+
+	basicEntity
+	ProjectId         *int       `json:"project_id" bun:"project_id"`
+	Name              *string    `json:"name" bun:"name"`
+	Goal              *string    `json:"goal" bun:"goal"`
+	StartDate         *string    `json:"start_date" bun:"start_date"`
+	EndDate           *string    `json:"end_date" bun:"end_date"`
+	State             *string    `json:"state" bun:"state"`
+	CommittedTasks    *int       `json:"committed_tasks" bun:"committed_tasks"`
+	CommittedPoints   *int       `json:"committed_points" bun:"committed_points"`
+	CompletedTasks    *int       `json:"completed_tasks" bun:"completed_tasks"`
+	CompletedPoints   *int       `json:"completed_points" bun:"completed_points"`
+	CarriedOverTasks  *int       `json:"carried_over_tasks" bun:"carried_over_tasks"`
+	CarriedOverPoints *int       `json:"carried_over_points" bun:"carried_over_points"`
+	StartedAt         *time.Time `json:"started_at" bun:"started_at"`
+	ClosedAt          *time.Time `json:"closed_at" bun:"closed_at"`
+}
@@ -0,0 +1,12 @@
+package entity
+
+import "github.com/uptrace/bun"
+
+type TaskComments struct {
+	bun.BaseModel `bun:"table:task_comments"`
+
+	basicEntity
+	TaskId *int    `json:"task_id" bun:"task_id"`
+	UserId *int    `json:"user_id" bun:"user_id"`
+	Body   *string `json:"body" bun:"body"`
+}
@@ -0,0 +1,16 @@
+package entity
+
+import "github.com/uptrace/bun"
+
+type TaskRecurrences struct {
+	bun.BaseModel `bun:"table:task_recurrences"`
+
+	basicEntity
+	TemplateTaskId *int    `json:"template_task_id" bun:"template_task_id"`
+	Rule           *string `json:"rule" bun:"rule"`
+	Mode           *string `json:"mode" bun:"mode"`
+	LeadDays       *int    `json:"lead_days" bun:"lead_days"`
+	Dtstart        *string `json:"dtstart" bun:"dtstart"`
+	NextDate       *string `json:"next_date" bun:"next_date"`
+	Occurrences    *int    `json:"occurrences" bun:"occurrences"`
+}
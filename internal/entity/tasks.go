@@ -14,5 +14,30 @@ type Tasks struct {
 	AssignedTo  *int    `json:"assigned_to" bun:"assigned_to"`
 	Status      *string `json:"status" bun:"status"`
 	Priority    *string `json:"priority" bun:"priority"`
+	StartDate   *string `json:"start_date" bun:"start_date"`
 	DueDate     *string `json:"due_date" bun:"due_date"`
+
+	OriginalEstimate  *int `json:"original_estimate" bun:"original_estimate"`
+	RemainingEstimate *int `json:"remaining_estimate" bun:"remaining_estimate"`
+	StoryPoints       *int `json:"story_points" bun:"story_points"`
+
+	RecurrenceId   *int    `json:"recurrence_id" bun:"recurrence_id"`
+	OccurrenceDate *string `json:"occurrence_date" bun:"occurrence_date"`
+
+	Rank        *string `json:"rank" bun:"rank"`
+	SprintId    *int    `json:"sprint_id" bun:"sprint_id"`
+	MilestoneId *int    `json:"milestone_id" bun:"milestone_id"`
+
+	Labels []string `json:"labels" bun:"labels,array"`
+
+	// Version is bumped on every write, see basic_repo.ErrVersionMismatch.
+	Version int `json:"version" bun:"version"`
+
+	// Assignee and Project are embedded on request only.
+	Assignee *UserRef    `json:"assignee,omitempty" bun:"-"`
+	Project  *ProjectRef `json:"project,omitempty" bun:"-"`
+
+	// CascadeDeleted marks a task deleted along with its project, restoring
+	// the project brings it back.
+	CascadeDeleted bool `json:"-" bun:"cascade_deleted"`
 }
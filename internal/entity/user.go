@@ -11,5 +11,6 @@ type User struct {
 	FullName *string `bun:"full_name,notnull"`
 	Email    *string `bun:"email,notnull"`
 	Role     *string `bun:"role,notnull"`
-	Password *string `bun:"password,notnull"`
+	Password *string `json:"-" bun:"password,notnull"`
+	Version  int     `json:"version" bun:"version"`
 }
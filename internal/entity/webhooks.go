@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type Webhooks struct {
+	bun.BaseModel `bun:"table:webhooks"`
+
+	basicEntity
+	ProjectId *int     `json:"project_id" bun:"project_id"`
+	Url       *string  `json:"url" bun:"url"`
+	Secret    *string  `json:"-" bun:"secret"`
+	Events    []string `json:"events" bun:"events,array"`
+	Active    *bool    `json:"active" bun:"active"`
+}
+
+type WebhookDeliveries struct {
+	bun.BaseModel `bun:"table:webhook_deliveries"`
+
+	basicEntity
+	WebhookId     *int       `json:"webhook_id" bun:"webhook_id"`
+	Event         *string    `json:"event" bun:"event"`
+	Payload       *string    `json:"-" bun:"payload,type:jsonb"`
+	Status        *string    `json:"status" bun:"status"`
+	Attempts      *int       `json:"attempts" bun:"attempts"`
+	NextAttemptAt *time.Time `json:"next_attempt_at" bun:"next_attempt_at"`
+	ResponseCode  *int       `json:"response_code" bun:"response_code"`
+	ResponseBody  *string    `json:"response_body" bun:"response_body"`
+	Error         *string    `json:"error" bun:"error"`
+	DeliveredAt   *time.Time `json:"delivered_at" bun:"delivered_at"`
+}
@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type Worklogs struct {
+	bun.BaseModel `bun:"table:worklogs"`
+
+	basicEntity
+	TaskId    *int       `json:"task_id" bun:"task_id"`
+	UserId    *int       `json:"user_id" bun:"user_id"`
+	StartedAt *time.Time `json:"started_at" bun:"started_at"`
+	Duration  *int       `json:"duration" bun:"duration"`
+	Note      *string    `json:"note" bun:"note"`
+}
@@ -0,0 +1,266 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: taskmanagement/v1/common.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Page selects a page of a list. Zero values leave the server defaults.
+type Page struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Limit int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// starts at 1
+	Page          int32 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Page) Reset() {
+	*x = Page{}
+	mi := &file_taskmanagement_v1_common_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Page) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Page) ProtoMessage() {}
+
+func (x *Page) ProtoReflect() protoreflect.Message {
+	mi := &file_taskmanagement_v1_common_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Page.ProtoReflect.Descriptor instead.
+func (*Page) Descriptor() ([]byte, []int) {
+	return file_taskmanagement_v1_common_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Page) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *Page) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+// TaskStats are the counts and progress of a set of tasks.
+type TaskStats struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	TotalTasks        int32                  `protobuf:"varint,1,opt,name=total_tasks,json=totalTasks,proto3" json:"total_tasks,omitempty"`
+	CompletedTasks    int32                  `protobuf:"varint,2,opt,name=completed_tasks,json=completedTasks,proto3" json:"completed_tasks,omitempty"`
+	InProgressTasks   int32                  `protobuf:"varint,3,opt,name=in_progress_tasks,json=inProgressTasks,proto3" json:"in_progress_tasks,omitempty"`
+	PendingTasks      int32                  `protobuf:"varint,4,opt,name=pending_tasks,json=pendingTasks,proto3" json:"pending_tasks,omitempty"`
+	TotalPoints       int32                  `protobuf:"varint,5,opt,name=total_points,json=totalPoints,proto3" json:"total_points,omitempty"`
+	CompletedPoints   int32                  `protobuf:"varint,6,opt,name=completed_points,json=completedPoints,proto3" json:"completed_points,omitempty"`
+	OriginalEstimate  int32                  `protobuf:"varint,7,opt,name=original_estimate,json=originalEstimate,proto3" json:"original_estimate,omitempty"`
+	RemainingEstimate int32                  `protobuf:"varint,8,opt,name=remaining_estimate,json=remainingEstimate,proto3" json:"remaining_estimate,omitempty"`
+	// what progress is measured in: count, points or estimate
+	Weighting string `protobuf:"bytes,9,opt,name=weighting,proto3" json:"weighting,omitempty"`
+	// percentage
+	Progress      float64 `protobuf:"fixed64,10,opt,name=progress,proto3" json:"progress,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskStats) Reset() {
+	*x = TaskStats{}
+	mi := &file_taskmanagement_v1_common_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskStats) ProtoMessage() {}
+
+func (x *TaskStats) ProtoReflect() protoreflect.Message {
+	mi := &file_taskmanagement_v1_common_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskStats.ProtoReflect.Descriptor instead.
+func (*TaskStats) Descriptor() ([]byte, []int) {
+	return file_taskmanagement_v1_common_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TaskStats) GetTotalTasks() int32 {
+	if x != nil {
+		return x.TotalTasks
+	}
+	return 0
+}
+
+func (x *TaskStats) GetCompletedTasks() int32 {
+	if x != nil {
+		return x.CompletedTasks
+	}
+	return 0
+}
+
+func (x *TaskStats) GetInProgressTasks() int32 {
+	if x != nil {
+		return x.InProgressTasks
+	}
+	return 0
+}
+
+func (x *TaskStats) GetPendingTasks() int32 {
+	if x != nil {
+		return x.PendingTasks
+	}
+	return 0
+}
+
+func (x *TaskStats) GetTotalPoints() int32 {
+	if x != nil {
+		return x.TotalPoints
+	}
+	return 0
+}
+
+func (x *TaskStats) GetCompletedPoints() int32 {
+	if x != nil {
+		return x.CompletedPoints
+	}
+	return 0
+}
+
+func (x *TaskStats) GetOriginalEstimate() int32 {
+	if x != nil {
+		return x.OriginalEstimate
+	}
+	return 0
+}
+
+func (x *TaskStats) GetRemainingEstimate() int32 {
+	if x != nil {
+		return x.RemainingEstimate
+	}
+	return 0
+}
+
+func (x *TaskStats) GetWeighting() string {
+	if x != nil {
+		return x.Weighting
+	}
+	return ""
+}
+
+func (x *TaskStats) GetProgress() float64 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+var File_taskmanagement_v1_common_proto protoreflect.FileDescriptor
+
+const file_taskmanagement_v1_common_proto_rawDesc = "" +
+	"\n" +
+	"\x1etaskmanagement/v1/common.proto\x12\x11taskmanagement.v1\"0\n" +
+	"\x04Page\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\"\x8a\x03\n" +
+	"\tTaskStats\x12\x1f\n" +
+	"\vtotal_tasks\x18\x01 \x01(\x05R\n" +
+	"totalTasks\x12'\n" +
+	"\x0fcompleted_tasks\x18\x02 \x01(\x05R\x0ecompletedTasks\x12*\n" +
+	"\x11in_progress_tasks\x18\x03 \x01(\x05R\x0finProgressTasks\x12#\n" +
+	"\rpending_tasks\x18\x04 \x01(\x05R\fpendingTasks\x12!\n" +
+	"\ftotal_points\x18\x05 \x01(\x05R\vtotalPoints\x12)\n" +
+	"\x10completed_points\x18\x06 \x01(\x05R\x0fcompletedPoints\x12+\n" +
+	"\x11original_estimate\x18\a \x01(\x05R\x10originalEstimate\x12-\n" +
+	"\x12remaining_estimate\x18\b \x01(\x05R\x11remainingEstimate\x12\x1c\n" +
+	"\tweighting\x18\t \x01(\tR\tweighting\x12\x1a\n" +
+	"\bprogress\x18\n" +
+	" \x01(\x01R\bprogressB#Z!task-management2/internal/grpc/pbb\x06proto3"
+
+var (
+	file_taskmanagement_v1_common_proto_rawDescOnce sync.Once
+	file_taskmanagement_v1_common_proto_rawDescData []byte
+)
+
+func file_taskmanagement_v1_common_proto_rawDescGZIP() []byte {
+	file_taskmanagement_v1_common_proto_rawDescOnce.Do(func() {
+		file_taskmanagement_v1_common_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_taskmanagement_v1_common_proto_rawDesc), len(file_taskmanagement_v1_common_proto_rawDesc)))
+	})
+	return file_taskmanagement_v1_common_proto_rawDescData
+}
+
+var file_taskmanagement_v1_common_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_taskmanagement_v1_common_proto_goTypes = []any{
+	(*Page)(nil),      // 0: taskmanagement.v1.Page
+	(*TaskStats)(nil), // 1: taskmanagement.v1.TaskStats
+}
+var file_taskmanagement_v1_common_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_taskmanagement_v1_common_proto_init() }
+func file_taskmanagement_v1_common_proto_init() {
+	if File_taskmanagement_v1_common_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_taskmanagement_v1_common_proto_rawDesc), len(file_taskmanagement_v1_common_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_taskmanagement_v1_common_proto_goTypes,
+		DependencyIndexes: file_taskmanagement_v1_common_proto_depIdxs,
+		MessageInfos:      file_taskmanagement_v1_common_proto_msgTypes,
+	}.Build()
+	File_taskmanagement_v1_common_proto = out.File
+	file_taskmanagement_v1_common_proto_goTypes = nil
+	file_taskmanagement_v1_common_proto_depIdxs = nil
+}
@@ -0,0 +1,5 @@
+// Package pb holds the messages and services generated from the protobuf
+// definitions in proto/taskmanagement/v1.
+package pb
+
+//go:generate protoc -I ../../../proto --go_out=../../.. --go_opt=module=task-management2 --go-grpc_out=../../.. --go-grpc_opt=module=task-management2 taskmanagement/v1/common.proto taskmanagement/v1/users.proto taskmanagement/v1/projects.proto taskmanagement/v1/tasks.proto
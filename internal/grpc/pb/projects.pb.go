@@ -0,0 +1,641 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: taskmanagement/v1/projects.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Project struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	OwnerId     int64                  `protobuf:"varint,4,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	Version     int32                  `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	// set by ListProjects, which only fills total_tasks and progress, and
+	// GetProject
+	TaskStats     *TaskStats `protobuf:"bytes,6,opt,name=task_stats,json=taskStats,proto3" json:"task_stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Project) Reset() {
+	*x = Project{}
+	mi := &file_taskmanagement_v1_projects_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Project) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Project) ProtoMessage() {}
+
+func (x *Project) ProtoReflect() protoreflect.Message {
+	mi := &file_taskmanagement_v1_projects_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Project.ProtoReflect.Descriptor instead.
+func (*Project) Descriptor() ([]byte, []int) {
+	return file_taskmanagement_v1_projects_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Project) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Project) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Project) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Project) GetOwnerId() int64 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+func (x *Project) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Project) GetTaskStats() *TaskStats {
+	if x != nil {
+		return x.TaskStats
+	}
+	return nil
+}
+
+type ListProjectsRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Page    *Page                  `protobuf:"bytes,1,opt,name=page,proto3" json:"page,omitempty"`
+	OwnerId *int64                 `protobuf:"varint,2,opt,name=owner_id,json=ownerId,proto3,oneof" json:"owner_id,omitempty"`
+	// count, points or estimate
+	Weighting     string `protobuf:"bytes,3,opt,name=weighting,proto3" json:"weighting,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectsRequest) Reset() {
+	*x = ListProjectsRequest{}
+	mi := &file_taskmanagement_v1_projects_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectsRequest) ProtoMessage() {}
+
+func (x *ListProjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskmanagement_v1_projects_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectsRequest.ProtoReflect.Descriptor instead.
+func (*ListProjectsRequest) Descriptor() ([]byte, []int) {
+	return file_taskmanagement_v1_projects_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListProjectsRequest) GetPage() *Page {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+func (x *ListProjectsRequest) GetOwnerId() int64 {
+	if x != nil && x.OwnerId != nil {
+		return *x.OwnerId
+	}
+	return 0
+}
+
+func (x *ListProjectsRequest) GetWeighting() string {
+	if x != nil {
+		return x.Weighting
+	}
+	return ""
+}
+
+type ListProjectsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Projects      []*Project             `protobuf:"bytes,1,rep,name=projects,proto3" json:"projects,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectsResponse) Reset() {
+	*x = ListProjectsResponse{}
+	mi := &file_taskmanagement_v1_projects_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectsResponse) ProtoMessage() {}
+
+func (x *ListProjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_taskmanagement_v1_projects_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectsResponse.ProtoReflect.Descriptor instead.
+func (*ListProjectsResponse) Descriptor() ([]byte, []int) {
+	return file_taskmanagement_v1_projects_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListProjectsResponse) GetProjects() []*Project {
+	if x != nil {
+		return x.Projects
+	}
+	return nil
+}
+
+func (x *ListProjectsResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type GetProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Weighting     string                 `protobuf:"bytes,2,opt,name=weighting,proto3" json:"weighting,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProjectRequest) Reset() {
+	*x = GetProjectRequest{}
+	mi := &file_taskmanagement_v1_projects_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProjectRequest) ProtoMessage() {}
+
+func (x *GetProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskmanagement_v1_projects_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProjectRequest.ProtoReflect.Descriptor instead.
+func (*GetProjectRequest) Descriptor() ([]byte, []int) {
+	return file_taskmanagement_v1_projects_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetProjectRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetProjectRequest) GetWeighting() string {
+	if x != nil {
+		return x.Weighting
+	}
+	return ""
+}
+
+type CreateProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   *string                `protobuf:"bytes,2,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	OwnerId       int64                  `protobuf:"varint,3,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProjectRequest) Reset() {
+	*x = CreateProjectRequest{}
+	mi := &file_taskmanagement_v1_projects_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProjectRequest) ProtoMessage() {}
+
+func (x *CreateProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskmanagement_v1_projects_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProjectRequest.ProtoReflect.Descriptor instead.
+func (*CreateProjectRequest) Descriptor() ([]byte, []int) {
+	return file_taskmanagement_v1_projects_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateProjectRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateProjectRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *CreateProjectRequest) GetOwnerId() int64 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+// UpdateProjectRequest changes the fields that are set.
+type UpdateProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Description   *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	OwnerId       *int64                 `protobuf:"varint,4,opt,name=owner_id,json=ownerId,proto3,oneof" json:"owner_id,omitempty"`
+	Version       *int32                 `protobuf:"varint,5,opt,name=version,proto3,oneof" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProjectRequest) Reset() {
+	*x = UpdateProjectRequest{}
+	mi := &file_taskmanagement_v1_projects_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProjectRequest) ProtoMessage() {}
+
+func (x *UpdateProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskmanagement_v1_projects_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProjectRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProjectRequest) Descriptor() ([]byte, []int) {
+	return file_taskmanagement_v1_projects_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateProjectRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateProjectRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateProjectRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateProjectRequest) GetOwnerId() int64 {
+	if x != nil && x.OwnerId != nil {
+		return *x.OwnerId
+	}
+	return 0
+}
+
+func (x *UpdateProjectRequest) GetVersion() int32 {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return 0
+}
+
+type DeleteProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version       *int32                 `protobuf:"varint,2,opt,name=version,proto3,oneof" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProjectRequest) Reset() {
+	*x = DeleteProjectRequest{}
+	mi := &file_taskmanagement_v1_projects_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProjectRequest) ProtoMessage() {}
+
+func (x *DeleteProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskmanagement_v1_projects_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProjectRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProjectRequest) Descriptor() ([]byte, []int) {
+	return file_taskmanagement_v1_projects_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteProjectRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteProjectRequest) GetVersion() int32 {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return 0
+}
+
+type RestoreProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreProjectRequest) Reset() {
+	*x = RestoreProjectRequest{}
+	mi := &file_taskmanagement_v1_projects_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreProjectRequest) ProtoMessage() {}
+
+func (x *RestoreProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskmanagement_v1_projects_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreProjectRequest.ProtoReflect.Descriptor instead.
+func (*RestoreProjectRequest) Descriptor() ([]byte, []int) {
+	return file_taskmanagement_v1_projects_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RestoreProjectRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+var File_taskmanagement_v1_projects_proto protoreflect.FileDescriptor
+
+const file_taskmanagement_v1_projects_proto_rawDesc = "" +
+	"\n" +
+	" taskmanagement/v1/projects.proto\x12\x11taskmanagement.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1etaskmanagement/v1/common.proto\"\xc1\x01\n" +
+	"\aProject\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x19\n" +
+	"\bowner_id\x18\x04 \x01(\x03R\aownerId\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\x05R\aversion\x12;\n" +
+	"\n" +
+	"task_stats\x18\x06 \x01(\v2\x1c.taskmanagement.v1.TaskStatsR\ttaskStats\"\x8d\x01\n" +
+	"\x13ListProjectsRequest\x12+\n" +
+	"\x04page\x18\x01 \x01(\v2\x17.taskmanagement.v1.PageR\x04page\x12\x1e\n" +
+	"\bowner_id\x18\x02 \x01(\x03H\x00R\aownerId\x88\x01\x01\x12\x1c\n" +
+	"\tweighting\x18\x03 \x01(\tR\tweightingB\v\n" +
+	"\t_owner_id\"d\n" +
+	"\x14ListProjectsResponse\x126\n" +
+	"\bprojects\x18\x01 \x03(\v2\x1a.taskmanagement.v1.ProjectR\bprojects\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"A\n" +
+	"\x11GetProjectRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1c\n" +
+	"\tweighting\x18\x02 \x01(\tR\tweighting\"|\n" +
+	"\x14CreateProjectRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12%\n" +
+	"\vdescription\x18\x02 \x01(\tH\x00R\vdescription\x88\x01\x01\x12\x19\n" +
+	"\bowner_id\x18\x03 \x01(\x03R\aownerIdB\x0e\n" +
+	"\f_description\"\xd7\x01\n" +
+	"\x14UpdateProjectRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01\x12\x1e\n" +
+	"\bowner_id\x18\x04 \x01(\x03H\x02R\aownerId\x88\x01\x01\x12\x1d\n" +
+	"\aversion\x18\x05 \x01(\x05H\x03R\aversion\x88\x01\x01B\a\n" +
+	"\x05_nameB\x0e\n" +
+	"\f_descriptionB\v\n" +
+	"\t_owner_idB\n" +
+	"\n" +
+	"\b_version\"Q\n" +
+	"\x14DeleteProjectRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\aversion\x18\x02 \x01(\x05H\x00R\aversion\x88\x01\x01B\n" +
+	"\n" +
+	"\b_version\"'\n" +
+	"\x15RestoreProjectRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id2\x97\x04\n" +
+	"\x0eProjectService\x12_\n" +
+	"\fListProjects\x12&.taskmanagement.v1.ListProjectsRequest\x1a'.taskmanagement.v1.ListProjectsResponse\x12N\n" +
+	"\n" +
+	"GetProject\x12$.taskmanagement.v1.GetProjectRequest\x1a\x1a.taskmanagement.v1.Project\x12T\n" +
+	"\rCreateProject\x12'.taskmanagement.v1.CreateProjectRequest\x1a\x1a.taskmanagement.v1.Project\x12T\n" +
+	"\rUpdateProject\x12'.taskmanagement.v1.UpdateProjectRequest\x1a\x1a.taskmanagement.v1.Project\x12P\n" +
+	"\rDeleteProject\x12'.taskmanagement.v1.DeleteProjectRequest\x1a\x16.google.protobuf.Empty\x12V\n" +
+	"\x0eRestoreProject\x12(.taskmanagement.v1.RestoreProjectRequest\x1a\x1a.taskmanagement.v1.ProjectB#Z!task-management2/internal/grpc/pbb\x06proto3"
+
+var (
+	file_taskmanagement_v1_projects_proto_rawDescOnce sync.Once
+	file_taskmanagement_v1_projects_proto_rawDescData []byte
+)
+
+func file_taskmanagement_v1_projects_proto_rawDescGZIP() []byte {
+	file_taskmanagement_v1_projects_proto_rawDescOnce.Do(func() {
+		file_taskmanagement_v1_projects_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_taskmanagement_v1_projects_proto_rawDesc), len(file_taskmanagement_v1_projects_proto_rawDesc)))
+	})
+	return file_taskmanagement_v1_projects_proto_rawDescData
+}
+
+var file_taskmanagement_v1_projects_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_taskmanagement_v1_projects_proto_goTypes = []any{
+	(*Project)(nil),               // 0: taskmanagement.v1.Project
+	(*ListProjectsRequest)(nil),   // 1: taskmanagement.v1.ListProjectsRequest
+	(*ListProjectsResponse)(nil),  // 2: taskmanagement.v1.ListProjectsResponse
+	(*GetProjectRequest)(nil),     // 3: taskmanagement.v1.GetProjectRequest
+	(*CreateProjectRequest)(nil),  // 4: taskmanagement.v1.CreateProjectRequest
+	(*UpdateProjectRequest)(nil),  // 5: taskmanagement.v1.UpdateProjectRequest
+	(*DeleteProjectRequest)(nil),  // 6: taskmanagement.v1.DeleteProjectRequest
+	(*RestoreProjectRequest)(nil), // 7: taskmanagement.v1.RestoreProjectRequest
+	(*TaskStats)(nil),             // 8: taskmanagement.v1.TaskStats
+	(*Page)(nil),                  // 9: taskmanagement.v1.Page
+	(*emptypb.Empty)(nil),         // 10: google.protobuf.Empty
+}
+var file_taskmanagement_v1_projects_proto_depIdxs = []int32{
+	8,  // 0: taskmanagement.v1.Project.task_stats:type_name -> taskmanagement.v1.TaskStats
+	9,  // 1: taskmanagement.v1.ListProjectsRequest.page:type_name -> taskmanagement.v1.Page
+	0,  // 2: taskmanagement.v1.ListProjectsResponse.projects:type_name -> taskmanagement.v1.Project
+	1,  // 3: taskmanagement.v1.ProjectService.ListProjects:input_type -> taskmanagement.v1.ListProjectsRequest
+	3,  // 4: taskmanagement.v1.ProjectService.GetProject:input_type -> taskmanagement.v1.GetProjectRequest
+	4,  // 5: taskmanagement.v1.ProjectService.CreateProject:input_type -> taskmanagement.v1.CreateProjectRequest
+	5,  // 6: taskmanagement.v1.ProjectService.UpdateProject:input_type -> taskmanagement.v1.UpdateProjectRequest
+	6,  // 7: taskmanagement.v1.ProjectService.DeleteProject:input_type -> taskmanagement.v1.DeleteProjectRequest
+	7,  // 8: taskmanagement.v1.ProjectService.RestoreProject:input_type -> taskmanagement.v1.RestoreProjectRequest
+	2,  // 9: taskmanagement.v1.ProjectService.ListProjects:output_type -> taskmanagement.v1.ListProjectsResponse
+	0,  // 10: taskmanagement.v1.ProjectService.GetProject:output_type -> taskmanagement.v1.Project
+	0,  // 11: taskmanagement.v1.ProjectService.CreateProject:output_type -> taskmanagement.v1.Project
+	0,  // 12: taskmanagement.v1.ProjectService.UpdateProject:output_type -> taskmanagement.v1.Project
+	10, // 13: taskmanagement.v1.ProjectService.DeleteProject:output_type -> google.protobuf.Empty
+	0,  // 14: taskmanagement.v1.ProjectService.RestoreProject:output_type -> taskmanagement.v1.Project
+	9,  // [9:15] is the sub-list for method output_type
+	3,  // [3:9] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_taskmanagement_v1_projects_proto_init() }
+func file_taskmanagement_v1_projects_proto_init() {
+	if File_taskmanagement_v1_projects_proto != nil {
+		return
+	}
+	file_taskmanagement_v1_common_proto_init()
+	file_taskmanagement_v1_projects_proto_msgTypes[1].OneofWrappers = []any{}
+	file_taskmanagement_v1_projects_proto_msgTypes[4].OneofWrappers = []any{}
+	file_taskmanagement_v1_projects_proto_msgTypes[5].OneofWrappers = []any{}
+	file_taskmanagement_v1_projects_proto_msgTypes[6].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_taskmanagement_v1_projects_proto_rawDesc), len(file_taskmanagement_v1_projects_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_taskmanagement_v1_projects_proto_goTypes,
+		DependencyIndexes: file_taskmanagement_v1_projects_proto_depIdxs,
+		MessageInfos:      file_taskmanagement_v1_projects_proto_msgTypes,
+	}.Build()
+	File_taskmanagement_v1_projects_proto = out.File
+	file_taskmanagement_v1_projects_proto_goTypes = nil
+	file_taskmanagement_v1_projects_proto_depIdxs = nil
+}
@@ -0,0 +1,1282 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: taskmanagement/v1/tasks.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Task struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProjectId   int64                  `protobuf:"varint,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Name        string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description *string                `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	AssignedTo  *int64                 `protobuf:"varint,5,opt,name=assigned_to,json=assignedTo,proto3,oneof" json:"assigned_to,omitempty"`
+	// pending, in_progress or completed
+	Status string `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	// low, medium or high
+	Priority string `protobuf:"bytes,7,opt,name=priority,proto3" json:"priority,omitempty"`
+	// dates are YYYY-MM-DD
+	StartDate         *string                `protobuf:"bytes,8,opt,name=start_date,json=startDate,proto3,oneof" json:"start_date,omitempty"`
+	DueDate           string                 `protobuf:"bytes,9,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	OriginalEstimate  *int32                 `protobuf:"varint,10,opt,name=original_estimate,json=originalEstimate,proto3,oneof" json:"original_estimate,omitempty"`
+	RemainingEstimate *int32                 `protobuf:"varint,11,opt,name=remaining_estimate,json=remainingEstimate,proto3,oneof" json:"remaining_estimate,omitempty"`
+	StoryPoints       *int32                 `protobuf:"varint,12,opt,name=story_points,json=storyPoints,proto3,oneof" json:"story_points,omitempty"`
+	SprintId          *int64                 `protobuf:"varint,13,opt,name=sprint_id,json=sprintId,proto3,oneof" json:"sprint_id,omitempty"`
+	MilestoneId       *int64                 `protobuf:"varint,14,opt,name=milestone_id,json=milestoneId,proto3,oneof" json:"milestone_id,omitempty"`
+	Labels            []string               `protobuf:"bytes,15,rep,name=labels,proto3" json:"labels,omitempty"`
+	Version           int32                  `protobuf:"varint,16,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Task) Reset() {
+	*x = Task{}
+	mi := &file_taskmanagement_v1_tasks_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Task) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Task) ProtoMessage() {}
+
+func (x *Task) ProtoReflect() protoreflect.Message {
+	mi := &file_taskmanagement_v1_tasks_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Task.ProtoReflect.Descriptor instead.
+func (*Task) Descriptor() ([]byte, []int) {
+	return file_taskmanagement_v1_tasks_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Task) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Task) GetProjectId() int64 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *Task) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Task) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *Task) GetAssignedTo() int64 {
+	if x != nil && x.AssignedTo != nil {
+		return *x.AssignedTo
+	}
+	return 0
+}
+
+func (x *Task) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Task) GetPriority() string {
+	if x != nil {
+		return x.Priority
+	}
+	return ""
+}
+
+func (x *Task) GetStartDate() string {
+	if x != nil && x.StartDate != nil {
+		return *x.StartDate
+	}
+	return ""
+}
+
+func (x *Task) GetDueDate() string {
+	if x != nil {
+		return x.DueDate
+	}
+	return ""
+}
+
+func (x *Task) GetOriginalEstimate() int32 {
+	if x != nil && x.OriginalEstimate != nil {
+		return *x.OriginalEstimate
+	}
+	return 0
+}
+
+func (x *Task) GetRemainingEstimate() int32 {
+	if x != nil && x.RemainingEstimate != nil {
+		return *x.RemainingEstimate
+	}
+	return 0
+}
+
+func (x *Task) GetStoryPoints() int32 {
+	if x != nil && x.StoryPoints != nil {
+		return *x.StoryPoints
+	}
+	return 0
+}
+
+func (x *Task) GetSprintId() int64 {
+	if x != nil && x.SprintId != nil {
+		return *x.SprintId
+	}
+	return 0
+}
+
+func (x *Task) GetMilestoneId() int64 {
+	if x != nil && x.MilestoneId != nil {
+		return *x.MilestoneId
+	}
+	return 0
+}
+
+func (x *Task) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Task) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Task) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Task) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ListTasksRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Page        *Page                  `protobuf:"bytes,1,opt,name=page,proto3" json:"page,omitempty"`
+	ProjectId   *int64                 `protobuf:"varint,2,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"`
+	SprintId    *int64                 `protobuf:"varint,3,opt,name=sprint_id,json=sprintId,proto3,oneof" json:"sprint_id,omitempty"`
+	MilestoneId *int64                 `protobuf:"varint,4,opt,name=milestone_id,json=milestoneId,proto3,oneof" json:"milestone_id,omitempty"`
+	// what the stats are measured in: count, points or estimate
+	Weighting     string `protobuf:"bytes,5,opt,name=weighting,proto3" json:"weighting,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksRequest) Reset() {
+	*x = ListTasksRequest{}
+	mi := &file_taskmanagement_v1_tasks_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksRequest) ProtoMessage() {}
+
+func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskmanagement_v1_tasks_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListTasksRequest) Descriptor() ([]byte, []int) {
+	return file_taskmanagement_v1_tasks_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListTasksRequest) GetPage() *Page {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+func (x *ListTasksRequest) GetProjectId() int64 {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return 0
+}
+
+func (x *ListTasksRequest) GetSprintId() int64 {
+	if x != nil && x.SprintId != nil {
+		return *x.SprintId
+	}
+	return 0
+}
+
+func (x *ListTasksRequest) GetMilestoneId() int64 {
+	if x != nil && x.MilestoneId != nil {
+		return *x.MilestoneId
+	}
+	return 0
+}
+
+func (x *ListTasksRequest) GetWeighting() string {
+	if x != nil {
+		return x.Weighting
+	}
+	return ""
+}
+
+type ListTasksResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Tasks []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	Count int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// of every task matching the filter
+	Stats         *TaskStats `protobuf:"bytes,3,opt,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksResponse) Reset() {
+	*x = ListTasksResponse{}
+	mi := &file_taskmanagement_v1_tasks_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksResponse) ProtoMessage() {}
+
+func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_taskmanagement_v1_tasks_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListTasksResponse) Descriptor() ([]byte, []int) {
+	return file_taskmanagement_v1_tasks_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListTasksResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *ListTasksResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *ListTasksResponse) GetStats() *TaskStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+type GetTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskRequest) Reset() {
+	*x = GetTaskRequest{}
+	mi := &file_taskmanagement_v1_tasks_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskRequest) ProtoMessage() {}
+
+func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskmanagement_v1_tasks_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskRequest) Descriptor() ([]byte, []int) {
+	return file_taskmanagement_v1_tasks_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetTaskRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type CreateTaskRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId         int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Name              string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description       *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	AssignedTo        *int64                 `protobuf:"varint,4,opt,name=assigned_to,json=assignedTo,proto3,oneof" json:"assigned_to,omitempty"`
+	Status            string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Priority          string                 `protobuf:"bytes,6,opt,name=priority,proto3" json:"priority,omitempty"`
+	StartDate         *string                `protobuf:"bytes,7,opt,name=start_date,json=startDate,proto3,oneof" json:"start_date,omitempty"`
+	DueDate           string                 `protobuf:"bytes,8,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	OriginalEstimate  *int32                 `protobuf:"varint,9,opt,name=original_estimate,json=originalEstimate,proto3,oneof" json:"original_estimate,omitempty"`
+	RemainingEstimate *int32                 `protobuf:"varint,10,opt,name=remaining_estimate,json=remainingEstimate,proto3,oneof" json:"remaining_estimate,omitempty"`
+	StoryPoints       *int32                 `protobuf:"varint,11,opt,name=story_points,json=storyPoints,proto3,oneof" json:"story_points,omitempty"`
+	Labels            []string               `protobuf:"bytes,12,rep,name=labels,proto3" json:"labels,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *CreateTaskRequest) Reset() {
+	*x = CreateTaskRequest{}
+	mi := &file_taskmanagement_v1_tasks_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTaskRequest) ProtoMessage() {}
+
+func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskmanagement_v1_tasks_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTaskRequest.ProtoReflect.Descriptor instead.
+func (*CreateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_taskmanagement_v1_tasks_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateTaskRequest) GetProjectId() int64 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *CreateTaskRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetAssignedTo() int64 {
+	if x != nil && x.AssignedTo != nil {
+		return *x.AssignedTo
+	}
+	return 0
+}
+
+func (x *CreateTaskRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetPriority() string {
+	if x != nil {
+		return x.Priority
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetStartDate() string {
+	if x != nil && x.StartDate != nil {
+		return *x.StartDate
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetDueDate() string {
+	if x != nil {
+		return x.DueDate
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetOriginalEstimate() int32 {
+	if x != nil && x.OriginalEstimate != nil {
+		return *x.OriginalEstimate
+	}
+	return 0
+}
+
+func (x *CreateTaskRequest) GetRemainingEstimate() int32 {
+	if x != nil && x.RemainingEstimate != nil {
+		return *x.RemainingEstimate
+	}
+	return 0
+}
+
+func (x *CreateTaskRequest) GetStoryPoints() int32 {
+	if x != nil && x.StoryPoints != nil {
+		return *x.StoryPoints
+	}
+	return 0
+}
+
+func (x *CreateTaskRequest) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+// UpdateTaskRequest changes the fields that are set.
+type UpdateTaskRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProjectId         *int64                 `protobuf:"varint,2,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"`
+	Name              *string                `protobuf:"bytes,3,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Description       *string                `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	AssignedTo        *int64                 `protobuf:"varint,5,opt,name=assigned_to,json=assignedTo,proto3,oneof" json:"assigned_to,omitempty"`
+	Status            *string                `protobuf:"bytes,6,opt,name=status,proto3,oneof" json:"status,omitempty"`
+	Priority          *string                `protobuf:"bytes,7,opt,name=priority,proto3,oneof" json:"priority,omitempty"`
+	StartDate         *string                `protobuf:"bytes,8,opt,name=start_date,json=startDate,proto3,oneof" json:"start_date,omitempty"`
+	DueDate           *string                `protobuf:"bytes,9,opt,name=due_date,json=dueDate,proto3,oneof" json:"due_date,omitempty"`
+	OriginalEstimate  *int32                 `protobuf:"varint,10,opt,name=original_estimate,json=originalEstimate,proto3,oneof" json:"original_estimate,omitempty"`
+	RemainingEstimate *int32                 `protobuf:"varint,11,opt,name=remaining_estimate,json=remainingEstimate,proto3,oneof" json:"remaining_estimate,omitempty"`
+	StoryPoints       *int32                 `protobuf:"varint,12,opt,name=story_points,json=storyPoints,proto3,oneof" json:"story_points,omitempty"`
+	// replaces the labels when set
+	Labels        *Labels `protobuf:"bytes,13,opt,name=labels,proto3" json:"labels,omitempty"`
+	Version       *int32  `protobuf:"varint,14,opt,name=version,proto3,oneof" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTaskRequest) Reset() {
+	*x = UpdateTaskRequest{}
+	mi := &file_taskmanagement_v1_tasks_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTaskRequest) ProtoMessage() {}
+
+func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskmanagement_v1_tasks_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTaskRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_taskmanagement_v1_tasks_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateTaskRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateTaskRequest) GetProjectId() int64 {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return 0
+}
+
+func (x *UpdateTaskRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetAssignedTo() int64 {
+	if x != nil && x.AssignedTo != nil {
+		return *x.AssignedTo
+	}
+	return 0
+}
+
+func (x *UpdateTaskRequest) GetStatus() string {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetPriority() string {
+	if x != nil && x.Priority != nil {
+		return *x.Priority
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetStartDate() string {
+	if x != nil && x.StartDate != nil {
+		return *x.StartDate
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetDueDate() string {
+	if x != nil && x.DueDate != nil {
+		return *x.DueDate
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetOriginalEstimate() int32 {
+	if x != nil && x.OriginalEstimate != nil {
+		return *x.OriginalEstimate
+	}
+	return 0
+}
+
+func (x *UpdateTaskRequest) GetRemainingEstimate() int32 {
+	if x != nil && x.RemainingEstimate != nil {
+		return *x.RemainingEstimate
+	}
+	return 0
+}
+
+func (x *UpdateTaskRequest) GetStoryPoints() int32 {
+	if x != nil && x.StoryPoints != nil {
+		return *x.StoryPoints
+	}
+	return 0
+}
+
+func (x *UpdateTaskRequest) GetLabels() *Labels {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *UpdateTaskRequest) GetVersion() int32 {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return 0
+}
+
+type Labels struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Labels        []string               `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Labels) Reset() {
+	*x = Labels{}
+	mi := &file_taskmanagement_v1_tasks_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Labels) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Labels) ProtoMessage() {}
+
+func (x *Labels) ProtoReflect() protoreflect.Message {
+	mi := &file_taskmanagement_v1_tasks_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Labels.ProtoReflect.Descriptor instead.
+func (*Labels) Descriptor() ([]byte, []int) {
+	return file_taskmanagement_v1_tasks_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Labels) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type DeleteTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version       *int32                 `protobuf:"varint,2,opt,name=version,proto3,oneof" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaskRequest) Reset() {
+	*x = DeleteTaskRequest{}
+	mi := &file_taskmanagement_v1_tasks_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskRequest) ProtoMessage() {}
+
+func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskmanagement_v1_tasks_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTaskRequest) Descriptor() ([]byte, []int) {
+	return file_taskmanagement_v1_tasks_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteTaskRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteTaskRequest) GetVersion() int32 {
+	if x != nil && x.Version != nil {
+		return *x.Version
+	}
+	return 0
+}
+
+type MoveTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	AfterId       *int64                 `protobuf:"varint,3,opt,name=after_id,json=afterId,proto3,oneof" json:"after_id,omitempty"`
+	BeforeId      *int64                 `protobuf:"varint,4,opt,name=before_id,json=beforeId,proto3,oneof" json:"before_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveTaskRequest) Reset() {
+	*x = MoveTaskRequest{}
+	mi := &file_taskmanagement_v1_tasks_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveTaskRequest) ProtoMessage() {}
+
+func (x *MoveTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskmanagement_v1_tasks_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveTaskRequest.ProtoReflect.Descriptor instead.
+func (*MoveTaskRequest) Descriptor() ([]byte, []int) {
+	return file_taskmanagement_v1_tasks_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *MoveTaskRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MoveTaskRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *MoveTaskRequest) GetAfterId() int64 {
+	if x != nil && x.AfterId != nil {
+		return *x.AfterId
+	}
+	return 0
+}
+
+func (x *MoveTaskRequest) GetBeforeId() int64 {
+	if x != nil && x.BeforeId != nil {
+		return *x.BeforeId
+	}
+	return 0
+}
+
+type RestoreTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreTaskRequest) Reset() {
+	*x = RestoreTaskRequest{}
+	mi := &file_taskmanagement_v1_tasks_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreTaskRequest) ProtoMessage() {}
+
+func (x *RestoreTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskmanagement_v1_tasks_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreTaskRequest.ProtoReflect.Descriptor instead.
+func (*RestoreTaskRequest) Descriptor() ([]byte, []int) {
+	return file_taskmanagement_v1_tasks_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RestoreTaskRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type WatchTasksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ignored, the stream is of the user whose token the authorization
+	// metadata carries as "Bearer <token>"
+	UserId int64 `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// only events of these projects, all the user may see when empty
+	ProjectIds []int64 `protobuf:"varint,2,rep,packed,name=project_ids,json=projectIds,proto3" json:"project_ids,omitempty"`
+	// replay the events after this one first, to resume a stream
+	AfterEventId  int64 `protobuf:"varint,3,opt,name=after_event_id,json=afterEventId,proto3" json:"after_event_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchTasksRequest) Reset() {
+	*x = WatchTasksRequest{}
+	mi := &file_taskmanagement_v1_tasks_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTasksRequest) ProtoMessage() {}
+
+func (x *WatchTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_taskmanagement_v1_tasks_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchTasksRequest.ProtoReflect.Descriptor instead.
+func (*WatchTasksRequest) Descriptor() ([]byte, []int) {
+	return file_taskmanagement_v1_tasks_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *WatchTasksRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *WatchTasksRequest) GetProjectIds() []int64 {
+	if x != nil {
+		return x.ProjectIds
+	}
+	return nil
+}
+
+func (x *WatchTasksRequest) GetAfterEventId() int64 {
+	if x != nil {
+		return x.AfterEventId
+	}
+	return 0
+}
+
+type TaskEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// outbox id, pass the last one as after_event_id to resume
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// such as task.created or task.updated
+	Type      string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	TaskId    int64  `protobuf:"varint,3,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	ProjectId *int64 `protobuf:"varint,4,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"`
+	// the event payload as JSON
+	Payload       string                 `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskEvent) Reset() {
+	*x = TaskEvent{}
+	mi := &file_taskmanagement_v1_tasks_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskEvent) ProtoMessage() {}
+
+func (x *TaskEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_taskmanagement_v1_tasks_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskEvent.ProtoReflect.Descriptor instead.
+func (*TaskEvent) Descriptor() ([]byte, []int) {
+	return file_taskmanagement_v1_tasks_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TaskEvent) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TaskEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TaskEvent) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *TaskEvent) GetProjectId() int64 {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return 0
+}
+
+func (x *TaskEvent) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *TaskEvent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+var File_taskmanagement_v1_tasks_proto protoreflect.FileDescriptor
+
+const file_taskmanagement_v1_tasks_proto_rawDesc = "" +
+	"\n" +
+	"\x1dtaskmanagement/v1/tasks.proto\x12\x11taskmanagement.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1etaskmanagement/v1/common.proto\"\x95\x06\n" +
+	"\x04Task\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\x03R\tprojectId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12%\n" +
+	"\vdescription\x18\x04 \x01(\tH\x00R\vdescription\x88\x01\x01\x12$\n" +
+	"\vassigned_to\x18\x05 \x01(\x03H\x01R\n" +
+	"assignedTo\x88\x01\x01\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1a\n" +
+	"\bpriority\x18\a \x01(\tR\bpriority\x12\"\n" +
+	"\n" +
+	"start_date\x18\b \x01(\tH\x02R\tstartDate\x88\x01\x01\x12\x19\n" +
+	"\bdue_date\x18\t \x01(\tR\adueDate\x120\n" +
+	"\x11original_estimate\x18\n" +
+	" \x01(\x05H\x03R\x10originalEstimate\x88\x01\x01\x122\n" +
+	"\x12remaining_estimate\x18\v \x01(\x05H\x04R\x11remainingEstimate\x88\x01\x01\x12&\n" +
+	"\fstory_points\x18\f \x01(\x05H\x05R\vstoryPoints\x88\x01\x01\x12 \n" +
+	"\tsprint_id\x18\r \x01(\x03H\x06R\bsprintId\x88\x01\x01\x12&\n" +
+	"\fmilestone_id\x18\x0e \x01(\x03H\aR\vmilestoneId\x88\x01\x01\x12\x16\n" +
+	"\x06labels\x18\x0f \x03(\tR\x06labels\x12\x18\n" +
+	"\aversion\x18\x10 \x01(\x05R\aversion\x129\n" +
+	"\n" +
+	"created_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\x0e\n" +
+	"\f_descriptionB\x0e\n" +
+	"\f_assigned_toB\r\n" +
+	"\v_start_dateB\x14\n" +
+	"\x12_original_estimateB\x15\n" +
+	"\x13_remaining_estimateB\x0f\n" +
+	"\r_story_pointsB\f\n" +
+	"\n" +
+	"_sprint_idB\x0f\n" +
+	"\r_milestone_id\"\xf9\x01\n" +
+	"\x10ListTasksRequest\x12+\n" +
+	"\x04page\x18\x01 \x01(\v2\x17.taskmanagement.v1.PageR\x04page\x12\"\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\x03H\x00R\tprojectId\x88\x01\x01\x12 \n" +
+	"\tsprint_id\x18\x03 \x01(\x03H\x01R\bsprintId\x88\x01\x01\x12&\n" +
+	"\fmilestone_id\x18\x04 \x01(\x03H\x02R\vmilestoneId\x88\x01\x01\x12\x1c\n" +
+	"\tweighting\x18\x05 \x01(\tR\tweightingB\r\n" +
+	"\v_project_idB\f\n" +
+	"\n" +
+	"_sprint_idB\x0f\n" +
+	"\r_milestone_id\"\x8c\x01\n" +
+	"\x11ListTasksResponse\x12-\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x17.taskmanagement.v1.TaskR\x05tasks\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x122\n" +
+	"\x05stats\x18\x03 \x01(\v2\x1c.taskmanagement.v1.TaskStatsR\x05stats\" \n" +
+	"\x0eGetTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x99\x04\n" +
+	"\x11CreateTaskRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\x03R\tprojectId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x00R\vdescription\x88\x01\x01\x12$\n" +
+	"\vassigned_to\x18\x04 \x01(\x03H\x01R\n" +
+	"assignedTo\x88\x01\x01\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1a\n" +
+	"\bpriority\x18\x06 \x01(\tR\bpriority\x12\"\n" +
+	"\n" +
+	"start_date\x18\a \x01(\tH\x02R\tstartDate\x88\x01\x01\x12\x19\n" +
+	"\bdue_date\x18\b \x01(\tR\adueDate\x120\n" +
+	"\x11original_estimate\x18\t \x01(\x05H\x03R\x10originalEstimate\x88\x01\x01\x122\n" +
+	"\x12remaining_estimate\x18\n" +
+	" \x01(\x05H\x04R\x11remainingEstimate\x88\x01\x01\x12&\n" +
+	"\fstory_points\x18\v \x01(\x05H\x05R\vstoryPoints\x88\x01\x01\x12\x16\n" +
+	"\x06labels\x18\f \x03(\tR\x06labelsB\x0e\n" +
+	"\f_descriptionB\x0e\n" +
+	"\f_assigned_toB\r\n" +
+	"\v_start_dateB\x14\n" +
+	"\x12_original_estimateB\x15\n" +
+	"\x13_remaining_estimateB\x0f\n" +
+	"\r_story_points\"\xc5\x05\n" +
+	"\x11UpdateTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\"\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\x03H\x00R\tprojectId\x88\x01\x01\x12\x17\n" +
+	"\x04name\x18\x03 \x01(\tH\x01R\x04name\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x04 \x01(\tH\x02R\vdescription\x88\x01\x01\x12$\n" +
+	"\vassigned_to\x18\x05 \x01(\x03H\x03R\n" +
+	"assignedTo\x88\x01\x01\x12\x1b\n" +
+	"\x06status\x18\x06 \x01(\tH\x04R\x06status\x88\x01\x01\x12\x1f\n" +
+	"\bpriority\x18\a \x01(\tH\x05R\bpriority\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"start_date\x18\b \x01(\tH\x06R\tstartDate\x88\x01\x01\x12\x1e\n" +
+	"\bdue_date\x18\t \x01(\tH\aR\adueDate\x88\x01\x01\x120\n" +
+	"\x11original_estimate\x18\n" +
+	" \x01(\x05H\bR\x10originalEstimate\x88\x01\x01\x122\n" +
+	"\x12remaining_estimate\x18\v \x01(\x05H\tR\x11remainingEstimate\x88\x01\x01\x12&\n" +
+	"\fstory_points\x18\f \x01(\x05H\n" +
+	"R\vstoryPoints\x88\x01\x01\x121\n" +
+	"\x06labels\x18\r \x01(\v2\x19.taskmanagement.v1.LabelsR\x06labels\x12\x1d\n" +
+	"\aversion\x18\x0e \x01(\x05H\vR\aversion\x88\x01\x01B\r\n" +
+	"\v_project_idB\a\n" +
+	"\x05_nameB\x0e\n" +
+	"\f_descriptionB\x0e\n" +
+	"\f_assigned_toB\t\n" +
+	"\a_statusB\v\n" +
+	"\t_priorityB\r\n" +
+	"\v_start_dateB\v\n" +
+	"\t_due_dateB\x14\n" +
+	"\x12_original_estimateB\x15\n" +
+	"\x13_remaining_estimateB\x0f\n" +
+	"\r_story_pointsB\n" +
+	"\n" +
+	"\b_version\" \n" +
+	"\x06Labels\x12\x16\n" +
+	"\x06labels\x18\x01 \x03(\tR\x06labels\"N\n" +
+	"\x11DeleteTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\aversion\x18\x02 \x01(\x05H\x00R\aversion\x88\x01\x01B\n" +
+	"\n" +
+	"\b_version\"\x96\x01\n" +
+	"\x0fMoveTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1e\n" +
+	"\bafter_id\x18\x03 \x01(\x03H\x00R\aafterId\x88\x01\x01\x12 \n" +
+	"\tbefore_id\x18\x04 \x01(\x03H\x01R\bbeforeId\x88\x01\x01B\v\n" +
+	"\t_after_idB\f\n" +
+	"\n" +
+	"_before_id\"$\n" +
+	"\x12RestoreTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"s\n" +
+	"\x11WatchTasksRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x1f\n" +
+	"\vproject_ids\x18\x02 \x03(\x03R\n" +
+	"projectIds\x12$\n" +
+	"\x0eafter_event_id\x18\x03 \x01(\x03R\fafterEventId\"\xd0\x01\n" +
+	"\tTaskEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x17\n" +
+	"\atask_id\x18\x03 \x01(\x03R\x06taskId\x12\"\n" +
+	"\n" +
+	"project_id\x18\x04 \x01(\x03H\x00R\tprojectId\x88\x01\x01\x12\x18\n" +
+	"\apayload\x18\x05 \x01(\tR\apayload\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAtB\r\n" +
+	"\v_project_id2\xfe\x04\n" +
+	"\vTaskService\x12V\n" +
+	"\tListTasks\x12#.taskmanagement.v1.ListTasksRequest\x1a$.taskmanagement.v1.ListTasksResponse\x12E\n" +
+	"\aGetTask\x12!.taskmanagement.v1.GetTaskRequest\x1a\x17.taskmanagement.v1.Task\x12K\n" +
+	"\n" +
+	"CreateTask\x12$.taskmanagement.v1.CreateTaskRequest\x1a\x17.taskmanagement.v1.Task\x12K\n" +
+	"\n" +
+	"UpdateTask\x12$.taskmanagement.v1.UpdateTaskRequest\x1a\x17.taskmanagement.v1.Task\x12J\n" +
+	"\n" +
+	"DeleteTask\x12$.taskmanagement.v1.DeleteTaskRequest\x1a\x16.google.protobuf.Empty\x12G\n" +
+	"\bMoveTask\x12\".taskmanagement.v1.MoveTaskRequest\x1a\x17.taskmanagement.v1.Task\x12M\n" +
+	"\vRestoreTask\x12%.taskmanagement.v1.RestoreTaskRequest\x1a\x17.taskmanagement.v1.Task\x12R\n" +
+	"\n" +
+	"WatchTasks\x12$.taskmanagement.v1.WatchTasksRequest\x1a\x1c.taskmanagement.v1.TaskEvent0\x01B#Z!task-management2/internal/grpc/pbb\x06proto3"
+
+var (
+	file_taskmanagement_v1_tasks_proto_rawDescOnce sync.Once
+	file_taskmanagement_v1_tasks_proto_rawDescData []byte
+)
+
+func file_taskmanagement_v1_tasks_proto_rawDescGZIP() []byte {
+	file_taskmanagement_v1_tasks_proto_rawDescOnce.Do(func() {
+		file_taskmanagement_v1_tasks_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_taskmanagement_v1_tasks_proto_rawDesc), len(file_taskmanagement_v1_tasks_proto_rawDesc)))
+	})
+	return file_taskmanagement_v1_tasks_proto_rawDescData
+}
+
+var file_taskmanagement_v1_tasks_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_taskmanagement_v1_tasks_proto_goTypes = []any{
+	(*Task)(nil),                  // 0: taskmanagement.v1.Task
+	(*ListTasksRequest)(nil),      // 1: taskmanagement.v1.ListTasksRequest
+	(*ListTasksResponse)(nil),     // 2: taskmanagement.v1.ListTasksResponse
+	(*GetTaskRequest)(nil),        // 3: taskmanagement.v1.GetTaskRequest
+	(*CreateTaskRequest)(nil),     // 4: taskmanagement.v1.CreateTaskRequest
+	(*UpdateTaskRequest)(nil),     // 5: taskmanagement.v1.UpdateTaskRequest
+	(*Labels)(nil),                // 6: taskmanagement.v1.Labels
+	(*DeleteTaskRequest)(nil),     // 7: taskmanagement.v1.DeleteTaskRequest
+	(*MoveTaskRequest)(nil),       // 8: taskmanagement.v1.MoveTaskRequest
+	(*RestoreTaskRequest)(nil),    // 9: taskmanagement.v1.RestoreTaskRequest
+	(*WatchTasksRequest)(nil),     // 10: taskmanagement.v1.WatchTasksRequest
+	(*TaskEvent)(nil),             // 11: taskmanagement.v1.TaskEvent
+	(*timestamppb.Timestamp)(nil), // 12: google.protobuf.Timestamp
+	(*Page)(nil),                  // 13: taskmanagement.v1.Page
+	(*TaskStats)(nil),             // 14: taskmanagement.v1.TaskStats
+	(*emptypb.Empty)(nil),         // 15: google.protobuf.Empty
+}
+var file_taskmanagement_v1_tasks_proto_depIdxs = []int32{
+	12, // 0: taskmanagement.v1.Task.created_at:type_name -> google.protobuf.Timestamp
+	12, // 1: taskmanagement.v1.Task.updated_at:type_name -> google.protobuf.Timestamp
+	13, // 2: taskmanagement.v1.ListTasksRequest.page:type_name -> taskmanagement.v1.Page
+	0,  // 3: taskmanagement.v1.ListTasksResponse.tasks:type_name -> taskmanagement.v1.Task
+	14, // 4: taskmanagement.v1.ListTasksResponse.stats:type_name -> taskmanagement.v1.TaskStats
+	6,  // 5: taskmanagement.v1.UpdateTaskRequest.labels:type_name -> taskmanagement.v1.Labels
+	12, // 6: taskmanagement.v1.TaskEvent.created_at:type_name -> google.protobuf.Timestamp
+	1,  // 7: taskmanagement.v1.TaskService.ListTasks:input_type -> taskmanagement.v1.ListTasksRequest
+	3,  // 8: taskmanagement.v1.TaskService.GetTask:input_type -> taskmanagement.v1.GetTaskRequest
+	4,  // 9: taskmanagement.v1.TaskService.CreateTask:input_type -> taskmanagement.v1.CreateTaskRequest
+	5,  // 10: taskmanagement.v1.TaskService.UpdateTask:input_type -> taskmanagement.v1.UpdateTaskRequest
+	7,  // 11: taskmanagement.v1.TaskService.DeleteTask:input_type -> taskmanagement.v1.DeleteTaskRequest
+	8,  // 12: taskmanagement.v1.TaskService.MoveTask:input_type -> taskmanagement.v1.MoveTaskRequest
+	9,  // 13: taskmanagement.v1.TaskService.RestoreTask:input_type -> taskmanagement.v1.RestoreTaskRequest
+	10, // 14: taskmanagement.v1.TaskService.WatchTasks:input_type -> taskmanagement.v1.WatchTasksRequest
+	2,  // 15: taskmanagement.v1.TaskService.ListTasks:output_type -> taskmanagement.v1.ListTasksResponse
+	0,  // 16: taskmanagement.v1.TaskService.GetTask:output_type -> taskmanagement.v1.Task
+	0,  // 17: taskmanagement.v1.TaskService.CreateTask:output_type -> taskmanagement.v1.Task
+	0,  // 18: taskmanagement.v1.TaskService.UpdateTask:output_type -> taskmanagement.v1.Task
+	15, // 19: taskmanagement.v1.TaskService.DeleteTask:output_type -> google.protobuf.Empty
+	0,  // 20: taskmanagement.v1.TaskService.MoveTask:output_type -> taskmanagement.v1.Task
+	0,  // 21: taskmanagement.v1.TaskService.RestoreTask:output_type -> taskmanagement.v1.Task
+	11, // 22: taskmanagement.v1.TaskService.WatchTasks:output_type -> taskmanagement.v1.TaskEvent
+	15, // [15:23] is the sub-list for method output_type
+	7,  // [7:15] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_taskmanagement_v1_tasks_proto_init() }
+func file_taskmanagement_v1_tasks_proto_init() {
+	if File_taskmanagement_v1_tasks_proto != nil {
+		return
+	}
+	file_taskmanagement_v1_common_proto_init()
+	file_taskmanagement_v1_tasks_proto_msgTypes[0].OneofWrappers = []any{}
+	file_taskmanagement_v1_tasks_proto_msgTypes[1].OneofWrappers = []any{}
+	file_taskmanagement_v1_tasks_proto_msgTypes[4].OneofWrappers = []any{}
+	file_taskmanagement_v1_tasks_proto_msgTypes[5].OneofWrappers = []any{}
+	file_taskmanagement_v1_tasks_proto_msgTypes[7].OneofWrappers = []any{}
+	file_taskmanagement_v1_tasks_proto_msgTypes[8].OneofWrappers = []any{}
+	file_taskmanagement_v1_tasks_proto_msgTypes[11].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_taskmanagement_v1_tasks_proto_rawDesc), len(file_taskmanagement_v1_tasks_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_taskmanagement_v1_tasks_proto_goTypes,
+		DependencyIndexes: file_taskmanagement_v1_tasks_proto_depIdxs,
+		MessageInfos:      file_taskmanagement_v1_tasks_proto_msgTypes,
+	}.Build()
+	File_taskmanagement_v1_tasks_proto = out.File
+	file_taskmanagement_v1_tasks_proto_goTypes = nil
+	file_taskmanagement_v1_tasks_proto_depIdxs = nil
+}
@@ -13,6 +13,18 @@ type Conf struct {
 	DBHost     string `yaml:"db_host"`
 	DBPort     string `yaml:"db_port"`
 	Port       string `yaml:"port"`
+	GRPCPort   string `yaml:"grpc_port"` // the gRPC API, off when empty
+
+	AppURL       string `yaml:"app_url"`
+	MailDriver   string `yaml:"mail_driver"` // smtp, file or empty to only log
+	MailFrom     string `yaml:"mail_from"`
+	MailDir      string `yaml:"mail_dir"`
+	SMTPHost     string `yaml:"smtp_host"`
+	SMTPPort     string `yaml:"smtp_port"`
+	SMTPUsername string `yaml:"smtp_username"`
+	SMTPPassword string `yaml:"smtp_password"`
+
+	TrashRetentionDays int `yaml:"trash_retention_days"` // 30 when empty
 }
 
 func GetConf() *Conf {
@@ -0,0 +1,92 @@
+// Package dataloader batches the lookups of a single request, so a list of
+// n rows and their relations costs one query per relation rather than n.
+package dataloader
+
+import (
+	"context"
+	"sync"
+)
+
+// Keys is the set of keys of one kind a request has seen so far, the ids of
+// every project listed for example. Loaders fetch all of them at once.
+type Keys[K comparable] struct {
+	mu    sync.Mutex
+	seen  map[K]bool
+	order []K
+}
+
+func NewKeys[K comparable]() *Keys[K] {
+	return &Keys[K]{seen: map[K]bool{}}
+}
+
+func (k *Keys[K]) Add(keys ...K) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	for _, key := range keys {
+		if !k.seen[key] {
+			k.seen[key] = true
+			k.order = append(k.order, key)
+		}
+	}
+}
+
+func (k *Keys[K]) All() []K {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	return append([]K(nil), k.order...)
+}
+
+// Loader loads values by key and caches them for the request. Loading a key
+// that isn't cached yet fetches it together with every key of siblings that
+// hasn't been fetched, with a single call of fetch. A key fetch doesn't
+// return a value for loads as the zero value.
+type Loader[K comparable, V any] struct {
+	fetch    func(ctx context.Context, keys []K) (map[K]V, error)
+	siblings *Keys[K]
+
+	mu      sync.Mutex
+	fetched map[K]bool
+	values  map[K]V
+}
+
+func New[K comparable, V any](siblings *Keys[K], fetch func(ctx context.Context, keys []K) (map[K]V, error)) *Loader[K, V] {
+	return &Loader[K, V]{
+		fetch:    fetch,
+		siblings: siblings,
+		fetched:  map[K]bool{},
+		values:   map[K]V{},
+	}
+}
+
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.fetched[key] {
+		return l.values[key], nil
+	}
+
+	keys := []K{key}
+	for _, k := range l.siblings.All() {
+		if k != key && !l.fetched[k] {
+			keys = append(keys, k)
+		}
+	}
+
+	values, err := l.fetch(ctx, keys)
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+
+	for _, k := range keys {
+		l.fetched[k] = true
+		if v, ok := values[k]; ok {
+			l.values[k] = v
+		}
+	}
+
+	return l.values[key], nil
+}
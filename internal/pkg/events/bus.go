@@ -0,0 +1,68 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"task-management2/internal/repository/postgres/outbox"
+)
+
+// Handler reacts to a domain event. Events are delivered at least once, so
+// handlers must tolerate seeing the same event id again.
+type Handler func(ctx context.Context, event outbox.Event) error
+
+// Bus fans domain events out to in-process subscribers.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+func NewBus() *Bus {
+	return &Bus{handlers: map[string][]Handler{}}
+}
+
+// Subscribe registers handler for an event type such as "task.created",
+// for every event of an aggregate with "task.*", or for all events with "*".
+func (b *Bus) Subscribe(pattern string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.handlers[pattern] = append(b.handlers[pattern], handler)
+}
+
+// Publish runs the handlers matching event one after another. All of them
+// run even when one fails; the failures are returned together, and the
+// outbox then retries the event for every handler.
+func (b *Bus) Publish(ctx context.Context, event outbox.Event) error {
+	b.mu.RLock()
+	var handlers []Handler
+	for _, pattern := range patterns(event.Type) {
+		handlers = append(handlers, b.handlers[pattern]...)
+	}
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%s event %d: %w", event.Type, event.Id, errors.Join(errs...))
+	}
+
+	return nil
+}
+
+func patterns(eventType string) []string {
+	result := []string{eventType}
+
+	if i := strings.Index(eventType, "."); i > 0 {
+		result = append(result, eventType[:i]+".*")
+	}
+
+	return append(result, "*")
+}
@@ -0,0 +1,75 @@
+package events
+
+import (
+	"context"
+	"log"
+	"task-management2/internal/repository/postgres/outbox"
+	"time"
+)
+
+const batchSize = 50
+
+type Repository interface {
+	Process(ctx context.Context, limit int, handle func(ctx context.Context, event outbox.Event) error) (int, error)
+}
+
+// Dispatcher moves events from the outbox to the bus. It can run on every
+// instance, the outbox keeps the per-aggregate order.
+type Dispatcher struct {
+	repo     Repository
+	bus      *Bus
+	interval time.Duration
+}
+
+func NewDispatcher(repo Repository, bus *Bus, interval time.Duration) *Dispatcher {
+	return &Dispatcher{
+		repo:     repo,
+		bus:      bus,
+		interval: interval,
+	}
+}
+
+// Start runs the dispatcher in the background until ctx is cancelled.
+func (d *Dispatcher) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(d.interval)
+		defer ticker.Stop()
+
+		for {
+			if err := d.RunOnce(ctx); err != nil {
+				log.Printf("event dispatcher: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// RunOnce publishes pending events until the outbox has nothing due. Each
+// batch only holds the oldest pending event per aggregate, so a busy
+// aggregate takes several batches.
+func (d *Dispatcher) RunOnce(ctx context.Context) error {
+	for {
+		claimed, err := d.repo.Process(ctx, batchSize, d.publish)
+		if err != nil {
+			return err
+		}
+
+		if claimed == 0 || ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+func (d *Dispatcher) publish(ctx context.Context, event outbox.Event) error {
+	err := d.bus.Publish(ctx, event)
+	if err != nil {
+		log.Printf("event dispatcher: %v", err)
+	}
+
+	return err
+}
@@ -0,0 +1,43 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileSender writes messages into a maildir (new/ subdirectory) for local
+// testing; any mail client that reads maildirs can open them.
+type FileSender struct {
+	dir string
+}
+
+func NewFileSender(dir string) *FileSender {
+	return &FileSender{dir: dir}
+}
+
+func (s *FileSender) Send(ctx context.Context, from string, msg Message) error {
+	raw, err := build(from, msg)
+	if err != nil {
+		return err
+	}
+
+	for _, sub := range []string{"tmp", "new", "cur"} {
+		if err := os.MkdirAll(filepath.Join(s.dir, sub), 0o755); err != nil {
+			return err
+		}
+	}
+
+	host, _ := os.Hostname()
+	name := fmt.Sprintf("%d.%d.%s", time.Now().UnixNano(), os.Getpid(), host)
+
+	// maildir delivery: write to tmp/ and move to new/ once complete
+	tmp := filepath.Join(s.dir, "tmp", name)
+	if err := os.WriteFile(tmp, raw, 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, filepath.Join(s.dir, "new", name))
+}
@@ -0,0 +1,15 @@
+package mailer
+
+import (
+	"context"
+	"log"
+)
+
+// LogSender only logs messages. It is used when no mail driver is
+// configured.
+type LogSender struct{}
+
+func (LogSender) Send(ctx context.Context, from string, msg Message) error {
+	log.Printf("mail to %v: %s", msg.To, msg.Subject)
+	return nil
+}
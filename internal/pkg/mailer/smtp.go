@@ -0,0 +1,36 @@
+package mailer
+
+import (
+	"context"
+	"net"
+	"net/smtp"
+)
+
+type SMTPSender struct {
+	addr string
+	auth smtp.Auth
+}
+
+// NewSMTPSender sends through host:port, authenticating with PLAIN auth
+// when a username is set. net/smtp upgrades to STARTTLS when offered.
+func NewSMTPSender(host, port, username, password string) *SMTPSender {
+	s := &SMTPSender{addr: net.JoinHostPort(host, port)}
+	if username != "" {
+		s.auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return s
+}
+
+func (s *SMTPSender) Send(ctx context.Context, from string, msg Message) error {
+	raw, err := build(from, msg)
+	if err != nil {
+		return err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return smtp.SendMail(s.addr, s.auth, address(from), msg.To, raw)
+}
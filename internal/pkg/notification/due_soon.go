@@ -0,0 +1,51 @@
+package notification
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+type Repository interface {
+	CreateDueSoon(ctx context.Context, days int) (int, error)
+}
+
+// DueSoonJob periodically notifies users about open tasks that are due
+// within the next days. Notifications are deduplicated in the database, so
+// the job can run on every instance.
+type DueSoonJob struct {
+	repo     Repository
+	days     int
+	interval time.Duration
+}
+
+func NewDueSoonJob(repo Repository, days int, interval time.Duration) *DueSoonJob {
+	return &DueSoonJob{
+		repo:     repo,
+		days:     days,
+		interval: interval,
+	}
+}
+
+// Start runs the job in the background until ctx is cancelled.
+func (j *DueSoonJob) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+
+		for {
+			created, err := j.repo.CreateDueSoon(ctx, j.days)
+			if err != nil {
+				log.Printf("due soon notifications: %v", err)
+			} else if created > 0 {
+				log.Printf("due soon notifications: created %d", created)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
@@ -0,0 +1,214 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"task-management2/internal/pkg/mailer"
+	"task-management2/internal/repository/postgres/notifications"
+	"task-management2/internal/repository/postgres/users"
+	"time"
+)
+
+const (
+	dateLayout = "2006-01-02"
+	emailBatch = 50
+	// upcomingDays is how far ahead the digest lists open tasks.
+	upcomingDays = 7
+)
+
+type EmailRepository interface {
+	ClaimPendingEmails(ctx context.Context, limit int) ([]notifications.PendingEmail, error)
+	ReleaseEmail(ctx context.Context, id int) error
+	GetDigestRecipients(ctx context.Context, today string, hour int) ([]notifications.DigestRecipient, error)
+	ClaimDigest(ctx context.Context, userId int, today string) (bool, error)
+	ReleaseDigest(ctx context.Context, userId int, today string) error
+}
+
+type UserRepository interface {
+	GetUserTasks(ctx context.Context, userId int) ([]users.TaskItem, error)
+}
+
+type DigestItem struct {
+	Name     string
+	DueDate  string
+	Priority string
+}
+
+// EmailJob mails assignment notifications and the daily digest. Both are
+// claimed in the database before sending, so the job can run on every
+// instance without users getting the same email twice.
+type EmailJob struct {
+	repo     EmailRepository
+	users    UserRepository
+	sender   mailer.Sender
+	from     string
+	appURL   string
+	interval time.Duration
+}
+
+func NewEmailJob(repo EmailRepository, userRepo UserRepository, sender mailer.Sender, from, appURL string, interval time.Duration) *EmailJob {
+	return &EmailJob{
+		repo:     repo,
+		users:    userRepo,
+		sender:   sender,
+		from:     from,
+		appURL:   strings.TrimRight(appURL, "/"),
+		interval: interval,
+	}
+}
+
+// Start runs the job in the background until ctx is cancelled.
+func (j *EmailJob) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+
+		for {
+			if err := j.SendAssignments(ctx); err != nil {
+				log.Printf("assignment emails: %v", err)
+			}
+			if err := j.SendDigests(ctx, time.Now().UTC()); err != nil {
+				log.Printf("digest emails: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (j *EmailJob) SendAssignments(ctx context.Context) error {
+	pending, err := j.repo.ClaimPendingEmails(ctx, emailBatch)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range pending {
+		// claimed anyway so the notification isn't picked up again
+		if !item.EmailAssignments || item.Email == "" {
+			continue
+		}
+
+		link := ""
+		if j.appURL != "" {
+			link = fmt.Sprintf("%s/task/%d", j.appURL, item.TaskId)
+		}
+
+		msg, err := mailer.Render("assignment", "You were assigned: "+item.TaskName, []string{item.Email}, map[string]interface{}{
+			"UserName":    item.UserName,
+			"TaskName":    item.TaskName,
+			"ProjectName": item.ProjectName,
+			"Status":      item.Status,
+			"Priority":    item.Priority,
+			"DueDate":     item.DueDate,
+			"Link":        link,
+		})
+		if err != nil {
+			log.Printf("assignment emails: notification %d: %v", item.Id, err)
+			j.releaseEmail(ctx, item.Id)
+			continue
+		}
+
+		if err := j.sender.Send(ctx, j.from, msg); err != nil {
+			log.Printf("assignment emails: notification %d: %v", item.Id, err)
+			j.releaseEmail(ctx, item.Id)
+		}
+	}
+
+	return nil
+}
+
+// releaseEmail gives back the claim of a notification that wasn't mailed,
+// so a later run tries again.
+func (j *EmailJob) releaseEmail(ctx context.Context, id int) {
+	if err := j.repo.ReleaseEmail(ctx, id); err != nil {
+		log.Printf("assignment emails: release notification %d: %v", id, err)
+	}
+}
+
+// SendDigests mails the daily digest to users whose digest hour has passed.
+// Users without overdue, due or upcoming tasks don't get an email.
+func (j *EmailJob) SendDigests(ctx context.Context, now time.Time) error {
+	today := now.Format(dateLayout)
+
+	recipients, err := j.repo.GetDigestRecipients(ctx, today, now.Hour())
+	if err != nil {
+		return err
+	}
+
+	for _, recipient := range recipients {
+		claimed, err := j.repo.ClaimDigest(ctx, recipient.UserId, today)
+		if err != nil {
+			log.Printf("digest emails: user %d: %v", recipient.UserId, err)
+			continue
+		}
+		if !claimed || recipient.Email == "" {
+			continue
+		}
+
+		if err := j.sendDigest(ctx, recipient, now); err != nil {
+			log.Printf("digest emails: user %d: %v", recipient.UserId, err)
+			if err := j.repo.ReleaseDigest(ctx, recipient.UserId, today); err != nil {
+				log.Printf("digest emails: release user %d: %v", recipient.UserId, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (j *EmailJob) sendDigest(ctx context.Context, recipient notifications.DigestRecipient, now time.Time) error {
+	tasks, err := j.users.GetUserTasks(ctx, recipient.UserId)
+	if err != nil {
+		return err
+	}
+
+	today := now.Format(dateLayout)
+	until := now.AddDate(0, 0, upcomingDays).Format(dateLayout)
+
+	var overdue, dueToday, upcoming []DigestItem
+	for _, task := range tasks {
+		if task.DueDate == nil || (task.Status != nil && *task.Status == "completed") {
+			continue
+		}
+
+		item := DigestItem{DueDate: task.DueDate.Format(dateLayout)}
+		if task.Name != nil {
+			item.Name = *task.Name
+		}
+		if task.Priority != nil {
+			item.Priority = *task.Priority
+		}
+
+		switch {
+		case item.DueDate < today:
+			overdue = append(overdue, item)
+		case item.DueDate == today:
+			dueToday = append(dueToday, item)
+		case item.DueDate <= until:
+			upcoming = append(upcoming, item)
+		}
+	}
+
+	if len(overdue) == 0 && len(dueToday) == 0 && len(upcoming) == 0 {
+		return nil
+	}
+
+	msg, err := mailer.Render("digest", "Your tasks for "+today, []string{recipient.Email}, map[string]interface{}{
+		"UserName": recipient.UserName,
+		"Date":     today,
+		"Overdue":  overdue,
+		"DueToday": dueToday,
+		"Upcoming": upcoming,
+	})
+	if err != nil {
+		return err
+	}
+
+	return j.sender.Send(ctx, j.from, msg)
+}
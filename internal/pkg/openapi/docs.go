@@ -0,0 +1,29 @@
+package openapi
+
+import (
+	"bytes"
+	_ "embed"
+	"html/template"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+//go:embed docs.html
+var docsPage string
+
+var docsTemplate = template.Must(template.New("docs").Parse(docsPage))
+
+// Docs serves a page listing the operations of the document at specURL,
+// with a form to send requests. It has no dependencies to load, so it works
+// offline.
+func Docs(specURL string) gin.HandlerFunc {
+	var page bytes.Buffer
+	if err := docsTemplate.Execute(&page, specURL); err != nil {
+		panic(err)
+	}
+
+	return func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", page.Bytes())
+	}
+}
@@ -0,0 +1,586 @@
+// Package openapi builds the OpenAPI 3.1 document of the API from the
+// routes and the DTOs they bind and return. Schemas are derived from the Go
+// types by reflection, following encoding/json, and the binding and
+// validate tags add required members, enums and bounds.
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Version is the OpenAPI version of the generated document.
+const Version = "3.1.0"
+
+// Operation describes one route. Body and Response are zero values of the
+// types the handler binds and writes, an Object for ad hoc envelopes.
+type Operation struct {
+	Method  string
+	Path    string // in gin notation, /task/:id
+	Tag     string
+	Summary string
+
+	Query    []Param
+	Headers  []Param
+	Body     interface{}
+	BodyType string // defaults to application/json
+
+	Status       int // defaults to 200
+	Response     interface{}
+	ResponseType string // defaults to application/json
+}
+
+type Param struct {
+	Name        string
+	Type        string // integer, string or boolean
+	Format      string
+	Enum        []string
+	Required    bool
+	Description string
+}
+
+// Object is a JSON object with the given members, values are zero values of
+// the member types or nested Objects.
+type Object map[string]interface{}
+
+// Data is the {"data": ...} envelope most handlers answer with.
+func Data(v interface{}) Object {
+	return Object{"data": v}
+}
+
+// List is the envelope of paginated lists.
+func List(v interface{}) Object {
+	return Object{"data": v, "count": 0}
+}
+
+// Message is the {"message": ..., "status": true} envelope, data is left out
+// when v is nil.
+func Message(v interface{}) Object {
+	o := Object{"message": "", "status": true}
+	if v != nil {
+		o["data"] = v
+	}
+
+	return o
+}
+
+// Binary marks a response that isn't JSON.
+type Binary struct{}
+
+type Spec struct {
+	title   string
+	version string
+
+	operations []Operation
+	errorBody  interface{}
+	schemas    map[string]interface{}
+	doc        []byte
+}
+
+func New(title, version string) *Spec {
+	return &Spec{title: title, version: version}
+}
+
+// Errors sets the body of error responses, added to every operation as the
+// default response.
+func (s *Spec) Errors(body interface{}) *Spec {
+	s.errorBody = body
+	s.doc = nil
+
+	return s
+}
+
+func (s *Spec) Add(ops ...Operation) *Spec {
+	s.operations = append(s.operations, ops...)
+	s.doc = nil
+
+	return s
+}
+
+// Check compares the spec with the registered routes. Routes without an
+// operation and operations without a route are both errors.
+func (s *Spec) Check(routes gin.RoutesInfo) error {
+	documented := map[string]bool{}
+	for _, op := range s.operations {
+		documented[op.Method+" "+op.Path] = true
+	}
+
+	var missing, stale []string
+	registered := map[string]bool{}
+	for _, route := range routes {
+		key := route.Method + " " + route.Path
+		registered[key] = true
+		if !documented[key] {
+			missing = append(missing, key)
+		}
+	}
+	for key := range documented {
+		if !registered[key] {
+			stale = append(stale, key)
+		}
+	}
+
+	if len(missing) == 0 && len(stale) == 0 {
+		return nil
+	}
+
+	sort.Strings(missing)
+	sort.Strings(stale)
+
+	var parts []string
+	if len(missing) > 0 {
+		parts = append(parts, "routes without an OpenAPI operation: "+strings.Join(missing, ", "))
+	}
+	if len(stale) > 0 {
+		parts = append(parts, "OpenAPI operations without a route: "+strings.Join(stale, ", "))
+	}
+
+	return fmt.Errorf("openapi: %s", strings.Join(parts, "; "))
+}
+
+// JSON returns the encoded document.
+func (s *Spec) JSON() ([]byte, error) {
+	if s.doc != nil {
+		return s.doc, nil
+	}
+
+	doc, err := json.Marshal(s.build())
+	if err != nil {
+		return nil, err
+	}
+	s.doc = doc
+
+	return doc, nil
+}
+
+// Handler serves the document.
+func (s *Spec) Handler(c *gin.Context) {
+	doc, err := s.JSON()
+	if err != nil {
+		c.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json; charset=utf-8", doc)
+}
+
+func (s *Spec) build() map[string]interface{} {
+	s.schemas = map[string]interface{}{}
+
+	paths := map[string]map[string]interface{}{}
+	tags := map[string]bool{}
+
+	var errorSchema interface{}
+	if s.errorBody != nil {
+		errorSchema = s.schemaOf(s.errorBody)
+	}
+
+	for _, op := range s.operations {
+		path, pathParams := convertPath(op.Path)
+		if paths[path] == nil {
+			paths[path] = map[string]interface{}{}
+		}
+		tags[op.Tag] = true
+
+		operation := map[string]interface{}{
+			"operationId": operationId(op),
+			"summary":     op.Summary,
+		}
+		if op.Tag != "" {
+			operation["tags"] = []string{op.Tag}
+		}
+
+		var params []interface{}
+		for _, name := range pathParams {
+			params = append(params, parameter("path", Param{Name: name, Type: pathParamType(name), Required: true}))
+		}
+		for _, p := range op.Query {
+			params = append(params, parameter("query", p))
+		}
+		for _, p := range op.Headers {
+			params = append(params, parameter("header", p))
+		}
+		if len(params) > 0 {
+			operation["parameters"] = params
+		}
+
+		if op.Body != nil {
+			bodyType := op.BodyType
+			if bodyType == "" {
+				bodyType = "application/json"
+			}
+			operation["requestBody"] = map[string]interface{}{
+				"required": true,
+				"content": map[string]interface{}{
+					bodyType: map[string]interface{}{"schema": s.schemaOf(op.Body)},
+				},
+			}
+		}
+
+		status := op.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		responses := map[string]interface{}{
+			strconv.Itoa(status): s.response(op, status),
+		}
+		if errorSchema != nil {
+			responses["default"] = map[string]interface{}{
+				"description": "Error",
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{"schema": errorSchema},
+				},
+			}
+		}
+		operation["responses"] = responses
+
+		paths[path][strings.ToLower(op.Method)] = operation
+	}
+
+	var tagList []interface{}
+	for _, name := range sortedKeys(tags) {
+		if name != "" {
+			tagList = append(tagList, map[string]interface{}{"name": name})
+		}
+	}
+
+	return map[string]interface{}{
+		"openapi": Version,
+		"info": map[string]interface{}{
+			"title":   s.title,
+			"version": s.version,
+		},
+		"tags":  tagList,
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": s.schemas,
+		},
+	}
+}
+
+func (s *Spec) response(op Operation, status int) map[string]interface{} {
+	response := map[string]interface{}{"description": http.StatusText(status)}
+	if op.Response == nil {
+		return response
+	}
+
+	responseType := op.ResponseType
+	if responseType == "" {
+		responseType = "application/json"
+	}
+
+	schema := map[string]interface{}{"type": "string"}
+	if _, ok := op.Response.(Binary); !ok {
+		schema = s.schemaOf(op.Response)
+	}
+
+	response["content"] = map[string]interface{}{
+		responseType: map[string]interface{}{"schema": schema},
+	}
+
+	return response
+}
+
+func (s *Spec) schemaOf(v interface{}) map[string]interface{} {
+	if o, ok := v.(Object); ok {
+		properties := map[string]interface{}{}
+		for name, member := range o {
+			properties[name] = s.schemaOf(member)
+		}
+		return map[string]interface{}{"type": "object", "properties": properties}
+	}
+
+	return s.schemaOfType(reflect.TypeOf(v))
+}
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	rawType  = reflect.TypeOf(json.RawMessage{})
+)
+
+func (s *Spec) schemaOfType(t reflect.Type) map[string]interface{} {
+	if t == nil {
+		return map[string]interface{}{}
+	}
+
+	switch {
+	case t == timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case t == rawType:
+		return map[string]interface{}{}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return nullable(s.schemaOfType(t.Elem()))
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "contentEncoding": "base64"}
+		}
+		return map[string]interface{}{"type": "array", "items": s.schemaOfType(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": s.schemaOfType(t.Elem())}
+	case reflect.Struct:
+		return s.component(t)
+	}
+
+	return map[string]interface{}{}
+}
+
+// component registers the schema of a named struct once and refers to it.
+func (s *Spec) component(t reflect.Type) map[string]interface{} {
+	if t.Name() == "" {
+		return s.structSchema(t)
+	}
+
+	name := t.String()
+	ref := map[string]interface{}{"$ref": "#/components/schemas/" + name}
+	if _, ok := s.schemas[name]; ok {
+		return ref
+	}
+
+	// placeholder for recursive types
+	s.schemas[name] = map[string]interface{}{}
+	s.schemas[name] = s.structSchema(t)
+
+	return ref
+}
+
+func (s *Spec) structSchema(t reflect.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+	var required []string
+
+	s.addFields(t, properties, &required)
+
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		sort.Strings(required)
+		schema["required"] = required
+	}
+
+	return schema
+}
+
+func (s *Spec) addFields(t reflect.Type, properties map[string]interface{}, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		name, omit := jsonName(f)
+		if omit {
+			continue
+		}
+
+		// embedded structs without a name are inlined, like encoding/json
+		if f.Anonymous && f.Tag.Get("json") == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				s.addFields(ft, properties, required)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+
+		schema := s.schemaOfType(f.Type)
+		if applyRules(schema, f) {
+			*required = append(*required, name)
+		}
+		properties[name] = schema
+	}
+}
+
+func jsonName(f reflect.StructField) (string, bool) {
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+
+	name := strings.SplitN(tag, ",", 2)[0]
+	if name == "" {
+		name = f.Name
+	}
+
+	return name, false
+}
+
+// applyRules adds the binding and validate rules of f to its schema and
+// reports whether the member is required.
+func applyRules(schema map[string]interface{}, f reflect.StructField) bool {
+	required := false
+
+	target := schema
+	if variants, ok := schema["anyOf"].([]interface{}); ok {
+		target = variants[0].(map[string]interface{})
+	}
+
+	for _, tag := range []string{f.Tag.Get("binding"), f.Tag.Get("validate")} {
+		for _, rule := range strings.Split(tag, ",") {
+			name, param, _ := strings.Cut(rule, "=")
+			switch name {
+			case "dive":
+				// the rules after dive are about the items
+				goto next
+			case "required":
+				required = true
+			case "oneof":
+				target["enum"] = strings.Fields(param)
+			case "email":
+				target["format"] = "email"
+			case "date":
+				target["format"] = "date"
+			case "url", "http_url":
+				target["format"] = "uri"
+			case "min", "max":
+				if n, err := strconv.Atoi(param); err == nil {
+					target[bound(target, name)] = n
+				}
+			}
+		}
+	next:
+	}
+
+	return required
+}
+
+func bound(schema map[string]interface{}, rule string) string {
+	kind := "imum"
+	switch typeOf(schema) {
+	case "string":
+		kind = "Length"
+	case "array":
+		kind = "Items"
+	case "object":
+		kind = "Properties"
+	}
+
+	if rule == "min" {
+		if kind == "imum" {
+			return "minimum"
+		}
+		return "min" + kind
+	}
+	if kind == "imum" {
+		return "maximum"
+	}
+	return "max" + kind
+}
+
+func typeOf(schema map[string]interface{}) string {
+	switch t := schema["type"].(type) {
+	case string:
+		return t
+	case []string:
+		return t[0]
+	}
+
+	return ""
+}
+
+func nullable(schema map[string]interface{}) map[string]interface{} {
+	if t, ok := schema["type"].(string); ok {
+		schema["type"] = []string{t, "null"}
+		return schema
+	}
+	if _, ok := schema["type"].([]string); ok {
+		return schema
+	}
+
+	return map[string]interface{}{
+		"anyOf": []interface{}{schema, map[string]interface{}{"type": "null"}},
+	}
+}
+
+func parameter(in string, p Param) map[string]interface{} {
+	schema := map[string]interface{}{"type": p.Type}
+	if p.Type == "" {
+		schema["type"] = "string"
+	}
+	if p.Format != "" {
+		schema["format"] = p.Format
+	}
+	if len(p.Enum) > 0 {
+		schema["enum"] = p.Enum
+	}
+
+	param := map[string]interface{}{
+		"name":     p.Name,
+		"in":       in,
+		"required": p.Required,
+		"schema":   schema,
+	}
+	if p.Description != "" {
+		param["description"] = p.Description
+	}
+
+	return param
+}
+
+// convertPath turns /task/:id into /task/{id} and returns the parameters.
+func convertPath(path string) (string, []string) {
+	var params []string
+
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			name := segment[1:]
+			params = append(params, name)
+			segments[i] = "{" + name + "}"
+		}
+	}
+
+	return strings.Join(segments, "/"), params
+}
+
+func pathParamType(name string) string {
+	if name == "id" || strings.HasSuffix(name, "_id") {
+		return "integer"
+	}
+
+	return "string"
+}
+
+func operationId(op Operation) string {
+	path, _ := convertPath(op.Path)
+	path = strings.NewReplacer("{", "", "}", "", "/", " ", "-", " ", "_", " ", ".", " ").Replace(path)
+
+	id := strings.ToLower(op.Method)
+	for _, word := range strings.Fields(path) {
+		if word == "api" || word == "v1" {
+			continue
+		}
+		id += strings.ToUpper(word[:1]) + word[1:]
+	}
+
+	return id
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
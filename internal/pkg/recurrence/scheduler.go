@@ -0,0 +1,188 @@
+package recurrence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"task-management2/internal/entity"
+	"task-management2/internal/pkg/validation"
+	"task-management2/internal/repository/postgres/recurrences"
+	"task-management2/internal/repository/postgres/tasks"
+	"task-management2/internal/util/rrule"
+	"time"
+
+	"github.com/uptrace/bun/driver/pgdriver"
+)
+
+const dateLayout = "2006-01-02"
+
+type RecurrenceRepository interface {
+	GetDue(ctx context.Context, today string) ([]recurrences.Due, error)
+	Advance(ctx context.Context, id int, expectedNext string, next *string) (bool, error)
+}
+
+type TaskRepository interface {
+	GetById(ctx context.Context, id int) (entity.Tasks, error)
+	Create(ctx context.Context, data tasks.Create) (entity.Tasks, error)
+}
+
+// Scheduler materialises the next occurrence of recurring tasks. Several
+// server instances may run it at once: the unique index on
+// (recurrence_id, occurrence_date) keeps an occurrence from being created
+// twice and Advance only lets one instance move a recurrence forward, so a
+// crash between the two steps is repaired on the next run.
+type Scheduler struct {
+	recurrences RecurrenceRepository
+	tasks       TaskRepository
+	interval    time.Duration
+}
+
+func NewScheduler(recurrenceRepo RecurrenceRepository, taskRepo TaskRepository, interval time.Duration) *Scheduler {
+	return &Scheduler{
+		recurrences: recurrenceRepo,
+		tasks:       taskRepo,
+		interval:    interval,
+	}
+}
+
+// Start runs the scheduler in the background until ctx is cancelled.
+func (s *Scheduler) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			if err := s.RunOnce(ctx); err != nil {
+				log.Printf("recurrence scheduler: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (s *Scheduler) RunOnce(ctx context.Context) error {
+	today := time.Now().UTC()
+
+	due, err := s.recurrences.GetDue(ctx, today.Format(dateLayout))
+	if err != nil {
+		return err
+	}
+
+	for _, item := range due {
+		if err := s.materialise(ctx, item, today); err != nil {
+			log.Printf("recurrence scheduler: recurrence %d: %v", item.Id, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Scheduler) materialise(ctx context.Context, item recurrences.Due, today time.Time) error {
+	rule, err := rrule.Parse(item.Rule)
+	if err != nil {
+		return fmt.Errorf("invalid rule: %v", err)
+	}
+
+	dtstart, err := time.Parse(dateLayout, item.Dtstart)
+	if err != nil {
+		return err
+	}
+	occurrence, err := time.Parse(dateLayout, item.NextDate)
+	if err != nil {
+		return err
+	}
+
+	if rule.Count > 0 && item.Occurrences >= rule.Count {
+		_, err = s.recurrences.Advance(ctx, item.Id, item.NextDate, nil)
+		return err
+	}
+
+	switch item.Mode {
+	case recurrences.ModeOnComplete:
+		// the previous occurrence was completed late, continue from today
+		// instead of creating tasks that are overdue already
+		if occurrence.Before(today.Truncate(24 * time.Hour)) {
+			next, ok := rule.Next(dtstart, today.AddDate(0, 0, -1))
+			if !ok {
+				_, err = s.recurrences.Advance(ctx, item.Id, item.NextDate, nil)
+				return err
+			}
+			occurrence = next
+		}
+	default:
+		// after downtime only the latest missed occurrence is created
+		for {
+			next, ok := rule.Next(dtstart, occurrence)
+			if !ok || next.After(today) {
+				break
+			}
+			occurrence = next
+		}
+	}
+
+	template, err := s.tasks.GetById(ctx, item.TemplateTaskId)
+	if err != nil {
+		return err
+	}
+
+	err = s.createOccurrence(ctx, item.Id, template, occurrence)
+	if err != nil {
+		return err
+	}
+
+	var next *string
+	if rule.Count == 0 || item.Occurrences+1 < rule.Count {
+		if n, ok := rule.Next(dtstart, occurrence); ok {
+			date := n.Format(dateLayout)
+			next = &date
+		}
+	}
+
+	_, err = s.recurrences.Advance(ctx, item.Id, item.NextDate, next)
+	return err
+}
+
+func (s *Scheduler) createOccurrence(ctx context.Context, recurrenceId int, template entity.Tasks, occurrence time.Time) error {
+	status := "pending"
+	dueDate := occurrence.Format(dateLayout)
+
+	data := tasks.Create{
+		ProjectId:        template.ProjectId,
+		Name:             template.Name,
+		Description:      template.Description,
+		AssignedTo:       template.AssignedTo,
+		Status:           &status,
+		Priority:         template.Priority,
+		DueDate:          &dueDate,
+		OriginalEstimate: template.OriginalEstimate,
+		StoryPoints:      template.StoryPoints,
+		RecurrenceId:     &recurrenceId,
+		OccurrenceDate:   &dueDate,
+	}
+
+	// keep the template's lead time between start and due date
+	if template.StartDate != nil && template.DueDate != nil {
+		start, errStart := time.Parse(dateLayout, *validation.Day(template.StartDate))
+		due, errDue := time.Parse(dateLayout, *validation.Day(template.DueDate))
+		if errStart == nil && errDue == nil && !start.After(due) {
+			startDate := occurrence.Add(start.Sub(due)).Format(dateLayout)
+			data.StartDate = &startDate
+		}
+	}
+
+	_, err := s.tasks.Create(ctx, data)
+
+	var pgErr pgdriver.Error
+	if errors.As(err, &pgErr) && pgErr.Field('C') == "23505" {
+		// another instance, or a run interrupted before Advance, created it
+		return nil
+	}
+
+	return err
+}
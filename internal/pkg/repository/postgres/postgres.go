@@ -37,6 +37,18 @@ func runMigrations(db *bun.DB) error {
 		"internal/pkg/script/migrations/users.sql",
 		"internal/pkg/script/migrations/projects.sql",
 		"internal/pkg/script/migrations/tasks.sql",
+		"internal/pkg/script/migrations/calendar_tokens.sql",
+		"internal/pkg/script/migrations/worklogs.sql",
+		"internal/pkg/script/migrations/task_recurrences.sql",
+		"internal/pkg/script/migrations/notifications.sql",
+		"internal/pkg/script/migrations/webhooks.sql",
+		"internal/pkg/script/migrations/outbox.sql",
+		"internal/pkg/script/migrations/board.sql",
+		"internal/pkg/script/migrations/sprints.sql",
+		"internal/pkg/script/migrations/milestones.sql",
+		"internal/pkg/script/migrations/trash.sql",
+		"internal/pkg/script/migrations/task_labels.sql",
+		"internal/pkg/script/migrations/versions.sql",
 	}
 
 	for _, file := range migrationFiles {
@@ -30,8 +30,217 @@ CREATE TABLE IF NOT EXISTS tasks (
     status VARCHAR(50) DEFAULT 'pending', -- 'pending', 'in_progress', 'completed'
     priority VARCHAR(50) DEFAULT 'medium', -- 'low', 'medium', 'high'
     due_date VARCHAR(50),
+    start_date DATE,
+    original_estimate INT, -- seconds
+    remaining_estimate INT, -- seconds
+    story_points INT,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     deleted_at TIMESTAMP DEFAULT NULL
     );
 ALTER TABLE tasks OWNER TO postgres;
+
+CREATE TABLE IF NOT EXISTS calendar_tokens (
+                                     id SERIAL PRIMARY KEY,
+                                     user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP DEFAULT NULL
+    );
+ALTER TABLE calendar_tokens OWNER TO postgres;
+
+CREATE TABLE IF NOT EXISTS worklogs (
+                                     id SERIAL PRIMARY KEY,
+                                     task_id INT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    started_at TIMESTAMP NOT NULL,
+    duration INT, -- seconds, NULL while the timer is running
+    note TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP DEFAULT NULL
+    );
+ALTER TABLE worklogs OWNER TO postgres;
+CREATE UNIQUE INDEX IF NOT EXISTS worklogs_running_timer_idx ON worklogs (user_id) WHERE duration IS NULL AND deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS worklogs_task_id_idx ON worklogs (task_id);
+
+CREATE TABLE IF NOT EXISTS task_recurrences (
+                                     id SERIAL PRIMARY KEY,
+                                     template_task_id INT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    rule VARCHAR(255) NOT NULL, -- RRULE subset, see internal/util/rrule
+    mode VARCHAR(50) DEFAULT 'schedule', -- 'schedule', 'on_complete'
+    lead_days INT DEFAULT 0,
+    dtstart DATE NOT NULL,
+    next_date DATE, -- NULL once the rule is exhausted
+    occurrences INT DEFAULT 1,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP DEFAULT NULL
+    );
+ALTER TABLE task_recurrences OWNER TO postgres;
+CREATE UNIQUE INDEX IF NOT EXISTS task_recurrences_template_idx ON task_recurrences (template_task_id) WHERE deleted_at IS NULL;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS recurrence_id INT REFERENCES task_recurrences(id) ON DELETE SET NULL;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS occurrence_date DATE;
+CREATE UNIQUE INDEX IF NOT EXISTS tasks_recurrence_occurrence_idx ON tasks (recurrence_id, occurrence_date) WHERE recurrence_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS notifications (
+                       id SERIAL PRIMARY KEY,
+                       user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                       type VARCHAR(50) NOT NULL, -- 'task_assigned', 'comment_mention', 'task_due_soon', 'task_status_changed'
+                       title VARCHAR(255) NOT NULL,
+                       body TEXT,
+                       task_id INT REFERENCES tasks(id) ON DELETE CASCADE,
+                       project_id INT REFERENCES projects(id) ON DELETE CASCADE,
+                       dedupe_key VARCHAR(255),
+                       read_at TIMESTAMP DEFAULT NULL,
+                       created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                       updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                       deleted_at TIMESTAMP DEFAULT NULL
+);
+ALTER TABLE notifications OWNER TO postgres;
+CREATE INDEX IF NOT EXISTS notifications_user_unread_idx ON notifications (user_id, read_at) WHERE deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS notifications_dedupe_idx ON notifications (user_id, dedupe_key) WHERE dedupe_key IS NOT NULL;
+CREATE TABLE IF NOT EXISTS task_watchers (
+                       task_id INT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+                       user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                       created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                       PRIMARY KEY (task_id, user_id)
+);
+ALTER TABLE task_watchers OWNER TO postgres;
+CREATE TABLE IF NOT EXISTS task_comments (
+                       id SERIAL PRIMARY KEY,
+                       task_id INT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+                       user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                       body TEXT NOT NULL,
+                       created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                       updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                       deleted_at TIMESTAMP DEFAULT NULL
+);
+ALTER TABLE task_comments OWNER TO postgres;
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS emailed_at TIMESTAMP DEFAULT NULL;
+CREATE TABLE IF NOT EXISTS notification_preferences (
+                       user_id INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+                       email_assignments BOOLEAN NOT NULL DEFAULT TRUE,
+                       email_digest BOOLEAN NOT NULL DEFAULT TRUE,
+                       digest_hour INT NOT NULL DEFAULT 8, -- UTC hour the digest is sent after
+                       last_digest_on DATE DEFAULT NULL,
+                       created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                       updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+ALTER TABLE notification_preferences OWNER TO postgres;
+
+CREATE TABLE IF NOT EXISTS webhooks (
+                                     id SERIAL PRIMARY KEY,
+                                     project_id INT REFERENCES projects(id) ON DELETE CASCADE, -- NULL subscribes to every project
+    url TEXT NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    events TEXT[] NOT NULL, -- 'task.created', 'task.updated', 'task.deleted', 'project.updated'
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP DEFAULT NULL
+    );
+ALTER TABLE webhooks OWNER TO postgres;
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+                                     id SERIAL PRIMARY KEY,
+                                     webhook_id INT NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending', -- 'pending', 'succeeded', 'failed'
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    response_code INT,
+    response_body TEXT,
+    error TEXT,
+    delivered_at TIMESTAMP DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP DEFAULT NULL
+    );
+ALTER TABLE webhook_deliveries OWNER TO postgres;
+CREATE INDEX IF NOT EXISTS webhook_deliveries_due_idx ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS webhook_deliveries_webhook_id_idx ON webhook_deliveries (webhook_id, id);
+
+CREATE TABLE IF NOT EXISTS outbox_events (
+                                     id BIGSERIAL PRIMARY KEY,
+                                     aggregate_type VARCHAR(50) NOT NULL, -- 'task', 'project', 'user'
+    aggregate_id INT NOT NULL,
+    type VARCHAR(100) NOT NULL, -- e.g. 'task.created'
+    payload JSONB NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    next_attempt_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    processed_at TIMESTAMP DEFAULT NULL,
+    dead_at TIMESTAMP DEFAULT NULL, -- gave up after too many failed attempts
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    );
+ALTER TABLE outbox_events OWNER TO postgres;
+CREATE INDEX IF NOT EXISTS outbox_events_pending_idx ON outbox_events (aggregate_type, aggregate_id, id) WHERE processed_at IS NULL AND dead_at IS NULL;
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS outbox_event_id BIGINT;
+CREATE UNIQUE INDEX IF NOT EXISTS webhook_deliveries_outbox_event_idx ON webhook_deliveries (webhook_id, outbox_event_id) WHERE outbox_event_id IS NOT NULL;
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS rank TEXT COLLATE "C"; -- fractional rank within the status column, see internal/util/rank
+UPDATE tasks SET rank = lpad(to_hex(id), 8, '0') || 'V' WHERE rank IS NULL;
+CREATE INDEX IF NOT EXISTS tasks_board_idx ON tasks (project_id, status, rank) WHERE deleted_at IS NULL;
+CREATE TABLE IF NOT EXISTS board_wip_limits (
+                                     project_id INT NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+                                     status VARCHAR(50) NOT NULL,
+    wip_limit INT NOT NULL CHECK (wip_limit > 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (project_id, status)
+    );
+ALTER TABLE board_wip_limits OWNER TO postgres;
+
+CREATE TABLE IF NOT EXISTS sprints (
+                                     id SERIAL PRIMARY KEY,
+                                     project_id INT NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    goal TEXT,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    state VARCHAR(20) NOT NULL DEFAULT 'planned', -- 'planned', 'active', 'closed'
+    committed_tasks INT, -- snapshot taken when the sprint starts
+    committed_points INT,
+    completed_tasks INT, -- snapshot taken when the sprint closes
+    completed_points INT,
+    carried_over_tasks INT,
+    carried_over_points INT,
+    started_at TIMESTAMP DEFAULT NULL,
+    closed_at TIMESTAMP DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP DEFAULT NULL
+    );
+ALTER TABLE sprints OWNER TO postgres;
+CREATE UNIQUE INDEX IF NOT EXISTS sprints_active_idx ON sprints (project_id) WHERE state = 'active' AND deleted_at IS NULL;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS sprint_id INT REFERENCES sprints(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS tasks_sprint_id_idx ON tasks (sprint_id) WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS milestones (
+                                     id SERIAL PRIMARY KEY,
+                                     project_id INT NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    target_date DATE NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP DEFAULT NULL
+    );
+ALTER TABLE milestones OWNER TO postgres;
+CREATE INDEX IF NOT EXISTS milestones_project_id_idx ON milestones (project_id, target_date) WHERE deleted_at IS NULL;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS milestone_id INT REFERENCES milestones(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS tasks_milestone_id_idx ON tasks (milestone_id) WHERE deleted_at IS NULL;
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS cascade_deleted BOOLEAN NOT NULL DEFAULT false; -- deleted along with its project
+CREATE INDEX IF NOT EXISTS users_deleted_at_idx ON users (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS projects_deleted_at_idx ON projects (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS tasks_deleted_at_idx ON tasks (deleted_at) WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS labels TEXT[] DEFAULT '{}';
+CREATE INDEX IF NOT EXISTS tasks_labels_idx ON tasks USING GIN (labels);
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1; -- bumped on every write, checked against If-Match
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
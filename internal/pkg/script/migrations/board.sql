@@ -0,0 +1,16 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS rank TEXT COLLATE "C"; -- fractional rank within the status column, see internal/util/rank
+
+UPDATE tasks SET rank = lpad(to_hex(id), 8, '0') || 'V' WHERE rank IS NULL;
+
+CREATE INDEX IF NOT EXISTS tasks_board_idx ON tasks (project_id, status, rank) WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS board_wip_limits (
+                       project_id INT NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+                       status VARCHAR(50) NOT NULL,
+                       wip_limit INT NOT NULL CHECK (wip_limit > 0),
+                       created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                       updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                       PRIMARY KEY (project_id, status)
+);
+
+ALTER TABLE board_wip_limits OWNER TO postgres;
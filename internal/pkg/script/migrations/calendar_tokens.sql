@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS calendar_tokens (
+                       id SERIAL PRIMARY KEY,
+                       user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                       token_hash VARCHAR(64) UNIQUE NOT NULL,
+                       created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                       updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                       deleted_at TIMESTAMP DEFAULT NULL
+);
+
+ALTER TABLE calendar_tokens OWNER TO postgres;
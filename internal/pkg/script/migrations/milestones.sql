@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS milestones (
+                       id SERIAL PRIMARY KEY,
+                       project_id INT NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+                       name VARCHAR(255) NOT NULL,
+                       description TEXT,
+                       target_date DATE NOT NULL,
+                       created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                       updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                       deleted_at TIMESTAMP DEFAULT NULL
+);
+
+ALTER TABLE milestones OWNER TO postgres;
+
+CREATE INDEX IF NOT EXISTS milestones_project_id_idx ON milestones (project_id, target_date) WHERE deleted_at IS NULL;
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS milestone_id INT REFERENCES milestones(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS tasks_milestone_id_idx ON tasks (milestone_id) WHERE deleted_at IS NULL;
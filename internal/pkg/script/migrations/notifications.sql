@@ -0,0 +1,55 @@
+CREATE TABLE IF NOT EXISTS notifications (
+                       id SERIAL PRIMARY KEY,
+                       user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                       type VARCHAR(50) NOT NULL, -- 'task_assigned', 'comment_mention', 'task_due_soon', 'task_status_changed'
+                       title VARCHAR(255) NOT NULL,
+                       body TEXT,
+                       task_id INT REFERENCES tasks(id) ON DELETE CASCADE,
+                       project_id INT REFERENCES projects(id) ON DELETE CASCADE,
+                       dedupe_key VARCHAR(255),
+                       read_at TIMESTAMP DEFAULT NULL,
+                       created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                       updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                       deleted_at TIMESTAMP DEFAULT NULL
+);
+
+ALTER TABLE notifications OWNER TO postgres;
+
+CREATE INDEX IF NOT EXISTS notifications_user_unread_idx ON notifications (user_id, read_at) WHERE deleted_at IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS notifications_dedupe_idx ON notifications (user_id, dedupe_key) WHERE dedupe_key IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS task_watchers (
+                       task_id INT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+                       user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                       created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                       PRIMARY KEY (task_id, user_id)
+);
+
+ALTER TABLE task_watchers OWNER TO postgres;
+
+CREATE TABLE IF NOT EXISTS task_comments (
+                       id SERIAL PRIMARY KEY,
+                       task_id INT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+                       user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                       body TEXT NOT NULL,
+                       created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                       updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                       deleted_at TIMESTAMP DEFAULT NULL
+);
+
+ALTER TABLE task_comments OWNER TO postgres;
+
+ALTER TABLE notifications ADD COLUMN IF NOT EXISTS emailed_at TIMESTAMP DEFAULT NULL;
+
+CREATE TABLE IF NOT EXISTS notification_preferences (
+                       user_id INT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+                       email_assignments BOOLEAN NOT NULL DEFAULT TRUE,
+                       email_digest BOOLEAN NOT NULL DEFAULT TRUE,
+                       digest_hour INT NOT NULL DEFAULT 8, -- UTC hour the digest is sent after
+                       last_digest_on DATE DEFAULT NULL,
+                       created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                       updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE notification_preferences OWNER TO postgres;
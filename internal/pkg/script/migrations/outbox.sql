@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS outbox_events (
+                       id BIGSERIAL PRIMARY KEY,
+                       aggregate_type VARCHAR(50) NOT NULL, -- 'task', 'project', 'user'
+                       aggregate_id INT NOT NULL,
+                       type VARCHAR(100) NOT NULL, -- e.g. 'task.created'
+                       payload JSONB NOT NULL,
+                       attempts INT NOT NULL DEFAULT 0,
+                       last_error TEXT,
+                       next_attempt_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                       processed_at TIMESTAMP DEFAULT NULL,
+                       dead_at TIMESTAMP DEFAULT NULL, -- gave up after too many failed attempts
+                       created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE outbox_events OWNER TO postgres;
+
+CREATE INDEX IF NOT EXISTS outbox_events_pending_idx ON outbox_events (aggregate_type, aggregate_id, id) WHERE processed_at IS NULL AND dead_at IS NULL;
+
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS outbox_event_id BIGINT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS webhook_deliveries_outbox_event_idx ON webhook_deliveries (webhook_id, outbox_event_id) WHERE outbox_event_id IS NOT NULL;
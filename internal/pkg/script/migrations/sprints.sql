@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS sprints (
+                       id SERIAL PRIMARY KEY,
+                       project_id INT NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+                       name VARCHAR(255) NOT NULL,
+                       goal TEXT,
+                       start_date DATE NOT NULL,
+                       end_date DATE NOT NULL,
+                       state VARCHAR(20) NOT NULL DEFAULT 'planned', -- 'planned', 'active', 'closed'
+                       committed_tasks INT, -- snapshot taken when the sprint starts
+                       committed_points INT,
+                       completed_tasks INT, -- snapshot taken when the sprint closes
+                       completed_points INT,
+                       carried_over_tasks INT,
+                       carried_over_points INT,
+                       started_at TIMESTAMP DEFAULT NULL,
+                       closed_at TIMESTAMP DEFAULT NULL,
+                       created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                       updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                       deleted_at TIMESTAMP DEFAULT NULL
+);
+
+ALTER TABLE sprints OWNER TO postgres;
+
+CREATE UNIQUE INDEX IF NOT EXISTS sprints_active_idx ON sprints (project_id) WHERE state = 'active' AND deleted_at IS NULL;
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS sprint_id INT REFERENCES sprints(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS tasks_sprint_id_idx ON tasks (sprint_id) WHERE deleted_at IS NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS labels TEXT[] DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS tasks_labels_idx ON tasks USING GIN (labels);
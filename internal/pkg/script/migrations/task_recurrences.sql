@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS task_recurrences (
+                       id SERIAL PRIMARY KEY,
+                       template_task_id INT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+                       rule VARCHAR(255) NOT NULL, -- RRULE subset, see internal/util/rrule
+                       mode VARCHAR(50) DEFAULT 'schedule', -- 'schedule', 'on_complete'
+                       lead_days INT DEFAULT 0,
+                       dtstart DATE NOT NULL,
+                       next_date DATE, -- NULL once the rule is exhausted
+                       occurrences INT DEFAULT 1,
+                       created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                       updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                       deleted_at TIMESTAMP DEFAULT NULL
+);
+
+ALTER TABLE task_recurrences OWNER TO postgres;
+
+CREATE UNIQUE INDEX IF NOT EXISTS task_recurrences_template_idx ON task_recurrences (template_task_id) WHERE deleted_at IS NULL;
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS recurrence_id INT REFERENCES task_recurrences(id) ON DELETE SET NULL;
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS occurrence_date DATE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS tasks_recurrence_occurrence_idx ON tasks (recurrence_id, occurrence_date) WHERE recurrence_id IS NOT NULL;
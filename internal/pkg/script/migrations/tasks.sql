@@ -12,4 +12,12 @@ CREATE TABLE IF NOT EXISTS tasks (
                        deleted_at TIMESTAMP DEFAULT NULL
 );
 
-ALTER TABLE tasks OWNER TO postgres;
\ No newline at end of file
+ALTER TABLE tasks OWNER TO postgres;
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS start_date DATE;
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS original_estimate INT; -- seconds
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS remaining_estimate INT; -- seconds
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS story_points INT;
@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS webhooks (
+                       id SERIAL PRIMARY KEY,
+                       project_id INT REFERENCES projects(id) ON DELETE CASCADE, -- NULL subscribes to every project
+                       url TEXT NOT NULL,
+                       secret VARCHAR(255) NOT NULL,
+                       events TEXT[] NOT NULL, -- 'task.created', 'task.updated', 'task.deleted', 'project.updated'
+                       active BOOLEAN NOT NULL DEFAULT TRUE,
+                       created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                       updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                       deleted_at TIMESTAMP DEFAULT NULL
+);
+
+ALTER TABLE webhooks OWNER TO postgres;
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+                       id SERIAL PRIMARY KEY,
+                       webhook_id INT NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+                       event VARCHAR(50) NOT NULL,
+                       payload JSONB NOT NULL,
+                       status VARCHAR(20) NOT NULL DEFAULT 'pending', -- 'pending', 'succeeded', 'failed'
+                       attempts INT NOT NULL DEFAULT 0,
+                       next_attempt_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                       response_code INT,
+                       response_body TEXT,
+                       error TEXT,
+                       delivered_at TIMESTAMP DEFAULT NULL,
+                       created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                       updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                       deleted_at TIMESTAMP DEFAULT NULL
+);
+
+ALTER TABLE webhook_deliveries OWNER TO postgres;
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_due_idx ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_webhook_id_idx ON webhook_deliveries (webhook_id, id);
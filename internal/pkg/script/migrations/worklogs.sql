@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS worklogs (
+                       id SERIAL PRIMARY KEY,
+                       task_id INT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+                       user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                       started_at TIMESTAMP NOT NULL,
+                       duration INT, -- seconds, NULL while the timer is running
+                       note TEXT,
+                       created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                       updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+                       deleted_at TIMESTAMP DEFAULT NULL
+);
+
+ALTER TABLE worklogs OWNER TO postgres;
+
+CREATE UNIQUE INDEX IF NOT EXISTS worklogs_running_timer_idx ON worklogs (user_id) WHERE duration IS NULL AND deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS worklogs_task_id_idx ON worklogs (task_id);
@@ -0,0 +1,164 @@
+package stream
+
+import (
+	"context"
+	"log"
+	"sync"
+	"task-management2/internal/repository/postgres/outbox"
+	"time"
+)
+
+const (
+	tailBatch = 500
+	// gapTimeout is how long the hub waits for a missing event id, which
+	// usually belongs to a transaction that hasn't committed yet. After that
+	// the id is taken to be rolled back.
+	gapTimeout   = 5 * time.Second
+	clientBuffer = 256
+)
+
+type Repository interface {
+	Since(ctx context.Context, afterId int64, limit int) ([]outbox.Event, error)
+	LastId(ctx context.Context) (int64, error)
+}
+
+// Subscription receives the events matching its filter. Done is closed when
+// the hub drops a client that doesn't keep up; it should reconnect with its
+// last event id.
+type Subscription struct {
+	Events chan outbox.Event
+	Done   chan struct{}
+
+	match func(outbox.Event) bool
+	once  sync.Once
+}
+
+func (s *Subscription) close() {
+	s.once.Do(func() { close(s.Done) })
+}
+
+// Hub tails the outbox and fans events out to the streams connected to this
+// instance. Tailing the table rather than subscribing to the bus lets every
+// instance see every event, whichever instance dispatched it.
+type Hub struct {
+	repo     Repository
+	interval time.Duration
+
+	mu       sync.Mutex
+	clients  map[*Subscription]struct{}
+	cursor   int64
+	gapSince time.Time
+}
+
+func NewHub(repo Repository, interval time.Duration) *Hub {
+	return &Hub{
+		repo:     repo,
+		interval: interval,
+		clients:  map[*Subscription]struct{}{},
+	}
+}
+
+// Start tails the outbox in the background until ctx is cancelled. Only
+// events written after the start are streamed live.
+func (h *Hub) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(h.interval)
+		defer ticker.Stop()
+
+		started := false
+		for {
+			if !started {
+				cursor, err := h.repo.LastId(ctx)
+				if err != nil {
+					log.Printf("stream hub: %v", err)
+				} else {
+					h.mu.Lock()
+					h.cursor = cursor
+					h.mu.Unlock()
+					started = true
+				}
+			} else if err := h.poll(ctx); err != nil {
+				log.Printf("stream hub: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Subscribe registers a client. It returns the id of the last event the hub
+// has passed on: everything after it arrives on the subscription, anything
+// up to it has to be read from the outbox to resume a stream.
+func (h *Hub) Subscribe(match func(outbox.Event) bool) (*Subscription, int64) {
+	sub := &Subscription{
+		Events: make(chan outbox.Event, clientBuffer),
+		Done:   make(chan struct{}),
+		match:  match,
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.clients[sub] = struct{}{}
+
+	return sub, h.cursor
+}
+
+func (h *Hub) Unsubscribe(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.clients, sub)
+	sub.close()
+}
+
+func (h *Hub) poll(ctx context.Context) error {
+	h.mu.Lock()
+	cursor := h.cursor
+	h.mu.Unlock()
+
+	events, err := h.repo.Since(ctx, cursor, tailBatch)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, event := range events {
+		if event.Id > h.cursor+1 {
+			if h.gapSince.IsZero() {
+				h.gapSince = time.Now()
+			}
+			if time.Since(h.gapSince) < gapTimeout {
+				break
+			}
+		}
+
+		h.gapSince = time.Time{}
+		h.cursor = event.Id
+		h.broadcast(event)
+	}
+
+	return nil
+}
+
+// broadcast must be called with h.mu held.
+func (h *Hub) broadcast(event outbox.Event) {
+	for sub := range h.clients {
+		if !sub.match(event) {
+			continue
+		}
+
+		select {
+		case sub.Events <- event:
+		default:
+			delete(h.clients, sub)
+			sub.close()
+		}
+	}
+}
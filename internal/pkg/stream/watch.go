@@ -0,0 +1,149 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"task-management2/internal/repository/postgres/outbox"
+	"task-management2/internal/repository/postgres/projects"
+	"time"
+)
+
+const (
+	replayBatch   = 500
+	accessRefresh = 30 * time.Second
+	// KeepAlive is how long a stream may stay idle before Run pings it.
+	KeepAlive = 15 * time.Second
+)
+
+// ErrDropped is returned by Run when the hub drops a client that doesn't
+// keep up. It should reconnect with its last event id.
+var ErrDropped = errors.New("too slow, reconnect with the last event id")
+
+type AccessRepository interface {
+	GetAccess(ctx context.Context, userId int) (projects.Access, error)
+}
+
+// Watcher opens the streams of the events a user may see, for the
+// Server-Sent Events and gRPC endpoints alike.
+type Watcher struct {
+	hub    *Hub
+	access AccessRepository
+}
+
+func NewWatcher(hub *Hub, access AccessRepository) *Watcher {
+	return &Watcher{
+		hub:    hub,
+		access: access,
+	}
+}
+
+// Watch is the open stream of a user.
+type Watch struct {
+	watcher *Watcher
+	userId  int
+	match   func(outbox.Event) bool
+
+	sub      *Subscription
+	cursor   int64
+	access   projects.Access
+	accessAt time.Time
+}
+
+// Open subscribes userId to the events matching match. It fails when the
+// access of the user can't be read, with sql.ErrNoRows for a missing user.
+// The Watch has to be closed.
+func (w *Watcher) Open(ctx context.Context, userId int, match func(outbox.Event) bool) (*Watch, error) {
+	access, err := w.access.GetAccess(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	sub, cursor := w.hub.Subscribe(match)
+
+	return &Watch{
+		watcher:  w,
+		userId:   userId,
+		match:    match,
+		sub:      sub,
+		cursor:   cursor,
+		access:   access,
+		accessAt: time.Now(),
+	}, nil
+}
+
+func (w *Watch) Close() {
+	w.watcher.hub.Unsubscribe(w.sub)
+}
+
+// Run passes the events of the stream to send: first the ones after
+// lastEventId a reconnecting client missed, up to where the live events
+// start, then the live ones. Events of projects the user may not see are
+// left out, the access is read again every accessRefresh. ping, unless nil,
+// is called when the stream was idle for KeepAlive.
+//
+// Run returns nil when ctx is done, ErrDropped when the hub drops the
+// client, or the error of send or ping.
+func (w *Watch) Run(ctx context.Context, lastEventId int64, send func(outbox.Event) error, ping func() error) error {
+	deliver := func(event outbox.Event) error {
+		if !w.access.CanView(event.ProjectId()) {
+			return nil
+		}
+
+		return send(event)
+	}
+
+replay:
+	for lastEventId > 0 && lastEventId < w.cursor {
+		events, err := w.watcher.hub.repo.Since(ctx, lastEventId, replayBatch)
+		if err != nil || len(events) == 0 {
+			break
+		}
+
+		for _, event := range events {
+			if event.Id > w.cursor {
+				break replay
+			}
+			lastEventId = event.Id
+
+			if !w.match(event) {
+				continue
+			}
+			if err := deliver(event); err != nil {
+				return err
+			}
+		}
+	}
+
+	var idle <-chan time.Time
+	if ping != nil {
+		ticker := time.NewTicker(KeepAlive)
+		defer ticker.Stop()
+		idle = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-w.sub.Done:
+			return ErrDropped
+		case <-idle:
+			if err := ping(); err != nil {
+				return err
+			}
+		case event := <-w.sub.Events:
+			if time.Since(w.accessAt) > accessRefresh {
+				if fresh, err := w.watcher.access.GetAccess(ctx, w.userId); err == nil {
+					w.access = fresh
+				} else {
+					w.access = projects.Access{}
+				}
+				w.accessAt = time.Now()
+			}
+
+			if err := deliver(event); err != nil {
+				return err
+			}
+		}
+	}
+}
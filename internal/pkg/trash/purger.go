@@ -0,0 +1,60 @@
+package trash
+
+import (
+	"context"
+	"log"
+	"task-management2/internal/repository/postgres/trash"
+	"time"
+)
+
+// DefaultRetention is how long deleted records are kept when the config
+// doesn't say.
+const DefaultRetention = 30 * 24 * time.Hour
+
+type Repository interface {
+	Purge(ctx context.Context, before time.Time) (trash.Purged, error)
+}
+
+// Purger periodically hard deletes records that have been in the trash for
+// longer than the retention. Purging is idempotent, so the job can run on
+// every instance.
+type Purger struct {
+	repo      Repository
+	retention time.Duration
+	interval  time.Duration
+}
+
+func NewPurger(repo Repository, retention, interval time.Duration) *Purger {
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+
+	return &Purger{
+		repo:      repo,
+		retention: retention,
+		interval:  interval,
+	}
+}
+
+// Start runs the job in the background until ctx is cancelled.
+func (p *Purger) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		for {
+			purged, err := p.repo.Purge(ctx, time.Now().Add(-p.retention))
+			if err != nil {
+				log.Printf("trash purge: %v", err)
+			} else if purged.Users+purged.Projects+purged.Tasks > 0 {
+				log.Printf("trash purge: removed %d users, %d projects, %d tasks", purged.Users, purged.Projects, purged.Tasks)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
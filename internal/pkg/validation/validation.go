@@ -0,0 +1,146 @@
+// Package validation is the validator gin binds requests with. Besides the
+// binding tags gin evaluates by default it evaluates the validate tags of
+// the repository DTOs, so rules can live next to the fields they check.
+package validation
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// DateLayout is the format of the date fields of every DTO.
+const DateLayout = "2006-01-02"
+
+// Day cuts a DATE column scanned as a timestamp, such as
+// "2025-01-22T00:00:00Z", down to the date, so it compares with the dates
+// of DTOs.
+func Day(s *string) *string {
+	if s == nil || len(*s) <= len(DateLayout) {
+		return s
+	}
+
+	d := (*s)[:len(DateLayout)]
+	return &d
+}
+
+type Validator struct {
+	binding  *validator.Validate
+	validate *validator.Validate
+}
+
+func New() *Validator {
+	return &Validator{
+		binding:  newValidate("binding"),
+		validate: newValidate("validate"),
+	}
+}
+
+var std = New()
+
+// Install makes gin bind requests with the validator Struct uses.
+func Install() {
+	binding.Validator = std
+}
+
+// Struct validates obj outside of request binding, for documents built by
+// the repositories such as merged patches.
+func Struct(obj interface{}) error {
+	return std.ValidateStruct(obj)
+}
+
+// ValidateStruct implements binding.StructValidator. obj may be a struct,
+// a pointer to one or a slice of them. All failed rules are returned as a
+// single validator.ValidationErrors.
+func (v *Validator) ValidateStruct(obj interface{}) error {
+	if obj == nil {
+		return nil
+	}
+
+	value := reflect.ValueOf(obj)
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return nil
+		}
+		return v.ValidateStruct(value.Elem().Interface())
+	case reflect.Struct:
+		return v.validateStruct(obj)
+	case reflect.Slice, reflect.Array:
+		var all validator.ValidationErrors
+		for i := 0; i < value.Len(); i++ {
+			err := v.ValidateStruct(value.Index(i).Interface())
+			if err != nil && !collect(&all, err) {
+				return err
+			}
+		}
+		if len(all) > 0 {
+			return all
+		}
+	}
+
+	return nil
+}
+
+// Engine returns the validator of the validate tags, for registering more
+// rules.
+func (v *Validator) Engine() interface{} {
+	return v.validate
+}
+
+func (v *Validator) validateStruct(obj interface{}) error {
+	var all validator.ValidationErrors
+
+	for _, validate := range []*validator.Validate{v.binding, v.validate} {
+		err := validate.Struct(obj)
+		if err != nil && !collect(&all, err) {
+			return err
+		}
+	}
+
+	if len(all) > 0 {
+		return all
+	}
+
+	return nil
+}
+
+// collect appends the field errors of err to all, it reports false for
+// errors that aren't about fields.
+func collect(all *validator.ValidationErrors, err error) bool {
+	var fields validator.ValidationErrors
+	if !errors.As(err, &fields) {
+		return false
+	}
+
+	*all = append(*all, fields...)
+	return true
+}
+
+func newValidate(tag string) *validator.Validate {
+	v := validator.New()
+	v.SetTagName(tag)
+
+	// report json names, not struct field names
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name := strings.SplitN(f.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return f.Name
+		}
+		return name
+	})
+
+	_ = v.RegisterValidation("date", func(fl validator.FieldLevel) bool {
+		_, err := time.Parse(DateLayout, fl.Field().String())
+		return err == nil
+	})
+
+	return v
+}
@@ -0,0 +1,154 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"task-management2/internal/repository/postgres/webhooks"
+	"time"
+)
+
+const (
+	batchSize = 20
+	// MaxAttempts is how often a delivery is tried before it is marked failed.
+	MaxAttempts = 8
+	// lease is how long a claimed delivery is hidden from other dispatchers.
+	lease           = 2 * time.Minute
+	baseBackoff     = 30 * time.Second
+	maxBackoff      = 6 * time.Hour
+	maxResponseBody = 4096
+)
+
+type Repository interface {
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]webhooks.Due, error)
+	RecordResult(ctx context.Context, result webhooks.Result) error
+}
+
+// Dispatcher posts queued webhook deliveries. Deliveries are claimed in the
+// database, so it can run on every instance. A delivery fails on a non-2xx
+// response or a transport error and is retried with exponential backoff.
+type Dispatcher struct {
+	repo     Repository
+	client   *http.Client
+	interval time.Duration
+}
+
+func NewDispatcher(repo Repository, client *http.Client, interval time.Duration) *Dispatcher {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return &Dispatcher{
+		repo:     repo,
+		client:   client,
+		interval: interval,
+	}
+}
+
+// Start runs the dispatcher in the background until ctx is cancelled.
+func (d *Dispatcher) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(d.interval)
+		defer ticker.Stop()
+
+		for {
+			if err := d.RunOnce(ctx); err != nil {
+				log.Printf("webhook dispatcher: %v", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// RunOnce sends the deliveries that are due now.
+func (d *Dispatcher) RunOnce(ctx context.Context) error {
+	due, err := d.repo.ClaimDue(ctx, batchSize, lease)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range due {
+		result := d.Deliver(ctx, item)
+
+		if err := d.repo.RecordResult(ctx, result); err != nil {
+			log.Printf("webhook dispatcher: delivery %d: %v", item.Id, err)
+		}
+	}
+
+	return nil
+}
+
+// Deliver makes one attempt at sending item and returns its outcome.
+func (d *Dispatcher) Deliver(ctx context.Context, item webhooks.Due) webhooks.Result {
+	result := webhooks.Result{Id: item.Id}
+	body := []byte(item.Payload)
+	timestamp := time.Now().Unix()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, item.Url, bytes.NewReader(body))
+	if err != nil {
+		return failed(result, item.Attempts, err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "task-management-webhooks")
+	req.Header.Set(HeaderEvent, item.Event)
+	req.Header.Set(HeaderDelivery, strconv.Itoa(item.Id))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(item.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return failed(result, item.Attempts, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	code := resp.StatusCode
+	text := string(respBody)
+	result.ResponseCode = &code
+	result.ResponseBody = &text
+
+	if code >= 200 && code < 300 {
+		result.Succeeded = true
+		return result
+	}
+
+	return failed(result, item.Attempts, nil)
+}
+
+// failed schedules the next attempt, or none once MaxAttempts is reached.
+func failed(result webhooks.Result, attempts int, err error) webhooks.Result {
+	if err != nil {
+		msg := err.Error()
+		result.Error = &msg
+	}
+
+	if attempts < MaxAttempts {
+		retryAt := time.Now().Add(Backoff(attempts))
+		result.RetryAt = &retryAt
+	}
+
+	return result
+}
+
+// Backoff returns the wait after the given number of failed attempts:
+// 30s, 1m, 2m, ... capped at 6h.
+func Backoff(attempts int) time.Duration {
+	wait := baseBackoff
+	for i := 1; i < attempts; i++ {
+		wait *= 2
+		if wait >= maxBackoff {
+			return maxBackoff
+		}
+	}
+
+	return wait
+}
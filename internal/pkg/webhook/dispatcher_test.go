@@ -0,0 +1,192 @@
+package webhook_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"task-management2/internal/pkg/webhook"
+	"task-management2/internal/repository/postgres/webhooks"
+)
+
+// repo hands out the given deliveries once and records the results.
+type repo struct {
+	mu      sync.Mutex
+	due     []webhooks.Due
+	results []webhooks.Result
+}
+
+func (r *repo) ClaimDue(context.Context, int, time.Duration) ([]webhooks.Due, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	due := r.due
+	r.due = nil
+	return due, nil
+}
+
+func (r *repo) RecordResult(_ context.Context, result webhooks.Result) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.results = append(r.results, result)
+
+	return nil
+}
+
+// receiver is a webhook endpoint answering status, it keeps the last
+// request it got.
+type receiver struct {
+	*httptest.Server
+
+	mu     sync.Mutex
+	status int
+	header http.Header
+	body   []byte
+}
+
+func newReceiver(t *testing.T, status int) *receiver {
+	t.Helper()
+
+	r := &receiver{status: status}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+
+		r.mu.Lock()
+		r.header, r.body = req.Header.Clone(), body
+		r.mu.Unlock()
+
+		w.WriteHeader(r.status)
+		_, _ = w.Write([]byte("received"))
+	}))
+	t.Cleanup(r.Close)
+
+	return r
+}
+
+// run dispatches one delivery of the given attempt to url and returns its
+// result.
+func run(t *testing.T, url string, attempts int) webhooks.Result {
+	t.Helper()
+
+	r := &repo{due: []webhooks.Due{{
+		Id: 7, WebhookId: 3, Event: "task.updated", Payload: `{"id":42}`,
+		Attempts: attempts, Url: url, Secret: "s3cret",
+	}}}
+
+	d := webhook.NewDispatcher(r, nil, time.Minute)
+	if err := d.RunOnce(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(r.results) != 1 {
+		t.Fatalf("want 1 result, got %+v", r.results)
+	}
+
+	return r.results[0]
+}
+
+func TestDeliverySignature(t *testing.T) {
+	rcv := newReceiver(t, http.StatusNoContent)
+
+	result := run(t, rcv.URL, 1)
+	if !result.Succeeded || result.RetryAt != nil {
+		t.Errorf("2xx response: want success, got %+v", result)
+	}
+
+	if got := rcv.header.Get(webhook.HeaderEvent); got != "task.updated" {
+		t.Errorf("event header: got %q", got)
+	}
+	if got := rcv.header.Get(webhook.HeaderDelivery); got != "7" {
+		t.Errorf("delivery header: got %q", got)
+	}
+	if string(rcv.body) != `{"id":42}` {
+		t.Errorf("body: got %s", rcv.body)
+	}
+
+	ts := rcv.header.Get(webhook.HeaderTimestamp)
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write([]byte(ts + "." + string(rcv.body)))
+	want := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	if got := rcv.header.Get(webhook.HeaderSignature); got != want {
+		t.Errorf("signature: want HMAC-SHA256 of timestamp.body %s, got %s", want, got)
+	}
+
+	timestamp, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		t.Fatalf("timestamp header %q: %v", ts, err)
+	}
+	if !webhook.Verify("s3cret", timestamp, rcv.body, want) {
+		t.Error("Verify rejects the signature sent")
+	}
+	if webhook.Verify("other", timestamp, rcv.body, want) {
+		t.Error("Verify accepts the signature with another secret")
+	}
+}
+
+func TestDeliveryRetry(t *testing.T) {
+	rcv := newReceiver(t, http.StatusInternalServerError)
+
+	for _, attempts := range []int{1, 3, webhook.MaxAttempts - 1} {
+		before := time.Now()
+		result := run(t, rcv.URL, attempts)
+		after := time.Now()
+
+		if result.Succeeded {
+			t.Fatalf("attempt %d: a 500 isn't a success", attempts)
+		}
+		if result.ResponseCode == nil || *result.ResponseCode != http.StatusInternalServerError {
+			t.Errorf("attempt %d: want response code 500, got %v", attempts, result.ResponseCode)
+		}
+		if result.ResponseBody == nil || *result.ResponseBody != "received" {
+			t.Errorf("attempt %d: want the response body kept, got %v", attempts, result.ResponseBody)
+		}
+
+		wait := webhook.Backoff(attempts)
+		if result.RetryAt == nil || result.RetryAt.Before(before.Add(wait)) || result.RetryAt.After(after.Add(wait)) {
+			t.Errorf("attempt %d: want a retry in %v, got %v", attempts, wait, result.RetryAt)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for attempts, want := range map[int]time.Duration{
+		1:  30 * time.Second,
+		2:  time.Minute,
+		3:  2 * time.Minute,
+		10: 256 * time.Minute,
+		11: 6 * time.Hour,
+		50: 6 * time.Hour,
+	} {
+		if got := webhook.Backoff(attempts); got != want {
+			t.Errorf("Backoff(%d): want %v, got %v", attempts, want, got)
+		}
+	}
+}
+
+func TestDeliveryDeadLetter(t *testing.T) {
+	rcv := newReceiver(t, http.StatusBadGateway)
+
+	result := run(t, rcv.URL, webhook.MaxAttempts)
+	if result.Succeeded || result.RetryAt != nil {
+		t.Errorf("last attempt failing: want no retry, got %+v", result)
+	}
+
+	// transport errors count as failures too
+	rcv.Close()
+	result = run(t, rcv.URL, webhook.MaxAttempts)
+	if result.Succeeded || result.RetryAt != nil || result.Error == nil {
+		t.Errorf("last attempt unreachable: want the error and no retry, got %+v", result)
+	}
+
+	result = run(t, rcv.URL, 2)
+	if result.RetryAt == nil || result.Error == nil {
+		t.Errorf("unreachable: want the error and a retry, got %+v", result)
+	}
+}
@@ -0,0 +1,23 @@
+package webhook
+
+import (
+	"context"
+	"task-management2/internal/pkg/events"
+	"task-management2/internal/repository/postgres/outbox"
+	"time"
+)
+
+type EnqueueRepository interface {
+	Enqueue(ctx context.Context, eventId int64, event string, occurredAt time.Time, projectId *int, data interface{}) error
+}
+
+// Subscribe queues webhook deliveries for the task and project events on
+// bus. Webhooks that aren't subscribed to an event type get nothing.
+func Subscribe(bus *events.Bus, repo EnqueueRepository) {
+	handler := func(ctx context.Context, event outbox.Event) error {
+		return repo.Enqueue(ctx, event.Id, event.Type, event.CreatedAt, event.ProjectId(), event.Payload)
+	}
+
+	bus.Subscribe(outbox.AggregateTask+".*", handler)
+	bus.Subscribe(outbox.AggregateProject+".*", handler)
+}
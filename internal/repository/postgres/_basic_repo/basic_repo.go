@@ -2,10 +2,16 @@ package basic_repo
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/uptrace/bun"
 	"time"
 )
 
+// ErrVersionMismatch is returned by writes that were given the version the
+// caller last read (from If-Match) when the record has changed since.
+var ErrVersionMismatch = errors.New("the record was changed by someone else, reload it and try again")
+
 func BasicDelete(ctx context.Context, data Delete, table interface{}, r *bun.DB) error {
 	_, err := r.NewUpdate().
 		Model(table).
@@ -18,3 +24,37 @@ func BasicDelete(ctx context.Context, data Delete, table interface{}, r *bun.DB)
 
 	return err
 }
+
+// ValidationError is returned for input that can't be written, as opposed
+// to failures of the database. Field names the offending request field
+// when there is a single one.
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// ConflictError is returned for writes the current state of the record
+// doesn't allow, such as starting a sprint twice.
+type ConflictError struct {
+	Message string
+}
+
+func (e *ConflictError) Error() string {
+	return e.Message
+}
+
+func Invalid(format string, args ...interface{}) error {
+	return &ValidationError{Message: fmt.Sprintf(format, args...)}
+}
+
+func InvalidField(field, format string, args ...interface{}) error {
+	return &ValidationError{Field: field, Message: fmt.Sprintf(format, args...)}
+}
+
+func Conflict(format string, args ...interface{}) error {
+	return &ConflictError{Message: fmt.Sprintf(format, args...)}
+}
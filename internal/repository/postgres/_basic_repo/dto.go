@@ -2,4 +2,14 @@ package basic_repo
 
 type Delete struct {
 	Id *int `json:"id" form:"id" bun:"id"`
+	// Version, when set, must be the record's current version.
+	Version *int `json:"-"`
+}
+
+// MergePatch is a JSON merge patch (RFC 7396) of the record Id.
+type MergePatch struct {
+	Id    *int
+	Patch []byte
+	// Version, when set, must be the record's current version.
+	Version *int
 }
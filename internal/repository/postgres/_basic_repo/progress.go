@@ -0,0 +1,83 @@
+package basic_repo
+
+import "fmt"
+
+// Progress weightings. With count every task weighs the same, with points a
+// task weighs its story points and with estimate its original estimate.
+const (
+	WeightingCount    = "count"
+	WeightingPoints   = "points"
+	WeightingEstimate = "estimate"
+)
+
+func ValidWeighting(weighting string) bool {
+	switch weighting {
+	case WeightingCount, WeightingPoints, WeightingEstimate:
+		return true
+	}
+
+	return false
+}
+
+// TaskStatsColumns are the aggregates over a set of task rows that
+// ProgressExpr is computed from.
+const TaskStatsColumns = `
+	COUNT(*) as total_tasks,
+	COUNT(CASE WHEN status = 'completed' THEN 1 END) as completed_tasks,
+	COUNT(CASE WHEN status = 'in_progress' THEN 1 END) as in_progress_tasks,
+	COUNT(CASE WHEN status = 'pending' THEN 1 END) as pending_tasks,
+	COALESCE(SUM(story_points), 0) as total_points,
+	COALESCE(SUM(CASE WHEN status = 'completed' THEN story_points END), 0) as completed_points,
+	COALESCE(SUM(CASE WHEN status = 'in_progress' THEN story_points END), 0) as in_progress_points,
+	COALESCE(SUM(original_estimate), 0) as original_estimate,
+	COALESCE(SUM(CASE WHEN status = 'completed' THEN 0 ELSE COALESCE(remaining_estimate, original_estimate) END), 0) as remaining_estimate,
+	COALESCE(SUM(
+		CASE
+			WHEN status = 'completed' THEN original_estimate
+			ELSE GREATEST(original_estimate - COALESCE(remaining_estimate, original_estimate), 0)
+		END
+	), 0) as done_estimate`
+
+// ProgressExpr returns the SQL expression of the progress percentage (one
+// decimal) over the TaskStatsColumns selected under alias. Completed work
+// counts fully; in progress work counts half with count and points
+// weighting, and by its logged-off estimate with estimate weighting.
+func ProgressExpr(weighting, alias string) string {
+	switch weighting {
+	case WeightingPoints:
+		return fmt.Sprintf(`COALESCE(
+			CASE 
+				WHEN %[1]s.total_points > 0 THEN
+					(
+						(COALESCE(%[1]s.completed_points, 0)::numeric * 100 + 
+						COALESCE(%[1]s.in_progress_points, 0)::numeric * 50) / 
+						(%[1]s.total_points::numeric * 100) * 100
+					)::numeric(10,1)
+				ELSE 0
+			END
+		, 0)`, alias)
+	case WeightingEstimate:
+		return fmt.Sprintf(`COALESCE(
+			CASE 
+				WHEN %[1]s.original_estimate > 0 THEN
+					(
+						COALESCE(%[1]s.done_estimate, 0)::numeric / 
+						%[1]s.original_estimate::numeric * 100
+					)::numeric(10,1)
+				ELSE 0
+			END
+		, 0)`, alias)
+	default:
+		return fmt.Sprintf(`COALESCE(
+			CASE 
+				WHEN %[1]s.total_tasks > 0 THEN
+					(
+						(COALESCE(%[1]s.completed_tasks, 0)::numeric * 100 + 
+						COALESCE(%[1]s.in_progress_tasks, 0)::numeric * 50) / 
+						(%[1]s.total_tasks::numeric * 100) * 100
+					)::numeric(10,1)
+				ELSE 0
+			END
+		, 0)`, alias)
+	}
+}
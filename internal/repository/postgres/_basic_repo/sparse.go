@@ -0,0 +1,121 @@
+package basic_repo
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Sparse is the fields and include parameters of a read: the fields to
+// return, every field when empty, and the related records to embed.
+type Sparse struct {
+	Fields  []string
+	Include []string
+}
+
+// Column is a field or a relation a read may select: the SQL it is selected
+// with, the join it needs and where the values are scanned to. Expr may
+// select several values, Dest returns a destination for each.
+type Column[T any] struct {
+	Name string
+	Expr string
+	Join string
+	Dest func(*T) []any
+	// Key columns are read even when not asked for, ETags and joins need
+	// them.
+	Key bool
+}
+
+// Selection is the columns of a read.
+type Selection[T any] []Column[T]
+
+// Select picks the fields and relations sparse asks for. Unknown names are
+// a ValidationError of the fields or include parameter.
+func Select[T any](fields, relations []Column[T], sparse Sparse) (Selection[T], error) {
+	for _, name := range sparse.Fields {
+		if !slices.ContainsFunc(fields, func(c Column[T]) bool { return c.Name == name }) {
+			return nil, InvalidField("fields", "unknown field %q", name)
+		}
+	}
+	for _, name := range sparse.Include {
+		if !slices.ContainsFunc(relations, func(c Column[T]) bool { return c.Name == name }) {
+			return nil, InvalidField("include", "unknown relation %q", name)
+		}
+	}
+
+	var selection Selection[T]
+	for _, c := range fields {
+		if c.Key || len(sparse.Fields) == 0 || slices.Contains(sparse.Fields, c.Name) {
+			selection = append(selection, c)
+		}
+	}
+	for _, c := range relations {
+		if slices.Contains(sparse.Include, c.Name) {
+			selection = append(selection, c)
+		}
+	}
+
+	return selection, nil
+}
+
+// Has reports whether the column name is selected.
+func (s Selection[T]) Has(name string) bool {
+	return slices.ContainsFunc(s, func(c Column[T]) bool { return c.Name == name })
+}
+
+// Columns is the select list.
+func (s Selection[T]) Columns() string {
+	exprs := make([]string, 0, len(s))
+	for _, c := range s {
+		exprs = append(exprs, c.Expr)
+	}
+
+	return strings.Join(exprs, ",\n")
+}
+
+// Joins are the joins the selected columns need, each once.
+func (s Selection[T]) Joins() string {
+	var joins []string
+	for _, c := range s {
+		if c.Join != "" && !slices.Contains(joins, c.Join) {
+			joins = append(joins, c.Join)
+		}
+	}
+
+	return strings.Join(joins, "\n")
+}
+
+// Scan scans a row of the select list into v, and the columns selected
+// after the list into extra.
+func (s Selection[T]) Scan(row interface{ Scan(dest ...any) error }, v *T, extra ...any) error {
+	var dest []any
+	for _, c := range s {
+		dest = append(dest, c.Dest(v)...)
+	}
+
+	return row.Scan(append(dest, extra...)...)
+}
+
+// JSON scans a json column, such as an embedded record built with
+// json_build_object, into v. NULL leaves v as it is.
+func JSON(v any) any {
+	return jsonScanner{v}
+}
+
+type jsonScanner struct {
+	v any
+}
+
+func (s jsonScanner) Scan(src any) error {
+	switch data := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(data, s.v)
+	case string:
+		return json.Unmarshal([]byte(data), s.v)
+	}
+
+	return fmt.Errorf("can't scan %T as json", src)
+}
@@ -0,0 +1,199 @@
+package calendar
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"fmt"
+	"task-management2/internal/entity"
+	"task-management2/internal/util/hash"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type Repository struct {
+	*bun.DB
+}
+
+func NewRepository(DB *bun.DB) *Repository {
+	return &Repository{DB: DB}
+}
+
+// CreateToken issues a new feed token for the user and revokes the previous
+// ones, so there is at most one active subscription url per user.
+func (r Repository) CreateToken(ctx context.Context, data CreateToken) (Token, error) {
+	token, err := hash.NewToken(32)
+	if err != nil {
+		return Token{}, fmt.Errorf("error generating token: %w", err)
+	}
+
+	tokenHash := hash.SHA256(token)
+	now := time.Now()
+
+	detail := entity.CalendarTokens{
+		UserId:    data.UserId,
+		TokenHash: &tokenHash,
+	}
+	detail.CreatedAt = &now
+
+	err = r.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewUpdate().
+			Model((*entity.CalendarTokens)(nil)).
+			Set("deleted_at = ?", now).
+			Where("user_id = ? AND deleted_at IS NULL", *data.UserId).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.NewInsert().Model(&detail).Exec(ctx)
+		return err
+	})
+	if err != nil {
+		return Token{}, fmt.Errorf("error creating calendar token: %w", err)
+	}
+
+	return Token{
+		Id:        detail.Id,
+		UserId:    *data.UserId,
+		Token:     token,
+		CreatedAt: detail.CreatedAt,
+	}, nil
+}
+
+func (r Repository) RevokeToken(ctx context.Context, userId int) error {
+	result, err := r.NewUpdate().
+		Model((*entity.CalendarTokens)(nil)).
+		Set("deleted_at = ?", time.Now()).
+		Where("user_id = ? AND deleted_at IS NULL", userId).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("error revoking calendar token: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetUserIdByToken resolves an active feed token to its owner. Tokens of
+// deleted users are treated as revoked.
+func (r Repository) GetUserIdByToken(ctx context.Context, token string) (int, error) {
+	query := `
+		SELECT ct.user_id
+		FROM calendar_tokens ct
+		JOIN users u ON u.id = ct.user_id AND u.deleted_at IS NULL
+		WHERE ct.token_hash = ? AND ct.deleted_at IS NULL`
+
+	var userId int
+	err := r.QueryRowContext(ctx, query, hash.SHA256(token)).Scan(&userId)
+	if err != nil {
+		return 0, err
+	}
+
+	return userId, nil
+}
+
+// GetUserIdByPassword resolves the email and password of a user, the
+// credential a token is issued for. A wrong password is sql.ErrNoRows, like
+// a missing user.
+func (r Repository) GetUserIdByPassword(ctx context.Context, email, password string) (int, error) {
+	var userId int
+	var stored string
+
+	err := r.QueryRowContext(ctx,
+		"SELECT id, password FROM users WHERE email = ? AND deleted_at IS NULL",
+		email,
+	).Scan(&userId, &stored)
+	if err != nil {
+		return 0, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(stored), []byte(password)) != 1 {
+		return 0, sql.ErrNoRows
+	}
+
+	return userId, nil
+}
+
+func (r Repository) GetFeed(ctx context.Context, filter Filter) ([]FeedItem, error) {
+	query := `
+		SELECT 
+			t.id,
+			t.name,
+			COALESCE(t.description, ''),
+			COALESCE(t.status, 'pending'),
+			COALESCE(t.priority, 'medium'),
+			to_char(t.due_date::date, 'YYYY-MM-DD'),
+			t.project_id,
+			COALESCE(p.name, ''),
+			t.assigned_to,
+			t.created_at,
+			t.updated_at
+		FROM tasks t
+		JOIN projects p ON p.id = t.project_id AND p.deleted_at IS NULL
+		WHERE t.deleted_at IS NULL AND t.due_date IS NOT NULL`
+
+	var params []interface{}
+
+	if filter.UserId != nil {
+		query += " AND t.assigned_to = ?"
+		params = append(params, *filter.UserId)
+	}
+	if filter.ProjectId != nil {
+		query += " AND t.project_id = ?"
+		params = append(params, *filter.ProjectId)
+	}
+
+	query += " ORDER BY t.due_date, t.id"
+
+	rows, err := r.QueryContext(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying calendar feed: %w", err)
+	}
+	defer rows.Close()
+
+	var result []FeedItem
+	for rows.Next() {
+		var item FeedItem
+		var assignedTo sql.NullInt64
+
+		err := rows.Scan(
+			&item.Id,
+			&item.Name,
+			&item.Description,
+			&item.Status,
+			&item.Priority,
+			&item.DueDate,
+			&item.ProjectId,
+			&item.ProjectName,
+			&assignedTo,
+			&item.CreatedAt,
+			&item.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning calendar feed row: %w", err)
+		}
+
+		if assignedTo.Valid {
+			id := int(assignedTo.Int64)
+			item.AssignedTo = &id
+		}
+
+		result = append(result, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating calendar feed rows: %w", err)
+	}
+
+	return result, nil
+}
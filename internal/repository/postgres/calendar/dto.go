@@ -0,0 +1,33 @@
+package calendar
+
+import "time"
+
+type Filter struct {
+	UserId    *int
+	ProjectId *int
+}
+
+type CreateToken struct {
+	UserId *int `json:"user_id" uri:"user_id" binding:"required"`
+}
+
+type Token struct {
+	Id        int        `json:"id"`
+	UserId    int        `json:"user_id"`
+	Token     string     `json:"token"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+type FeedItem struct {
+	Id          int
+	Name        string
+	Description string
+	Status      string
+	Priority    string
+	DueDate     string
+	ProjectId   int
+	ProjectName string
+	AssignedTo  *int
+	CreatedAt   *time.Time
+	UpdatedAt   *time.Time
+}
@@ -0,0 +1,186 @@
+package comments
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"task-management2/internal/entity"
+	basic_repo "task-management2/internal/repository/postgres/_basic_repo"
+	"task-management2/internal/repository/postgres/notifications"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// mentionPattern matches user mentions written as @<user id>, e.g. "@12".
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@(\d+)\b`)
+
+type Repository struct {
+	*bun.DB
+}
+
+func NewRepository(DB *bun.DB) *Repository {
+	return &Repository{DB: DB}
+}
+
+// Mentions returns the distinct user ids mentioned in body.
+func Mentions(body string) []int {
+	var ids []int
+	seen := make(map[int]bool)
+
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		id, err := strconv.Atoi(match[1])
+		if err != nil || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+func (r Repository) GetAll(ctx context.Context, filter Filter) ([]List, int, error) {
+	query := `
+		SELECT
+			c.id,
+			c.task_id,
+			c.user_id,
+			COALESCE(u.full_name, ''),
+			c.body,
+			c.created_at,
+			COUNT(*) OVER() as total_count
+		FROM task_comments c
+		LEFT JOIN users u ON u.id = c.user_id
+		WHERE c.deleted_at IS NULL AND c.task_id = ?
+		ORDER BY c.id`
+
+	params := []interface{}{*filter.TaskId}
+
+	if filter.Limit != nil {
+		query += " LIMIT ?"
+		params = append(params, *filter.Limit)
+	}
+	if filter.Offset != nil {
+		query += " OFFSET ?"
+		params = append(params, *filter.Offset)
+	}
+
+	rows, err := r.QueryContext(ctx, query, params...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error querying comments: %w", err)
+	}
+	defer rows.Close()
+
+	var result []List
+	var totalCount int
+
+	for rows.Next() {
+		var item List
+
+		err := rows.Scan(
+			&item.Id,
+			&item.TaskId,
+			&item.UserId,
+			&item.UserName,
+			&item.Body,
+			&item.CreatedAt,
+			&totalCount,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error scanning comment row: %w", err)
+		}
+
+		item.Mentions = Mentions(item.Body)
+		result = append(result, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating comment rows: %w", err)
+	}
+
+	return result, totalCount, nil
+}
+
+// Create stores a comment and notifies the users it mentions. Mentions of
+// unknown or deleted users and of the author are ignored.
+func (r Repository) Create(ctx context.Context, data Create) (entity.TaskComments, error) {
+	var detail entity.TaskComments
+
+	now := time.Now()
+	detail.TaskId = data.TaskId
+	detail.UserId = data.UserId
+	detail.Body = data.Body
+	detail.CreatedAt = &now
+
+	err := r.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var task entity.Tasks
+		err := tx.NewSelect().
+			Model(&task).
+			Column("id", "name", "project_id").
+			Where("id = ? AND deleted_at IS NULL", *data.TaskId).
+			Scan(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.NewInsert().Model(&detail).Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		mentions := Mentions(*data.Body)
+		if len(mentions) == 0 {
+			return nil
+		}
+
+		var userIds []int
+		err = tx.NewSelect().
+			Model((*entity.User)(nil)).
+			Column("id").
+			Where("id IN (?) AND id <> ? AND deleted_at IS NULL", bun.In(mentions), *data.UserId).
+			Scan(ctx, &userIds)
+		if err != nil {
+			return err
+		}
+
+		var author string
+		err = tx.NewSelect().
+			Model((*entity.User)(nil)).
+			Column("full_name").
+			Where("id = ?", *data.UserId).
+			Scan(ctx, &author)
+		if err != nil {
+			return err
+		}
+
+		notificationType := notifications.TypeCommentMention
+		title := fmt.Sprintf("%s mentioned you on %s", author, *task.Name)
+		dedupeKey := fmt.Sprintf("%s:%d", notifications.TypeCommentMention, detail.Id)
+
+		items := make([]entity.Notifications, 0, len(userIds))
+		for i := range userIds {
+			items = append(items, entity.Notifications{
+				UserId:    &userIds[i],
+				Type:      &notificationType,
+				Title:     &title,
+				Body:      data.Body,
+				TaskId:    data.TaskId,
+				ProjectId: task.ProjectId,
+				DedupeKey: &dedupeKey,
+			})
+		}
+
+		return notifications.Insert(ctx, tx, items)
+	})
+	if err != nil {
+		return entity.TaskComments{}, err
+	}
+
+	return detail, nil
+}
+
+func (r Repository) Delete(ctx context.Context, data basic_repo.Delete) error {
+	return basic_repo.BasicDelete(ctx, data, &entity.TaskComments{}, r.DB)
+}
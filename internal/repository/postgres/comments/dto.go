@@ -0,0 +1,25 @@
+package comments
+
+import "time"
+
+type Filter struct {
+	Limit  *int
+	Offset *int
+	TaskId *int
+}
+
+type Create struct {
+	TaskId *int    `json:"task_id"`
+	UserId *int    `json:"user_id" binding:"required"`
+	Body   *string `json:"body" binding:"required" validate:"omitempty,min=1,max=10000"`
+}
+
+type List struct {
+	Id        int        `json:"id"`
+	TaskId    int        `json:"task_id"`
+	UserId    int        `json:"user_id"`
+	UserName  string     `json:"user_name"`
+	Body      string     `json:"body"`
+	Mentions  []int      `json:"mentions"`
+	CreatedAt *time.Time `json:"created_at"`
+}
@@ -0,0 +1,45 @@
+package notifications
+
+type Filter struct {
+	Limit  *int
+	Offset *int
+	UserId *int
+	Unread bool
+}
+
+type MarkAllRead struct {
+	UserId *int `json:"user_id" binding:"required"`
+}
+
+type Watcher struct {
+	TaskId *int `json:"task_id"`
+	UserId *int `json:"user_id" binding:"required"`
+}
+
+type UpdatePreferences struct {
+	UserId           *int  `json:"user_id"`
+	EmailAssignments *bool `json:"email_assignments"`
+	EmailDigest      *bool `json:"email_digest"`
+	DigestHour       *int  `json:"digest_hour" validate:"omitempty,min=0,max=23"`
+}
+
+// PendingEmail is an assignment notification claimed for emailing.
+type PendingEmail struct {
+	Id               int
+	UserId           int
+	Email            string
+	UserName         string
+	EmailAssignments bool
+	TaskId           int
+	TaskName         string
+	ProjectName      string
+	Status           string
+	Priority         string
+	DueDate          string
+}
+
+type DigestRecipient struct {
+	UserId   int
+	Email    string
+	UserName string
+}
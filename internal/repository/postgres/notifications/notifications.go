@@ -0,0 +1,449 @@
+package notifications
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"task-management2/internal/entity"
+	basic_repo "task-management2/internal/repository/postgres/_basic_repo"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+const (
+	TypeTaskAssigned      = "task_assigned"
+	TypeCommentMention    = "comment_mention"
+	TypeTaskDueSoon       = "task_due_soon"
+	TypeTaskStatusChanged = "task_status_changed"
+)
+
+type Repository struct {
+	*bun.DB
+}
+
+func NewRepository(DB *bun.DB) *Repository {
+	return &Repository{DB: DB}
+}
+
+// Insert stores notifications through db, which is usually the transaction
+// of the write that caused them. Notifications with a dedupe key that the
+// user already got are skipped.
+func Insert(ctx context.Context, db bun.IDB, items []entity.Notifications) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	for i := range items {
+		items[i].CreatedAt = &now
+	}
+
+	_, err := db.NewInsert().
+		Model(&items).
+		On("CONFLICT (user_id, dedupe_key) WHERE dedupe_key IS NOT NULL DO NOTHING").
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("error creating notifications: %w", err)
+	}
+
+	return nil
+}
+
+// WatcherIds returns the users watching a task.
+func WatcherIds(ctx context.Context, db bun.IDB, taskId int) ([]int, error) {
+	var ids []int
+
+	err := db.NewSelect().
+		Table("task_watchers").
+		Column("user_id").
+		Where("task_id = ?", taskId).
+		Order("user_id").
+		Scan(ctx, &ids)
+	if err != nil {
+		return nil, fmt.Errorf("error getting task watchers: %w", err)
+	}
+
+	return ids, nil
+}
+
+func (r Repository) GetAll(ctx context.Context, filter Filter) ([]entity.Notifications, int, error) {
+	var result []entity.Notifications
+
+	query := r.NewSelect().
+		Model(&result).
+		Where("user_id = ? AND deleted_at IS NULL", *filter.UserId).
+		Order("id DESC")
+
+	if filter.Unread {
+		query = query.Where("read_at IS NULL")
+	}
+	if filter.Limit != nil {
+		query = query.Limit(*filter.Limit)
+	}
+	if filter.Offset != nil {
+		query = query.Offset(*filter.Offset)
+	}
+
+	count, err := query.ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error querying notifications: %w", err)
+	}
+
+	return result, count, nil
+}
+
+func (r Repository) UnreadCount(ctx context.Context, userId int) (int, error) {
+	count, err := r.NewSelect().
+		Model((*entity.Notifications)(nil)).
+		Where("user_id = ? AND read_at IS NULL AND deleted_at IS NULL", userId).
+		Count(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error counting notifications: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r Repository) MarkRead(ctx context.Context, id int) (entity.Notifications, error) {
+	var detail entity.Notifications
+
+	_, err := r.NewUpdate().
+		Model(&detail).
+		Set("read_at = COALESCE(read_at, ?)", time.Now()).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		return entity.Notifications{}, err
+	}
+
+	if detail.Id == 0 {
+		return entity.Notifications{}, sql.ErrNoRows
+	}
+
+	return detail, nil
+}
+
+func (r Repository) MarkAllRead(ctx context.Context, data MarkAllRead) (int, error) {
+	result, err := r.NewUpdate().
+		Model((*entity.Notifications)(nil)).
+		Set("read_at = ?", time.Now()).
+		Where("user_id = ? AND read_at IS NULL AND deleted_at IS NULL", *data.UserId).
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+func (r Repository) GetWatchers(ctx context.Context, taskId int) ([]int, error) {
+	return WatcherIds(ctx, r.DB, taskId)
+}
+
+func (r Repository) AddWatcher(ctx context.Context, data Watcher) error {
+	_, err := r.ExecContext(ctx,
+		"INSERT INTO task_watchers (task_id, user_id) VALUES (?, ?) ON CONFLICT DO NOTHING",
+		*data.TaskId, *data.UserId,
+	)
+	if err != nil {
+		return fmt.Errorf("error adding watcher: %w", err)
+	}
+
+	return nil
+}
+
+func (r Repository) RemoveWatcher(ctx context.Context, data Watcher) error {
+	_, err := r.ExecContext(ctx,
+		"DELETE FROM task_watchers WHERE task_id = ? AND user_id = ?",
+		*data.TaskId, *data.UserId,
+	)
+	if err != nil {
+		return fmt.Errorf("error removing watcher: %w", err)
+	}
+
+	return nil
+}
+
+// CreateDueSoon notifies assignees and watchers of open tasks due within
+// the next days. The dedupe key contains the due date, so every user is
+// told once per due date no matter how often this runs or on how many
+// instances.
+func (r Repository) CreateDueSoon(ctx context.Context, days int) (int, error) {
+	query := `
+		INSERT INTO notifications (user_id, type, title, body, task_id, project_id, dedupe_key, created_at)
+		SELECT
+			n.user_id,
+			?,
+			'Task due soon: ' || t.name,
+			'Due on ' || to_char(t.due_date::date, 'YYYY-MM-DD'),
+			t.id,
+			t.project_id,
+			? || ':' || t.id || ':' || to_char(t.due_date::date, 'YYYY-MM-DD'),
+			now()
+		FROM tasks t
+		JOIN (
+			SELECT id as task_id, assigned_to as user_id FROM tasks WHERE assigned_to IS NOT NULL
+			UNION
+			SELECT task_id, user_id FROM task_watchers
+		) n ON n.task_id = t.id
+		WHERE t.deleted_at IS NULL
+		AND COALESCE(t.status, '') <> 'completed'
+		AND t.due_date::date BETWEEN CURRENT_DATE AND CURRENT_DATE + ?::int
+		ON CONFLICT (user_id, dedupe_key) WHERE dedupe_key IS NOT NULL DO NOTHING`
+
+	result, err := r.ExecContext(ctx, query, TypeTaskDueSoon, TypeTaskDueSoon, days)
+	if err != nil {
+		return 0, fmt.Errorf("error creating due soon notifications: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rowsAffected), nil
+}
+
+func defaultPreferences(userId int) entity.NotificationPreferences {
+	enabled := true
+	digestHour := 8
+
+	return entity.NotificationPreferences{
+		UserId:           &userId,
+		EmailAssignments: &enabled,
+		EmailDigest:      &enabled,
+		DigestHour:       &digestHour,
+	}
+}
+
+// GetPreferences returns the user's notification preferences, or the
+// defaults when the user never changed them.
+func (r Repository) GetPreferences(ctx context.Context, userId int) (entity.NotificationPreferences, error) {
+	detail := defaultPreferences(userId)
+
+	err := r.NewSelect().
+		Model(&detail).
+		ColumnExpr("user_id, email_assignments, email_digest, digest_hour, created_at, updated_at").
+		ColumnExpr("to_char(last_digest_on, 'YYYY-MM-DD') as last_digest_on").
+		Where("user_id = ?", userId).
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return defaultPreferences(userId), nil
+	}
+	if err != nil {
+		return entity.NotificationPreferences{}, err
+	}
+
+	return detail, nil
+}
+
+func (r Repository) UpdatePreferences(ctx context.Context, data UpdatePreferences) (entity.NotificationPreferences, error) {
+	detail, err := r.GetPreferences(ctx, *data.UserId)
+	if err != nil {
+		return entity.NotificationPreferences{}, err
+	}
+
+	if data.EmailAssignments != nil {
+		detail.EmailAssignments = data.EmailAssignments
+	}
+	if data.EmailDigest != nil {
+		detail.EmailDigest = data.EmailDigest
+	}
+	if data.DigestHour != nil {
+		if *data.DigestHour < 0 || *data.DigestHour > 23 {
+			return entity.NotificationPreferences{}, basic_repo.InvalidField("digest_hour", "digest_hour must be between 0 and 23")
+		}
+		detail.DigestHour = data.DigestHour
+	}
+
+	now := time.Now()
+	if detail.CreatedAt == nil {
+		detail.CreatedAt = &now
+	}
+	detail.UpdateAt = &now
+
+	_, err = r.NewInsert().
+		Model(&detail).
+		On("CONFLICT (user_id) DO UPDATE").
+		Set("email_assignments = EXCLUDED.email_assignments").
+		Set("email_digest = EXCLUDED.email_digest").
+		Set("digest_hour = EXCLUDED.digest_hour").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
+	if err != nil {
+		return entity.NotificationPreferences{}, err
+	}
+
+	return detail, nil
+}
+
+// ClaimPendingEmails marks up to limit recent assignment notifications as
+// emailed and returns them. Rows locked by another instance are skipped, so
+// every notification is claimed once; ReleaseEmail hands a claim back when
+// sending failed.
+func (r Repository) ClaimPendingEmails(ctx context.Context, limit int) ([]PendingEmail, error) {
+	query := `
+		WITH claimed AS (
+			UPDATE notifications
+			SET emailed_at = now()
+			WHERE id IN (
+				SELECT id
+				FROM notifications
+				WHERE type = ? AND emailed_at IS NULL AND deleted_at IS NULL
+				AND created_at > now() - interval '1 day'
+				ORDER BY id
+				LIMIT ?
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING id, user_id, task_id
+		)
+		SELECT
+			c.id,
+			c.user_id,
+			u.email,
+			COALESCE(u.full_name, ''),
+			COALESCE(np.email_assignments, TRUE),
+			t.id,
+			COALESCE(t.name, ''),
+			COALESCE(p.name, ''),
+			COALESCE(t.status, ''),
+			COALESCE(t.priority, ''),
+			COALESCE(to_char(t.due_date::date, 'YYYY-MM-DD'), '')
+		FROM claimed c
+		JOIN users u ON u.id = c.user_id AND u.deleted_at IS NULL
+		JOIN tasks t ON t.id = c.task_id
+		LEFT JOIN projects p ON p.id = t.project_id
+		LEFT JOIN notification_preferences np ON np.user_id = c.user_id
+		ORDER BY c.id`
+
+	rows, err := r.QueryContext(ctx, query, TypeTaskAssigned, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error claiming pending emails: %w", err)
+	}
+	defer rows.Close()
+
+	var result []PendingEmail
+	for rows.Next() {
+		var item PendingEmail
+
+		err := rows.Scan(
+			&item.Id,
+			&item.UserId,
+			&item.Email,
+			&item.UserName,
+			&item.EmailAssignments,
+			&item.TaskId,
+			&item.TaskName,
+			&item.ProjectName,
+			&item.Status,
+			&item.Priority,
+			&item.DueDate,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning pending email row: %w", err)
+		}
+
+		result = append(result, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pending email rows: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r Repository) ReleaseEmail(ctx context.Context, id int) error {
+	_, err := r.NewUpdate().
+		Model((*entity.Notifications)(nil)).
+		Set("emailed_at = NULL").
+		Where("id = ?", id).
+		Exec(ctx)
+
+	return err
+}
+
+// GetDigestRecipients returns the users that want a digest, whose digest
+// hour has passed and who didn't get one today yet.
+func (r Repository) GetDigestRecipients(ctx context.Context, today string, hour int) ([]DigestRecipient, error) {
+	query := `
+		SELECT u.id, u.email, COALESCE(u.full_name, '')
+		FROM users u
+		LEFT JOIN notification_preferences np ON np.user_id = u.id
+		WHERE u.deleted_at IS NULL
+		AND COALESCE(np.email_digest, TRUE)
+		AND COALESCE(np.digest_hour, 8) <= ?
+		AND (np.last_digest_on IS NULL OR np.last_digest_on < ?::date)
+		ORDER BY u.id`
+
+	rows, err := r.QueryContext(ctx, query, hour, today)
+	if err != nil {
+		return nil, fmt.Errorf("error querying digest recipients: %w", err)
+	}
+	defer rows.Close()
+
+	var result []DigestRecipient
+	for rows.Next() {
+		var item DigestRecipient
+
+		if err := rows.Scan(&item.UserId, &item.Email, &item.UserName); err != nil {
+			return nil, fmt.Errorf("error scanning digest recipient row: %w", err)
+		}
+
+		result = append(result, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating digest recipient rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// ClaimDigest records that the user's digest for today is being sent. It
+// reports false when another instance claimed it first.
+func (r Repository) ClaimDigest(ctx context.Context, userId int, today string) (bool, error) {
+	query := `
+		INSERT INTO notification_preferences (user_id, last_digest_on)
+		VALUES (?, ?::date)
+		ON CONFLICT (user_id) DO UPDATE
+		SET last_digest_on = EXCLUDED.last_digest_on
+		WHERE notification_preferences.last_digest_on IS NULL
+		OR notification_preferences.last_digest_on < EXCLUDED.last_digest_on`
+
+	result, err := r.ExecContext(ctx, query, userId, today)
+	if err != nil {
+		return false, fmt.Errorf("error claiming digest: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
+
+// ReleaseDigest undoes ClaimDigest for a digest that couldn't be sent, so
+// it is sent on a later run.
+func (r Repository) ReleaseDigest(ctx context.Context, userId int, today string) error {
+	query := `
+		UPDATE notification_preferences
+		SET last_digest_on = NULL
+		WHERE user_id = ? AND last_digest_on = ?::date`
+
+	_, err := r.ExecContext(ctx, query, userId, today)
+	if err != nil {
+		return fmt.Errorf("error releasing digest: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package outbox
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Event is a domain event read back from the outbox.
+type Event struct {
+	Id            int64           `json:"id"`
+	AggregateType string          `json:"aggregate_type"`
+	AggregateId   int             `json:"aggregate_id"`
+	Type          string          `json:"type"`
+	Payload       json.RawMessage `json:"payload"`
+	Attempts      int             `json:"attempts"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// ProjectId returns the project an event belongs to: the aggregate itself for
+// project events, the project_id of the payload otherwise.
+func (e Event) ProjectId() *int {
+	if e.AggregateType == AggregateProject {
+		id := e.AggregateId
+		return &id
+	}
+
+	var data struct {
+		ProjectId *int `json:"project_id"`
+	}
+	if err := json.Unmarshal(e.Payload, &data); err != nil {
+		return nil
+	}
+
+	return data.ProjectId
+}
@@ -0,0 +1,237 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"task-management2/internal/entity"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+const (
+	AggregateTask    = "task"
+	AggregateProject = "project"
+	AggregateUser    = "user"
+)
+
+const (
+	EventTaskCreated    = "task.created"
+	EventTaskUpdated    = "task.updated"
+	EventTaskDeleted    = "task.deleted"
+	EventProjectCreated = "project.created"
+	EventProjectUpdated = "project.updated"
+	EventProjectDeleted = "project.deleted"
+	EventUserCreated    = "user.created"
+	EventUserUpdated    = "user.updated"
+	EventUserDeleted    = "user.deleted"
+)
+
+const (
+	// MaxAttempts is how often an event is handed to subscribers before it is
+	// given up on. A dead event no longer holds back its aggregate.
+	MaxAttempts = 10
+	baseBackoff = 5 * time.Second
+	maxBackoff  = 30 * time.Minute
+)
+
+type Repository struct {
+	*bun.DB
+}
+
+func NewRepository(DB *bun.DB) *Repository {
+	return &Repository{DB: DB}
+}
+
+// Append writes an event to the outbox through db, which must be the
+// transaction of the write the event describes: the event is stored if and
+// only if the write commits.
+func Append(ctx context.Context, db bun.IDB, aggregateType string, aggregateId int, eventType string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("error encoding %s event: %v", eventType, err)
+	}
+
+	payloadStr := string(body)
+	attempts := 0
+	now := time.Now()
+
+	event := entity.OutboxEvents{
+		AggregateType: &aggregateType,
+		AggregateId:   &aggregateId,
+		Type:          &eventType,
+		Payload:       &payloadStr,
+		Attempts:      &attempts,
+		NextAttemptAt: &now,
+		CreatedAt:     &now,
+	}
+
+	_, err = db.NewInsert().Model(&event).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("error appending %s event: %v", eventType, err)
+	}
+
+	return nil
+}
+
+// Process hands up to limit pending events to handle and records the
+// outcome, all in one transaction. Only the oldest pending event of each
+// aggregate is eligible and claimed rows are locked, so events of one
+// aggregate are handled one after another, in order, even with several
+// dispatchers running. A failed event is retried with backoff and holds back
+// the later events of its aggregate until it succeeds or is given up on.
+// It returns the number of events claimed, whatever the outcome.
+func (r Repository) Process(ctx context.Context, limit int, handle func(ctx context.Context, event Event) error) (int, error) {
+	claimed := 0
+
+	err := r.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		query := `
+			SELECT e.id, e.aggregate_type, e.aggregate_id, e.type, e.payload::text, e.attempts, e.created_at
+			FROM outbox_events e
+			WHERE e.processed_at IS NULL AND e.dead_at IS NULL AND e.next_attempt_at <= now()
+			AND NOT EXISTS (
+				SELECT 1
+				FROM outbox_events p
+				WHERE p.aggregate_type = e.aggregate_type AND p.aggregate_id = e.aggregate_id
+				AND p.id < e.id AND p.processed_at IS NULL AND p.dead_at IS NULL
+			)
+			ORDER BY e.id
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED`
+
+		rows, err := tx.QueryContext(ctx, query, limit)
+		if err != nil {
+			return fmt.Errorf("error claiming outbox events: %w", err)
+		}
+
+		var events []Event
+		for rows.Next() {
+			var event Event
+			var payload string
+
+			err := rows.Scan(
+				&event.Id,
+				&event.AggregateType,
+				&event.AggregateId,
+				&event.Type,
+				&payload,
+				&event.Attempts,
+				&event.CreatedAt,
+			)
+			if err != nil {
+				rows.Close()
+				return fmt.Errorf("error scanning outbox event row: %w", err)
+			}
+			event.Payload = json.RawMessage(payload)
+
+			events = append(events, event)
+		}
+		rows.Close()
+
+		if err = rows.Err(); err != nil {
+			return fmt.Errorf("error iterating outbox event rows: %w", err)
+		}
+
+		claimed = len(events)
+
+		for _, event := range events {
+			handleErr := handle(ctx, event)
+
+			update := tx.NewUpdate().
+				Model((*entity.OutboxEvents)(nil)).
+				Set("attempts = attempts + 1").
+				Where("id = ?", event.Id)
+
+			now := time.Now()
+			switch {
+			case handleErr == nil:
+				update = update.Set("processed_at = ?", now).Set("last_error = NULL")
+			case event.Attempts+1 >= MaxAttempts:
+				update = update.Set("dead_at = ?", now).Set("last_error = ?", handleErr.Error())
+			default:
+				update = update.
+					Set("next_attempt_at = ?", now.Add(backoff(event.Attempts+1))).
+					Set("last_error = ?", handleErr.Error())
+			}
+
+			if _, err := update.Exec(ctx); err != nil {
+				return fmt.Errorf("error recording outbox event %d: %v", event.Id, err)
+			}
+		}
+
+		return nil
+	})
+
+	return claimed, err
+}
+
+// backoff returns the wait after the given number of failed attempts.
+func backoff(attempts int) time.Duration {
+	wait := baseBackoff
+	for i := 1; i < attempts; i++ {
+		wait *= 2
+		if wait >= maxBackoff {
+			return maxBackoff
+		}
+	}
+
+	return wait
+}
+
+// Since returns up to limit events with an id above afterId, oldest first,
+// whether they were dispatched or not. Streams use it to tail the outbox.
+func (r Repository) Since(ctx context.Context, afterId int64, limit int) ([]Event, error) {
+	query := `
+		SELECT id, aggregate_type, aggregate_id, type, payload::text, attempts, created_at
+		FROM outbox_events
+		WHERE id > ?
+		ORDER BY id
+		LIMIT ?`
+
+	rows, err := r.QueryContext(ctx, query, afterId, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error querying outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	var result []Event
+	for rows.Next() {
+		var event Event
+		var payload string
+
+		err := rows.Scan(
+			&event.Id,
+			&event.AggregateType,
+			&event.AggregateId,
+			&event.Type,
+			&payload,
+			&event.Attempts,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning outbox event row: %w", err)
+		}
+		event.Payload = json.RawMessage(payload)
+
+		result = append(result, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating outbox event rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// LastId returns the id of the newest event, 0 for an empty outbox.
+func (r Repository) LastId(ctx context.Context) (int64, error) {
+	var id int64
+
+	err := r.QueryRowContext(ctx, "SELECT COALESCE(MAX(id), 0) FROM outbox_events").Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("error querying last outbox event: %w", err)
+	}
+
+	return id, nil
+}
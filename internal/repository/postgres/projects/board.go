@@ -0,0 +1,185 @@
+package projects
+
+import (
+	"context"
+	"fmt"
+	basic_repo "task-management2/internal/repository/postgres/_basic_repo"
+
+	"github.com/uptrace/bun"
+)
+
+// BoardStatuses are the board columns, in order.
+var BoardStatuses = []string{"pending", "in_progress", "completed"}
+
+// GetBoard returns the project's tasks grouped by status, each column in
+// rank order.
+func (r Repository) GetBoard(ctx context.Context, projectId int) (Board, error) {
+	board := Board{ProjectId: projectId}
+
+	err := r.DB.QueryRowContext(ctx,
+		"SELECT COALESCE(name, '') FROM projects WHERE id = ? AND deleted_at IS NULL",
+		projectId,
+	).Scan(&board.Name)
+	if err != nil {
+		return Board{}, err
+	}
+
+	limits, err := r.getWipLimits(ctx, projectId)
+	if err != nil {
+		return Board{}, err
+	}
+
+	columns := map[string]int{}
+	for i, status := range BoardStatuses {
+		board.Columns = append(board.Columns, BoardColumn{Status: status, Tasks: []BoardTask{}})
+		columns[status] = i
+	}
+
+	query := `
+		SELECT
+			t.id,
+			COALESCE(t.name, ''),
+			t.assigned_to,
+			COALESCE(t.priority, ''),
+			COALESCE(t.status, ''),
+			to_char(t.due_date::date, 'YYYY-MM-DD'),
+			t.story_points,
+			t.rank
+		FROM tasks t
+		WHERE t.project_id = ? AND t.deleted_at IS NULL
+		ORDER BY t.rank NULLS LAST, t.id`
+
+	rows, err := r.DB.QueryContext(ctx, query, projectId)
+	if err != nil {
+		return Board{}, fmt.Errorf("error querying board tasks: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var task BoardTask
+		var status string
+
+		err := rows.Scan(
+			&task.Id,
+			&task.Name,
+			&task.AssignedTo,
+			&task.Priority,
+			&status,
+			&task.DueDate,
+			&task.StoryPoints,
+			&task.Rank,
+		)
+		if err != nil {
+			return Board{}, fmt.Errorf("error scanning board task row: %w", err)
+		}
+
+		i, ok := columns[status]
+		if !ok {
+			// a status outside the board's, shown after the known columns
+			board.Columns = append(board.Columns, BoardColumn{Status: status, Tasks: []BoardTask{}})
+			i = len(board.Columns) - 1
+			columns[status] = i
+		}
+
+		board.Columns[i].Tasks = append(board.Columns[i].Tasks, task)
+		board.Columns[i].Count++
+	}
+
+	if err = rows.Err(); err != nil {
+		return Board{}, fmt.Errorf("error iterating board task rows: %w", err)
+	}
+
+	for i := range board.Columns {
+		if limit, ok := limits[board.Columns[i].Status]; ok {
+			limit := limit
+			board.Columns[i].WipLimit = &limit
+		}
+	}
+
+	return board, nil
+}
+
+func (r Repository) getWipLimits(ctx context.Context, projectId int) (map[string]int, error) {
+	rows, err := r.DB.QueryContext(ctx,
+		"SELECT status, wip_limit FROM board_wip_limits WHERE project_id = ?",
+		projectId,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error querying WIP limits: %w", err)
+	}
+	defer rows.Close()
+
+	result := map[string]int{}
+	for rows.Next() {
+		var status string
+		var limit int
+
+		if err := rows.Scan(&status, &limit); err != nil {
+			return nil, fmt.Errorf("error scanning WIP limit row: %w", err)
+		}
+		result[status] = limit
+	}
+
+	return result, rows.Err()
+}
+
+// SetWipLimits sets the WIP limits of the given columns, other columns keep
+// theirs. Limits only apply to tasks moved into a column later on.
+func (r Repository) SetWipLimits(ctx context.Context, data WipLimits) (map[string]int, error) {
+	for status, limit := range data.Limits {
+		if !validStatus(status) {
+			return nil, basic_repo.InvalidField(status, "unknown status %q", status)
+		}
+		if limit < 0 {
+			return nil, basic_repo.InvalidField(status, "wip limit of %s must not be negative", status)
+		}
+	}
+
+	err := r.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var id int
+		err := tx.QueryRowContext(ctx,
+			"SELECT id FROM projects WHERE id = ? AND deleted_at IS NULL FOR UPDATE",
+			*data.ProjectId,
+		).Scan(&id)
+		if err != nil {
+			return err
+		}
+
+		for status, limit := range data.Limits {
+			if limit == 0 {
+				_, err = tx.ExecContext(ctx,
+					"DELETE FROM board_wip_limits WHERE project_id = ? AND status = ?",
+					*data.ProjectId, status,
+				)
+			} else {
+				_, err = tx.ExecContext(ctx, `
+					INSERT INTO board_wip_limits (project_id, status, wip_limit)
+					VALUES (?, ?, ?)
+					ON CONFLICT (project_id, status) DO UPDATE
+					SET wip_limit = EXCLUDED.wip_limit, updated_at = now()`,
+					*data.ProjectId, status, limit,
+				)
+			}
+			if err != nil {
+				return fmt.Errorf("error saving WIP limit: %w", err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.getWipLimits(ctx, *data.ProjectId)
+}
+
+func validStatus(status string) bool {
+	for _, s := range BoardStatuses {
+		if s == status {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,27 +1,51 @@
 package projects
 
+import (
+	"task-management2/internal/entity"
+	basic_repo "task-management2/internal/repository/postgres/_basic_repo"
+	"time"
+)
+
 type Filter struct {
-	Limit   *int
-	Offset  *int
-	OwnerId *int
+	Limit     *int
+	Offset    *int
+	OwnerId   *int
+	Weighting *string
+	Sparse    basic_repo.Sparse
+}
+
+type DetailFilter struct {
+	Id        int
+	Weighting *string
+	Sparse    basic_repo.Sparse
 }
 
 type Create struct {
-	Name        *string `json:"name" bun:"name"`
-	Description *string `json:"description" bun:"description"`
-	Owner_id    *int    `json:"owner_id" bun:"owner_id"`
+	Name        *string `json:"name" bun:"name" validate:"required,min=1,max=255"`
+	Description *string `json:"description" bun:"description" validate:"omitempty,max=10000"`
+	Owner_id    *int    `json:"owner_id" bun:"owner_id" validate:"required,min=1"`
 }
 
 type Update struct {
 	Id          *int    `json:"id" form:"id"`
-	Name        *string `json:"name" bun:"name"`
-	Description *string `json:"description" bun:"description"`
-	Owner_id    *int    `json:"owner_id" bun:"owner_id"`
+	Name        *string `json:"name" bun:"name" validate:"omitempty,min=1,max=255"`
+	Description *string `json:"description" bun:"description" validate:"omitempty,max=10000"`
+	Owner_id    *int    `json:"owner_id" bun:"owner_id" validate:"omitempty,min=1"`
+	// Version, when set, must be the project's current version.
+	Version *int `json:"-"`
 }
 
 type TaskStats struct {
-	TotalTasks int     `json:"total_tasks"`
-	Progress   float64 `json:"progress"`
+	TotalTasks        int     `json:"total_tasks"`
+	CompletedTasks    int     `json:"completed_tasks"`
+	InProgressTasks   int     `json:"in_progress_tasks"`
+	PendingTasks      int     `json:"pending_tasks"`
+	TotalPoints       int     `json:"total_points"`
+	CompletedPoints   int     `json:"completed_points"`
+	OriginalEstimate  int     `json:"original_estimate"`
+	RemainingEstimate int     `json:"remaining_estimate"`
+	Weighting         string  `json:"weighting"`
+	Progress          float64 `json:"progress"`
 }
 
 type List struct {
@@ -31,6 +55,9 @@ type List struct {
 	OwnerId     int     `json:"owner_id"`
 	TotalTasks  int     `json:"total_tasks"`
 	Progress    float64 `json:"progress"`
+
+	// embedded on request only
+	Owner *entity.UserRef `json:"owner,omitempty"`
 }
 
 type Detail struct {
@@ -38,5 +65,134 @@ type Detail struct {
 	Name        string    `json:"name"`
 	Description string    `json:"description"`
 	Owner_id    int       `json:"owner_id"`
+	Version     int       `json:"version"`
 	TaskStats   TaskStats `json:"task_stats"`
+
+	// embedded on request only
+	Owner *entity.UserRef `json:"owner,omitempty"`
+}
+
+type TimelineFilter struct {
+	ProjectId int
+	Location  *time.Location
+}
+
+type TimelineItem struct {
+	Id         int    `json:"id"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Priority   string `json:"priority"`
+	AssignedTo *int   `json:"assigned_to"`
+	Start      string `json:"start"`
+	End        string `json:"end"`
+	Duration   int    `json:"duration_days"`
+}
+
+type Timeline struct {
+	ProjectId int            `json:"project_id"`
+	Name      string         `json:"name"`
+	TimeZone  string         `json:"time_zone"`
+	Start     *string        `json:"start"`
+	End       *string        `json:"end"`
+	Items     []TimelineItem `json:"items"`
+}
+
+// Access lists the projects a user may see.
+type Access struct {
+	All        bool
+	ProjectIds map[int]bool
+}
+
+func (a Access) CanView(projectId *int) bool {
+	if a.All {
+		return true
+	}
+
+	return projectId != nil && a.ProjectIds[*projectId]
+}
+
+type BoardTask struct {
+	Id          int     `json:"id"`
+	Name        string  `json:"name"`
+	AssignedTo  *int    `json:"assigned_to"`
+	Priority    string  `json:"priority"`
+	DueDate     *string `json:"due_date"`
+	StoryPoints *int    `json:"story_points"`
+	Rank        *string `json:"rank"`
+}
+
+type BoardColumn struct {
+	Status   string      `json:"status"`
+	WipLimit *int        `json:"wip_limit"`
+	Count    int         `json:"count"`
+	Tasks    []BoardTask `json:"tasks"`
+}
+
+type Board struct {
+	ProjectId int           `json:"project_id"`
+	Name      string        `json:"name"`
+	Columns   []BoardColumn `json:"columns"`
+}
+
+// WipLimits maps a status to its WIP limit. A limit of 0 removes it.
+type WipLimits struct {
+	ProjectId *int           `json:"project_id"`
+	Limits    map[string]int `json:"limits" binding:"required"`
+}
+
+// Milestone health, see MilestoneFilter.AtRiskDays.
+const (
+	MilestoneOnTrack   = "on_track"
+	MilestoneAtRisk    = "at_risk"
+	MilestoneOverdue   = "overdue"
+	MilestoneCompleted = "completed"
+)
+
+type MilestoneFilter struct {
+	ProjectId int
+	Weighting *string
+	Location  *time.Location
+	// A milestone with open tasks is at risk from this many days before its
+	// target date on.
+	AtRiskDays int
+}
+
+type MilestoneCreate struct {
+	ProjectId   *int    `json:"project_id"`
+	Name        *string `json:"name" binding:"required" validate:"omitempty,min=1,max=255"`
+	Description *string `json:"description"`
+	TargetDate  *string `json:"target_date" binding:"required" validate:"omitempty,date"`
+}
+
+type MilestoneUpdate struct {
+	Id          *int    `json:"id"`
+	ProjectId   *int    `json:"project_id"`
+	Name        *string `json:"name" validate:"omitempty,min=1,max=255"`
+	Description *string `json:"description"`
+	TargetDate  *string `json:"target_date" validate:"omitempty,date"`
+}
+
+type MilestoneTasks struct {
+	ProjectId   *int  `json:"project_id"`
+	MilestoneId *int  `json:"milestone_id"`
+	TaskIds     []int `json:"task_ids" binding:"required"`
+}
+
+type Milestone struct {
+	Id          int       `json:"id"`
+	ProjectId   int       `json:"project_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	TargetDate  string    `json:"target_date"`
+	DaysLeft    int       `json:"days_left"`
+	OpenTasks   int       `json:"open_tasks"`
+	Health      string    `json:"health"`
+	TaskStats   TaskStats `json:"task_stats"`
+}
+
+// Patchable are the fields of a project a merge patch may change.
+type Patchable struct {
+	Name        *string `json:"name" validate:"required,min=1,max=255"`
+	Description *string `json:"description" validate:"omitempty,max=10000"`
+	OwnerId     *int    `json:"owner_id" validate:"required,min=1"`
 }
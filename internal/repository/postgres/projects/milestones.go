@@ -0,0 +1,328 @@
+package projects
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"task-management2/internal/entity"
+	basic_repo "task-management2/internal/repository/postgres/_basic_repo"
+	"task-management2/internal/repository/postgres/outbox"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// DefaultAtRiskDays is used when MilestoneFilter.AtRiskDays is not set.
+const DefaultAtRiskDays = 7
+
+var ErrTaskNotInProject = basic_repo.InvalidField("task_ids", "tasks must belong to the milestone's project")
+
+// buildMilestonesQuery selects the milestones of a project with the same
+// task stats and progress as a project's, over the tasks linked to each.
+func (r Repository) buildMilestonesQuery(weighting, whereClause string) string {
+	return fmt.Sprintf(`
+		WITH task_stats AS (
+			SELECT 
+				milestone_id,
+				%s
+			FROM tasks
+			WHERE deleted_at IS NULL AND project_id = ? AND milestone_id IS NOT NULL
+			GROUP BY milestone_id
+		)
+		SELECT 
+			m.id,
+			m.project_id,
+			COALESCE(m.name, ''),
+			COALESCE(m.description, ''),
+			to_char(m.target_date, 'YYYY-MM-DD'),
+			m.target_date - ?::date as days_left,
+			COALESCE(ts.total_tasks, 0) as total_tasks,
+			COALESCE(ts.completed_tasks, 0) as completed_tasks,
+			COALESCE(ts.in_progress_tasks, 0) as in_progress_tasks,
+			COALESCE(ts.pending_tasks, 0) as pending_tasks,
+			COALESCE(ts.total_points, 0) as total_points,
+			COALESCE(ts.completed_points, 0) as completed_points,
+			COALESCE(ts.original_estimate, 0) as original_estimate,
+			COALESCE(ts.remaining_estimate, 0) as remaining_estimate,
+			%s as progress
+		FROM milestones m
+		LEFT JOIN task_stats ts ON ts.milestone_id = m.id
+		WHERE m.project_id = ? AND m.deleted_at IS NULL
+		%s
+		ORDER BY m.target_date, m.id
+	`, basic_repo.TaskStatsColumns, basic_repo.ProgressExpr(weighting, "ts"), whereClause)
+}
+
+// GetMilestones returns the project's milestones by target date.
+func (r Repository) GetMilestones(ctx context.Context, filter MilestoneFilter) ([]Milestone, error) {
+	var id int
+	err := r.DB.QueryRowContext(ctx,
+		"SELECT id FROM projects WHERE id = ? AND deleted_at IS NULL",
+		filter.ProjectId,
+	).Scan(&id)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.queryMilestones(ctx, filter, "")
+}
+
+func (r Repository) GetMilestone(ctx context.Context, filter MilestoneFilter, id int) (Milestone, error) {
+	result, err := r.queryMilestones(ctx, filter, "AND m.id = ?", id)
+	if err != nil {
+		return Milestone{}, err
+	}
+	if len(result) == 0 {
+		return Milestone{}, sql.ErrNoRows
+	}
+
+	return result[0], nil
+}
+
+func (r Repository) queryMilestones(ctx context.Context, filter MilestoneFilter, whereClause string, args ...interface{}) ([]Milestone, error) {
+	w := weighting(filter.Weighting)
+
+	loc := filter.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	today := time.Now().In(loc).Format("2006-01-02")
+
+	atRiskDays := filter.AtRiskDays
+	if atRiskDays <= 0 {
+		atRiskDays = DefaultAtRiskDays
+	}
+
+	params := append([]interface{}{filter.ProjectId, today, filter.ProjectId}, args...)
+
+	rows, err := r.DB.QueryContext(ctx, r.buildMilestonesQuery(w, whereClause), params...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying milestones: %w", err)
+	}
+	defer rows.Close()
+
+	result := []Milestone{}
+	for rows.Next() {
+		item := Milestone{TaskStats: TaskStats{Weighting: w}}
+		stats := &item.TaskStats
+
+		err = rows.Scan(
+			&item.Id,
+			&item.ProjectId,
+			&item.Name,
+			&item.Description,
+			&item.TargetDate,
+			&item.DaysLeft,
+			&stats.TotalTasks,
+			&stats.CompletedTasks,
+			&stats.InProgressTasks,
+			&stats.PendingTasks,
+			&stats.TotalPoints,
+			&stats.CompletedPoints,
+			&stats.OriginalEstimate,
+			&stats.RemainingEstimate,
+			&stats.Progress,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning milestone row: %w", err)
+		}
+
+		item.OpenTasks = stats.TotalTasks - stats.CompletedTasks
+		item.Health = milestoneHealth(item, atRiskDays)
+
+		result = append(result, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating milestone rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// milestoneHealth tells whether open work remains close to, or past, the
+// target date. A milestone without tasks has no work left and is on track.
+func milestoneHealth(m Milestone, atRiskDays int) string {
+	switch {
+	case m.TaskStats.TotalTasks > 0 && m.OpenTasks == 0:
+		return MilestoneCompleted
+	case m.OpenTasks > 0 && m.DaysLeft < 0:
+		return MilestoneOverdue
+	case m.OpenTasks > 0 && m.DaysLeft <= atRiskDays:
+		return MilestoneAtRisk
+	default:
+		return MilestoneOnTrack
+	}
+}
+
+func (r Repository) CreateMilestone(ctx context.Context, data MilestoneCreate) (entity.Milestones, error) {
+	if _, err := time.Parse("2006-01-02", *data.TargetDate); err != nil {
+		return entity.Milestones{}, basic_repo.InvalidField("target_date", "invalid target_date format: %v", err)
+	}
+
+	var id int
+	err := r.DB.QueryRowContext(ctx,
+		"SELECT id FROM projects WHERE id = ? AND deleted_at IS NULL",
+		*data.ProjectId,
+	).Scan(&id)
+	if err != nil {
+		return entity.Milestones{}, err
+	}
+
+	now := time.Now()
+	detail := entity.Milestones{
+		ProjectId:   data.ProjectId,
+		Name:        data.Name,
+		Description: data.Description,
+		TargetDate:  data.TargetDate,
+	}
+	detail.CreatedAt = &now
+
+	_, err = r.NewInsert().Model(&detail).Exec(ctx)
+	if err != nil {
+		return entity.Milestones{}, fmt.Errorf("error creating milestone: %w", err)
+	}
+
+	return detail, nil
+}
+
+func (r Repository) UpdateMilestone(ctx context.Context, data MilestoneUpdate) (entity.Milestones, error) {
+	if data.TargetDate != nil {
+		if _, err := time.Parse("2006-01-02", *data.TargetDate); err != nil {
+			return entity.Milestones{}, basic_repo.InvalidField("target_date", "invalid target_date format: %v", err)
+		}
+	}
+
+	var detail entity.Milestones
+
+	err := r.NewSelect().
+		Model(&detail).
+		ColumnExpr("id, project_id, name, description, to_char(target_date, 'YYYY-MM-DD') as target_date, created_at, updated_at, deleted_at").
+		Where("id = ? AND project_id = ? AND deleted_at IS NULL", *data.Id, *data.ProjectId).
+		Scan(ctx)
+	if err != nil {
+		return entity.Milestones{}, err
+	}
+
+	if data.Name != nil {
+		detail.Name = data.Name
+	}
+	if data.Description != nil {
+		detail.Description = data.Description
+	}
+	if data.TargetDate != nil {
+		detail.TargetDate = data.TargetDate
+	}
+
+	now := time.Now()
+	detail.UpdateAt = &now
+
+	_, err = r.NewUpdate().Model(&detail).WherePK().Exec(ctx)
+	if err != nil {
+		return entity.Milestones{}, fmt.Errorf("error updating milestone: %w", err)
+	}
+
+	return detail, nil
+}
+
+// DeleteMilestone removes a milestone and unlinks its tasks.
+func (r Repository) DeleteMilestone(ctx context.Context, projectId, id int) error {
+	return r.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		res, err := tx.ExecContext(ctx,
+			"UPDATE milestones SET deleted_at = ? WHERE id = ? AND project_id = ? AND deleted_at IS NULL",
+			time.Now(), id, projectId,
+		)
+		if err != nil {
+			return err
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			return sql.ErrNoRows
+		}
+
+		_, err = linkTasks(ctx, tx, nil, "milestone_id = ?", id)
+		return err
+	})
+}
+
+// AddMilestoneTasks links tasks of the project to the milestone, replacing
+// any milestone they were linked to.
+func (r Repository) AddMilestoneTasks(ctx context.Context, data MilestoneTasks) ([]entity.Tasks, error) {
+	var result []entity.Tasks
+
+	if len(data.TaskIds) == 0 {
+		return nil, basic_repo.InvalidField("task_ids", "task_ids must not be empty")
+	}
+
+	err := r.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var id int
+		err := tx.QueryRowContext(ctx,
+			"SELECT id FROM milestones WHERE id = ? AND project_id = ? AND deleted_at IS NULL FOR UPDATE",
+			*data.MilestoneId, *data.ProjectId,
+		).Scan(&id)
+		if err != nil {
+			return err
+		}
+
+		result, err = linkTasks(ctx, tx, data.MilestoneId, "id IN (?) AND project_id = ?", bun.In(data.TaskIds), *data.ProjectId)
+		if err != nil {
+			return err
+		}
+
+		ids := map[int]bool{}
+		for _, taskId := range data.TaskIds {
+			ids[taskId] = true
+		}
+		if len(result) != len(ids) {
+			return ErrTaskNotInProject
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r Repository) RemoveMilestoneTask(ctx context.Context, projectId, milestoneId, taskId int) error {
+	return r.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		tasks, err := linkTasks(ctx, tx, nil, "id = ? AND project_id = ? AND milestone_id = ?", taskId, projectId, milestoneId)
+		if err != nil {
+			return err
+		}
+		if len(tasks) == 0 {
+			return sql.ErrNoRows
+		}
+
+		return nil
+	})
+}
+
+// linkTasks sets the milestone of the tasks matching where (none for nil)
+// and records a task.updated event for each.
+func linkTasks(ctx context.Context, tx bun.Tx, milestoneId *int, where string, args ...interface{}) ([]entity.Tasks, error) {
+	var tasks []entity.Tasks
+
+	_, err := tx.NewUpdate().
+		Model(&tasks).
+		Set("milestone_id = ?", milestoneId).
+		Set("updated_at = ?", time.Now()).
+		Set("version = version + 1").
+		Where("deleted_at IS NULL").
+		Where(where, args...).
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error linking tasks: %w", err)
+	}
+
+	for _, task := range tasks {
+		err = outbox.Append(ctx, tx, outbox.AggregateTask, task.Id, outbox.EventTaskUpdated, task)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tasks, nil
+}
@@ -0,0 +1,69 @@
+package projects
+
+import (
+	"context"
+	"task-management2/internal/entity"
+	"task-management2/internal/pkg/validation"
+	basic_repo "task-management2/internal/repository/postgres/_basic_repo"
+	"task-management2/internal/repository/postgres/outbox"
+	"task-management2/internal/util/mergepatch"
+
+	"github.com/uptrace/bun"
+)
+
+// Patch applies a JSON merge patch to a project. A null description clears
+// it, name and owner_id can't be cleared.
+func (r Repository) Patch(ctx context.Context, data basic_repo.MergePatch) (entity.Projects, error) {
+	var project entity.Projects
+
+	err := r.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		err := tx.NewSelect().Model(&project).Where("id = ? AND deleted_at IS NULL", *data.Id).For("UPDATE").Scan(ctx)
+		if err != nil {
+			return err
+		}
+
+		if data.Version != nil && project.Version != *data.Version {
+			return basic_repo.ErrVersionMismatch
+		}
+
+		fields := Patchable{
+			Name:        project.Name,
+			Description: project.Description,
+			OwnerId:     project.OwnerId,
+		}
+		if err := mergepatch.ApplyTo(&fields, data.Patch); err != nil {
+			return basic_repo.Invalid("invalid patch: %v", err)
+		}
+
+		if err := validation.Struct(fields); err != nil {
+			return err
+		}
+
+		if mergepatch.Has(data.Patch, "owner_id") {
+			if err := checkOwner(ctx, tx, *fields.OwnerId); err != nil {
+				return err
+			}
+		}
+
+		project.Name = fields.Name
+		project.Description = fields.Description
+		project.OwnerId = fields.OwnerId
+		project.Version++
+
+		_, err = tx.NewUpdate().
+			Model(&project).
+			Column("name", "description", "owner_id", "version").
+			Where("id = ?", project.Id).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		return outbox.Append(ctx, tx, outbox.AggregateProject, project.Id, outbox.EventProjectUpdated, project)
+	})
+	if err != nil {
+		return entity.Projects{}, err
+	}
+
+	return project, nil
+}
@@ -3,9 +3,11 @@ package projects
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"task-management2/internal/entity"
 	basic_repo "task-management2/internal/repository/postgres/_basic_repo"
+	"task-management2/internal/repository/postgres/outbox"
 	"time"
 
 	"github.com/uptrace/bun"
@@ -20,54 +22,74 @@ func (r Repository) QueryRowContext(ctx context.Context, query string, args ...i
 }
 
 func (r Repository) buildTaskStatsQuery() string {
-	return `
+	return fmt.Sprintf(`
 		WITH task_stats AS (
 			SELECT 
 				project_id,
-				COUNT(*) as total_tasks,
-				COUNT(CASE WHEN status = 'completed' THEN 1 END) as completed_tasks,
-				COUNT(CASE WHEN status = 'in_progress' THEN 1 END) as in_progress_tasks
+				%s
 			FROM tasks
 			WHERE deleted_at IS NULL
 			GROUP BY project_id
 		)
-	`
+	`, basic_repo.TaskStatsColumns)
 }
 
-func (r Repository) buildProjectsBaseQuery(whereClause string) string {
+func (r Repository) buildProjectsBaseQuery(whereClause, weighting string) string {
 	return fmt.Sprintf(`
 		SELECT 
 			p.*,
 			COALESCE(ts.total_tasks, 0) as total_tasks,
-			COALESCE(
-				CASE 
-					WHEN ts.total_tasks > 0 THEN
-						(
-							(COALESCE(ts.completed_tasks, 0)::numeric * 100 + 
-							COALESCE(ts.in_progress_tasks, 0)::numeric * 50) / 
-							(ts.total_tasks::numeric * 100) * 100
-						)::numeric(10,1)
-					ELSE 0
-				END
-			, 0) as progress
+			%s as progress
 		FROM projects p
 		LEFT JOIN task_stats ts ON p.id = ts.project_id
 		WHERE p.deleted_at IS NULL
 		%s
-	`, whereClause)
+	`, basic_repo.ProgressExpr(weighting, "ts"), whereClause)
 }
 
-func (r Repository) buildFinalSelectQuery() string {
-	return `
+func (r Repository) buildFinalSelectQuery(selection basic_repo.Selection[List]) string {
+	return fmt.Sprintf(`
 		SELECT 
-			id,
-			name,
-			description,
-			owner_id,
-			total_tasks,
-			progress
-		FROM projects_with_stats
-	`
+			%s
+		FROM projects_with_stats ps
+		%s
+		ORDER BY ps.id
+	`, selection.Columns(), selection.Joins())
+}
+
+// listColumns are the fields of a project of a list a read may select,
+// listRelations the records it may embed.
+var (
+	listColumns = []basic_repo.Column[List]{
+		{Name: "id", Expr: "ps.id", Dest: func(p *List) []any { return []any{&p.Id} }, Key: true},
+		{Name: "name", Expr: "COALESCE(ps.name, '')", Dest: func(p *List) []any { return []any{&p.Name} }},
+		{Name: "description", Expr: "COALESCE(ps.description, '')", Dest: func(p *List) []any { return []any{&p.Description} }},
+		{Name: "owner_id", Expr: "COALESCE(ps.owner_id, 0)", Dest: func(p *List) []any { return []any{&p.OwnerId} }},
+		{Name: "total_tasks", Expr: "ps.total_tasks", Dest: func(p *List) []any { return []any{&p.TotalTasks} }},
+		{Name: "progress", Expr: "ps.progress", Dest: func(p *List) []any { return []any{&p.Progress} }},
+	}
+
+	listRelations = []basic_repo.Column[List]{
+		{
+			Name: "owner",
+			Expr: ownerExpr,
+			Join: "LEFT JOIN users o ON o.id = ps.owner_id",
+			Dest: func(p *List) []any { return []any{basic_repo.JSON(&p.Owner)} },
+		},
+	}
+)
+
+const ownerExpr = `CASE WHEN o.id IS NULL THEN NULL ELSE json_build_object(
+	'id', o.id, 'full_name', o.full_name, 'email', o.email, 'role', o.role) END`
+
+// weighting returns the requested progress weighting, count by default.
+// Unknown values are rejected by the controller before they get here.
+func weighting(w *string) string {
+	if w == nil || !basic_repo.ValidWeighting(*w) {
+		return basic_repo.WeightingCount
+	}
+
+	return *w
 }
 
 func (r Repository) buildWhereAndParams(filter Filter) (string, []interface{}) {
@@ -98,7 +120,7 @@ func (r Repository) buildLimitOffset(filter Filter, params []interface{}) (strin
 	return limitOffsetClause, params
 }
 
-func (r Repository) buildProjectsQuery(filter Filter) (string, []interface{}) {
+func (r Repository) buildProjectsQuery(filter Filter, selection basic_repo.Selection[List]) (string, []interface{}) {
 	whereClause, params := r.buildWhereAndParams(filter)
 	limitOffsetClause, params := r.buildLimitOffset(filter, params)
 
@@ -111,8 +133,8 @@ func (r Repository) buildProjectsQuery(filter Filter) (string, []interface{}) {
 		%s
 	`,
 		r.buildTaskStatsQuery(),
-		r.buildProjectsBaseQuery(whereClause),
-		r.buildFinalSelectQuery(),
+		r.buildProjectsBaseQuery(whereClause, weighting(filter.Weighting)),
+		r.buildFinalSelectQuery(selection),
 		limitOffsetClause,
 	)
 
@@ -122,7 +144,12 @@ func (r Repository) buildProjectsQuery(filter Filter) (string, []interface{}) {
 func (r Repository) GetProjectsWithStats(ctx context.Context, filter Filter) ([]List, error) {
 	var result []List
 
-	query, params := r.buildProjectsQuery(filter)
+	selection, err := basic_repo.Select(listColumns, listRelations, filter.Sparse)
+	if err != nil {
+		return nil, err
+	}
+
+	query, params := r.buildProjectsQuery(filter, selection)
 	rows, err := r.DB.QueryContext(ctx, query, params...)
 	if err != nil {
 		return nil, err
@@ -131,35 +158,10 @@ func (r Repository) GetProjectsWithStats(ctx context.Context, filter Filter) ([]
 
 	for rows.Next() {
 		var item List
-		var name, description *string
-		var ownerId *int
-		var totalTasks int
-		var progress float64
-
-		err = rows.Scan(
-			&item.Id,
-			&name,
-			&description,
-			&ownerId,
-			&totalTasks,
-			&progress,
-		)
-		if err != nil {
+		if err = selection.Scan(rows, &item); err != nil {
 			return nil, err
 		}
 
-		if name != nil {
-			item.Name = *name
-		}
-		if description != nil {
-			item.Description = *description
-		}
-		if ownerId != nil {
-			item.OwnerId = *ownerId
-		}
-		item.TotalTasks = totalTasks
-		item.Progress = progress
-
 		result = append(result, item)
 	}
 
@@ -191,96 +193,209 @@ func (r Repository) GetProjectsCount(ctx context.Context, filter Filter) (int, e
 }
 
 func (r Repository) buildTaskStatsForFindOneQuery() string {
-	return `
+	return fmt.Sprintf(`
 		WITH task_stats AS (
 			SELECT 
 				project_id,
-				COUNT(*) as total_tasks,
-				COUNT(CASE WHEN status = 'completed' THEN 1 END) as completed_tasks,
-				COUNT(CASE WHEN status = 'in_progress' THEN 1 END) as in_progress_tasks
+				%s
 			FROM tasks
-			WHERE deleted_at IS NULL
+			WHERE deleted_at IS NULL AND project_id = ?
 			GROUP BY project_id
 		)
-	`
+	`, basic_repo.TaskStatsColumns)
 }
 
-func (r Repository) buildProjectWithStatsFindOneQuery() string {
-	return `
+func (r Repository) buildProjectWithStatsFindOneQuery(selection basic_repo.Selection[Detail]) string {
+	return fmt.Sprintf(`
 		SELECT 
-			p.id,
-			p.name,
-			p.description,
-			p.owner_id,
-			COALESCE(ts.total_tasks, 0) as total_tasks,
-			COALESCE(
-				CASE 
-					WHEN ts.total_tasks > 0 THEN
-						(
-							(COALESCE(ts.completed_tasks, 0)::numeric * 100 + 
-							COALESCE(ts.in_progress_tasks, 0)::numeric * 50) / 
-							(ts.total_tasks::numeric * 100) * 100
-						)::numeric(10,1)
-					ELSE 0
-				END
-			, 0) as progress
+			%s
 		FROM projects p
-		LEFT JOIN task_stats ts ON p.id = ts.project_id
+		%s
 		WHERE p.id = ? AND p.deleted_at IS NULL
-	`
+	`, selection.Columns(), selection.Joins())
 }
 
-func (r Repository) buildFindOneQuery() string {
+func (r Repository) buildFindOneQuery(selection basic_repo.Selection[Detail]) string {
 	return fmt.Sprintf(`
 		%s
 		%s
 	`,
 		r.buildTaskStatsForFindOneQuery(),
-		r.buildProjectWithStatsFindOneQuery(),
+		r.buildProjectWithStatsFindOneQuery(selection),
 	)
 }
 
-func (r Repository) scanProjectDetailForFindOne(row *sql.Row) (Detail, error) {
-	var detail Detail
-	var name, description *string
-	var ownerId *int
-	var totalTasks int
-	var progress float64
-
-	err := row.Scan(
-		&detail.Id,
-		&name,
-		&description,
-		&ownerId,
-		&totalTasks,
-		&progress,
-	)
+// detailColumns are the fields of a project a read may select, in the given
+// progress weighting, detailRelations the records it may embed.
+func detailColumns(weighting string) []basic_repo.Column[Detail] {
+	return []basic_repo.Column[Detail]{
+		{Name: "id", Expr: "p.id", Dest: func(p *Detail) []any { return []any{&p.Id} }, Key: true},
+		{Name: "name", Expr: "COALESCE(p.name, '')", Dest: func(p *Detail) []any { return []any{&p.Name} }},
+		{Name: "description", Expr: "COALESCE(p.description, '')", Dest: func(p *Detail) []any { return []any{&p.Description} }},
+		{Name: "owner_id", Expr: "COALESCE(p.owner_id, 0)", Dest: func(p *Detail) []any { return []any{&p.Owner_id} }},
+		{Name: "version", Expr: "p.version", Dest: func(p *Detail) []any { return []any{&p.Version} }, Key: true},
+		{
+			Name: "task_stats",
+			Expr: fmt.Sprintf(`
+				COALESCE(ts.total_tasks, 0),
+				COALESCE(ts.completed_tasks, 0),
+				COALESCE(ts.in_progress_tasks, 0),
+				COALESCE(ts.pending_tasks, 0),
+				COALESCE(ts.total_points, 0),
+				COALESCE(ts.completed_points, 0),
+				COALESCE(ts.original_estimate, 0),
+				COALESCE(ts.remaining_estimate, 0),
+				%s`, basic_repo.ProgressExpr(weighting, "ts")),
+			Join: "LEFT JOIN task_stats ts ON p.id = ts.project_id",
+			Dest: func(p *Detail) []any {
+				stats := &p.TaskStats
+				stats.Weighting = weighting
+
+				return []any{
+					&stats.TotalTasks,
+					&stats.CompletedTasks,
+					&stats.InProgressTasks,
+					&stats.PendingTasks,
+					&stats.TotalPoints,
+					&stats.CompletedPoints,
+					&stats.OriginalEstimate,
+					&stats.RemainingEstimate,
+					&stats.Progress,
+				}
+			},
+		},
+	}
+}
+
+var detailRelations = []basic_repo.Column[Detail]{
+	{
+		Name: "owner",
+		Expr: ownerExpr,
+		Join: "LEFT JOIN users o ON o.id = p.owner_id",
+		Dest: func(p *Detail) []any { return []any{basic_repo.JSON(&p.Owner)} },
+	},
+}
+
+func (r Repository) GetById(ctx context.Context, id int) (Detail, error) {
+	return r.GetDetail(ctx, DetailFilter{Id: id})
+}
+
+func (r Repository) GetDetail(ctx context.Context, filter DetailFilter) (Detail, error) {
+	selection, err := basic_repo.Select(detailColumns(weighting(filter.Weighting)), detailRelations, filter.Sparse)
 	if err != nil {
 		return Detail{}, err
 	}
 
-	if name != nil {
-		detail.Name = *name
+	var detail Detail
+	row := r.QueryRowContext(ctx, r.buildFindOneQuery(selection), filter.Id, filter.Id)
+	if err := selection.Scan(row, &detail); err != nil {
+		return Detail{}, err
 	}
-	if description != nil {
-		detail.Description = *description
+
+	return detail, nil
+}
+
+// GetTimeline returns one span per task of the project. A task starts on its
+// start_date, or on the day it was created in the caller's time zone, and
+// ends on its due date. Tasks without a due date end on their start day.
+func (r Repository) GetTimeline(ctx context.Context, filter TimelineFilter) (Timeline, error) {
+	loc := filter.Location
+	if loc == nil {
+		loc = time.UTC
 	}
-	if ownerId != nil {
-		detail.Owner_id = *ownerId
+
+	var name *string
+	err := r.DB.QueryRowContext(ctx,
+		"SELECT name FROM projects WHERE id = ? AND deleted_at IS NULL",
+		filter.ProjectId,
+	).Scan(&name)
+	if err != nil {
+		return Timeline{}, err
 	}
 
-	detail.TaskStats = TaskStats{
-		TotalTasks: totalTasks,
-		Progress:   progress,
+	query := `
+		SELECT 
+			t.id,
+			COALESCE(t.name, ''),
+			COALESCE(t.status, ''),
+			COALESCE(t.priority, ''),
+			t.assigned_to,
+			to_char(COALESCE(
+				t.start_date,
+				(t.created_at AT TIME ZONE 'UTC' AT TIME ZONE ?)::date
+			), 'YYYY-MM-DD') as start_day,
+			to_char(t.due_date::date, 'YYYY-MM-DD') as due_day
+		FROM tasks t
+		WHERE t.deleted_at IS NULL AND t.project_id = ?
+		ORDER BY start_day, t.id`
+
+	rows, err := r.DB.QueryContext(ctx, query, loc.String(), filter.ProjectId)
+	if err != nil {
+		return Timeline{}, err
 	}
+	defer rows.Close()
 
-	return detail, nil
-}
+	result := Timeline{
+		ProjectId: filter.ProjectId,
+		TimeZone:  loc.String(),
+		Items:     []TimelineItem{},
+	}
+	if name != nil {
+		result.Name = *name
+	}
 
-func (r Repository) GetById(ctx context.Context, id int) (Detail, error) {
-	query := r.buildFindOneQuery()
-	row := r.QueryRowContext(ctx, query, id)
-	return r.scanProjectDetailForFindOne(row)
+	for rows.Next() {
+		var item TimelineItem
+		var assignedTo sql.NullInt64
+		var start, due sql.NullString
+
+		err = rows.Scan(
+			&item.Id,
+			&item.Name,
+			&item.Status,
+			&item.Priority,
+			&assignedTo,
+			&start,
+			&due,
+		)
+		if err != nil {
+			return Timeline{}, err
+		}
+
+		if assignedTo.Valid {
+			id := int(assignedTo.Int64)
+			item.AssignedTo = &id
+		}
+
+		item.Start = start.String
+		item.End = start.String
+		if due.Valid {
+			item.End = due.String
+		}
+		// a task created after its due date is drawn as a single day bar
+		if item.Start == "" || item.End < item.Start {
+			item.Start = item.End
+		}
+
+		startDay, _ := time.Parse("2006-01-02", item.Start)
+		endDay, _ := time.Parse("2006-01-02", item.End)
+		item.Duration = int(endDay.Sub(startDay).Hours()/24) + 1
+
+		if result.Start == nil || item.Start < *result.Start {
+			result.Start = &item.Start
+		}
+		if result.End == nil || item.End > *result.End {
+			result.End = &item.End
+		}
+
+		result.Items = append(result.Items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return Timeline{}, err
+	}
+
+	return result, nil
 }
 
 func (r Repository) Create(ctx context.Context, data Create) (entity.Projects, error) {
@@ -289,22 +404,36 @@ func (r Repository) Create(ctx context.Context, data Create) (entity.Projects, e
 	query := `
 		INSERT INTO projects (name, description, owner_id, created_at)
 		VALUES (?, ?, ?, ?)
-		RETURNING id, name, description, owner_id, created_at
+		RETURNING id, name, description, owner_id, created_at, version
 	`
 
 	now := time.Now()
-	err := r.DB.QueryRowContext(ctx, query,
-		data.Name,
-		data.Description,
-		data.Owner_id,
-		now,
-	).Scan(
-		&project.Id,
-		&project.Name,
-		&project.Description,
-		&project.OwnerId,
-		&project.CreatedAt,
-	)
+	err := r.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if data.Owner_id != nil {
+			if err := checkOwner(ctx, tx, *data.Owner_id); err != nil {
+				return err
+			}
+		}
+
+		err := tx.QueryRowContext(ctx, query,
+			data.Name,
+			data.Description,
+			data.Owner_id,
+			now,
+		).Scan(
+			&project.Id,
+			&project.Name,
+			&project.Description,
+			&project.OwnerId,
+			&project.CreatedAt,
+			&project.Version,
+		)
+		if err != nil {
+			return err
+		}
+
+		return outbox.Append(ctx, tx, outbox.AggregateProject, project.Id, outbox.EventProjectCreated, project)
+	})
 
 	if err != nil {
 		return entity.Projects{}, err
@@ -321,23 +450,43 @@ func (r Repository) Update(ctx context.Context, data Update) (entity.Projects, e
 		SET 
 			name = COALESCE(?, name),
 			description = COALESCE(?, description),
-			owner_id = COALESCE(?, owner_id)
-		WHERE id = ? AND deleted_at IS NULL
-		RETURNING id, name, description, owner_id, created_at
+			owner_id = COALESCE(?, owner_id),
+			version = version + 1
+		WHERE id = ? AND deleted_at IS NULL AND (?::int IS NULL OR version = ?)
+		RETURNING id, name, description, owner_id, created_at, version
 	`
 
-	err := r.DB.QueryRowContext(ctx, query,
-		data.Name,
-		data.Description,
-		data.Owner_id,
-		data.Id,
-	).Scan(
-		&project.Id,
-		&project.Name,
-		&project.Description,
-		&project.OwnerId,
-		&project.CreatedAt,
-	)
+	err := r.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if data.Owner_id != nil {
+			if err := checkOwner(ctx, tx, *data.Owner_id); err != nil {
+				return err
+			}
+		}
+
+		err := tx.QueryRowContext(ctx, query,
+			data.Name,
+			data.Description,
+			data.Owner_id,
+			data.Id,
+			data.Version,
+			data.Version,
+		).Scan(
+			&project.Id,
+			&project.Name,
+			&project.Description,
+			&project.OwnerId,
+			&project.CreatedAt,
+			&project.Version,
+		)
+		if errors.Is(err, sql.ErrNoRows) && data.Version != nil {
+			return versionMismatch(ctx, tx, *data.Id)
+		}
+		if err != nil {
+			return err
+		}
+
+		return outbox.Append(ctx, tx, outbox.AggregateProject, project.Id, outbox.EventProjectUpdated, project)
+	})
 
 	if err != nil {
 		return entity.Projects{}, err
@@ -349,25 +498,224 @@ func (r Repository) Update(ctx context.Context, data Update) (entity.Projects, e
 func (r Repository) Delete(ctx context.Context, data basic_repo.Delete) error {
 	query := `
 		UPDATE projects 
-		SET deleted_at = ? 
-		WHERE id = ? AND deleted_at IS NULL
+		SET deleted_at = ?, version = version + 1
+		WHERE id = ? AND deleted_at IS NULL AND (?::int IS NULL OR version = ?)
+	`
+
+	now := time.Now()
+
+	return r.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		result, err := tx.ExecContext(ctx, query, now, data.Id, data.Version, data.Version)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if rowsAffected == 0 && data.Version != nil {
+			err = versionMismatch(ctx, tx, *data.Id)
+			if !errors.Is(err, sql.ErrNoRows) {
+				return err
+			}
+		}
+		if rowsAffected == 0 {
+			return sql.ErrNoRows
+		}
+
+		err = outbox.Append(ctx, tx, outbox.AggregateProject, *data.Id, outbox.EventProjectDeleted, map[string]int{"id": *data.Id})
+		if err != nil {
+			return err
+		}
+
+		// the tasks go with the project and are marked so that restoring it
+		// brings back exactly these
+		var tasks []entity.Tasks
+		_, err = tx.NewUpdate().
+			Model(&tasks).
+			Set("deleted_at = ?", now).
+			Set("cascade_deleted = true").
+			Set("version = version + 1").
+			Where("project_id = ? AND deleted_at IS NULL", *data.Id).
+			Returning("*").
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("error deleting project tasks: %w", err)
+		}
+
+		for _, task := range tasks {
+			err = outbox.Append(ctx, tx, outbox.AggregateTask, task.Id, outbox.EventTaskDeleted, task)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Restore brings back a deleted project along with the tasks that were
+// deleted with it. Tasks deleted on their own before stay in the trash.
+// Consumers see the restored records as created again.
+func (r Repository) Restore(ctx context.Context, id int) (entity.Projects, error) {
+	var project entity.Projects
+
+	query := `
+		UPDATE projects 
+		SET deleted_at = NULL, updated_at = ?, version = version + 1
+		WHERE id = ? AND deleted_at IS NOT NULL
+		RETURNING id, name, description, owner_id, created_at, version
 	`
 
-	result, err := r.DB.ExecContext(ctx, query, time.Now(), data.Id)
+	err := r.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		err := tx.QueryRowContext(ctx, query, time.Now(), id).Scan(
+			&project.Id,
+			&project.Name,
+			&project.Description,
+			&project.OwnerId,
+			&project.CreatedAt,
+			&project.Version,
+		)
+		if err != nil {
+			return err
+		}
+
+		err = outbox.Append(ctx, tx, outbox.AggregateProject, project.Id, outbox.EventProjectCreated, project)
+		if err != nil {
+			return err
+		}
+
+		var tasks []entity.Tasks
+		_, err = tx.NewUpdate().
+			Model(&tasks).
+			Set("deleted_at = NULL").
+			Set("cascade_deleted = false").
+			Set("version = version + 1").
+			Where("project_id = ? AND deleted_at IS NOT NULL AND cascade_deleted", id).
+			Returning("*").
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("error restoring project tasks: %w", err)
+		}
+
+		for _, task := range tasks {
+			err = outbox.Append(ctx, tx, outbox.AggregateTask, task.Id, outbox.EventTaskCreated, task)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return entity.Projects{}, err
+	}
+
+	return project, nil
+}
+
+// checkOwner fails with a validation error unless ownerId is a user that
+// isn't deleted.
+func checkOwner(ctx context.Context, tx bun.Tx, ownerId int) error {
+	var exists bool
+
+	err := tx.QueryRowContext(ctx,
+		"SELECT EXISTS (SELECT 1 FROM users WHERE id = ? AND deleted_at IS NULL)",
+		ownerId,
+	).Scan(&exists)
 	if err != nil {
 		return err
 	}
+	if !exists {
+		return basic_repo.InvalidField("owner_id", "user %d not found", ownerId)
+	}
+
+	return nil
+}
+
+// versionMismatch tells why a versioned write to a project matched no row:
+// ErrVersionMismatch when the project exists, sql.ErrNoRows otherwise.
+func versionMismatch(ctx context.Context, tx bun.Tx, id int) error {
+	var exists bool
 
-	rowsAffected, err := result.RowsAffected()
+	err := tx.QueryRowContext(ctx,
+		"SELECT EXISTS (SELECT 1 FROM projects WHERE id = ? AND deleted_at IS NULL)",
+		id,
+	).Scan(&exists)
 	if err != nil {
 		return err
 	}
+	if exists {
+		return basic_repo.ErrVersionMismatch
+	}
+
+	return sql.ErrNoRows
+}
 
-	if rowsAffected == 0 {
-		return fmt.Errorf("project not found")
+// GetAccess returns the projects userId may see: every project for managers,
+// the projects they own or have tasks assigned in for workers.
+func (r Repository) GetAccess(ctx context.Context, userId int) (Access, error) {
+	var role string
+
+	err := r.DB.QueryRowContext(ctx,
+		"SELECT role FROM users WHERE id = ? AND deleted_at IS NULL",
+		userId,
+	).Scan(&role)
+	if err != nil {
+		return Access{}, err
 	}
 
-	return nil
+	if role == "manager" {
+		return Access{All: true}, nil
+	}
+
+	query := `
+		SELECT id FROM projects WHERE owner_id = ? AND deleted_at IS NULL
+		UNION
+		SELECT DISTINCT project_id FROM tasks WHERE assigned_to = ? AND deleted_at IS NULL AND project_id IS NOT NULL`
+
+	rows, err := r.DB.QueryContext(ctx, query, userId, userId)
+	if err != nil {
+		return Access{}, fmt.Errorf("error querying project access: %w", err)
+	}
+	defer rows.Close()
+
+	access := Access{ProjectIds: map[int]bool{}}
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return Access{}, fmt.Errorf("error scanning project access row: %w", err)
+		}
+		access.ProjectIds[id] = true
+	}
+
+	if err = rows.Err(); err != nil {
+		return Access{}, fmt.Errorf("error iterating project access rows: %w", err)
+	}
+
+	return access, nil
+}
+
+// GetByIds returns the projects with the given ids, missing and deleted ones
+// are left out.
+func (r Repository) GetByIds(ctx context.Context, ids []int) ([]entity.Projects, error) {
+	list := []entity.Projects{}
+	if len(ids) == 0 {
+		return list, nil
+	}
+
+	err := r.NewSelect().
+		Model(&list).
+		Where("id IN (?) AND deleted_at IS NULL", bun.In(ids)).
+		Order("id").
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting projects: %w", err)
+	}
+
+	return list, nil
 }
 
 func NewRepository(DB *bun.DB) *Repository {
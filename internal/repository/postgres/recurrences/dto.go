@@ -0,0 +1,33 @@
+package recurrences
+
+type Create struct {
+	TaskId   *int    `json:"task_id"`
+	Rule     *string `json:"rule" binding:"required"`
+	Mode     *string `json:"mode" validate:"omitempty,oneof=schedule on_complete"`
+	LeadDays *int    `json:"lead_days" validate:"omitempty,min=0"`
+	Dtstart  *string `json:"dtstart"`
+}
+
+type Detail struct {
+	Id             int      `json:"id"`
+	TemplateTaskId int      `json:"template_task_id"`
+	Rule           string   `json:"rule"`
+	Mode           string   `json:"mode"`
+	LeadDays       int      `json:"lead_days"`
+	Dtstart        string   `json:"dtstart"`
+	NextDate       *string  `json:"next_date"`
+	Occurrences    int      `json:"occurrences"`
+	Upcoming       []string `json:"upcoming"`
+}
+
+// Due is a recurrence whose next occurrence should be materialised now.
+type Due struct {
+	Id             int
+	TemplateTaskId int
+	Rule           string
+	Mode           string
+	LeadDays       int
+	Dtstart        string
+	NextDate       string
+	Occurrences    int
+}
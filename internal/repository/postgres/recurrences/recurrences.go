@@ -0,0 +1,291 @@
+package recurrences
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"task-management2/internal/entity"
+	basic_repo "task-management2/internal/repository/postgres/_basic_repo"
+	"task-management2/internal/util/rrule"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+const (
+	ModeSchedule   = "schedule"
+	ModeOnComplete = "on_complete"
+)
+
+const (
+	dateLayout    = "2006-01-02"
+	upcomingCount = 5
+)
+
+type Repository struct {
+	*bun.DB
+}
+
+func NewRepository(DB *bun.DB) *Repository {
+	return &Repository{DB: DB}
+}
+
+// Upsert attaches a recurrence rule to a template task, replacing the rule
+// it had before. The template counts as the first occurrence.
+func (r Repository) Upsert(ctx context.Context, data Create) (Detail, error) {
+	rule, err := rrule.Parse(*data.Rule)
+	if err != nil {
+		return Detail{}, basic_repo.InvalidField("rule", "invalid rule: %v", err)
+	}
+
+	mode := ModeSchedule
+	if data.Mode != nil {
+		mode = *data.Mode
+	}
+	if mode != ModeSchedule && mode != ModeOnComplete {
+		return Detail{}, basic_repo.InvalidField("mode", "mode must be one of schedule, on_complete")
+	}
+
+	leadDays := 0
+	if data.LeadDays != nil {
+		if *data.LeadDays < 0 {
+			return Detail{}, basic_repo.InvalidField("lead_days", "lead_days must not be negative")
+		}
+		leadDays = *data.LeadDays
+	}
+
+	var dueDate sql.NullString
+	err = r.QueryRowContext(ctx,
+		"SELECT to_char(due_date::date, 'YYYY-MM-DD') FROM tasks WHERE id = ? AND deleted_at IS NULL",
+		*data.TaskId,
+	).Scan(&dueDate)
+	if err != nil {
+		return Detail{}, err
+	}
+
+	dtstart := time.Now().UTC()
+	switch {
+	case data.Dtstart != nil:
+		dtstart, err = time.Parse(dateLayout, *data.Dtstart)
+		if err != nil {
+			return Detail{}, basic_repo.InvalidField("dtstart", "invalid dtstart format: %v", err)
+		}
+	case dueDate.Valid:
+		dtstart, _ = time.Parse(dateLayout, dueDate.String)
+	}
+
+	var nextDate *string
+	if rule.Count != 1 {
+		if next, ok := rule.Next(dtstart, dtstart); ok {
+			s := next.Format(dateLayout)
+			nextDate = &s
+		}
+	}
+
+	canonical := rule.String()
+	start := dtstart.Format(dateLayout)
+	occurrences := 1
+	now := time.Now()
+
+	detail := entity.TaskRecurrences{
+		TemplateTaskId: data.TaskId,
+		Rule:           &canonical,
+		Mode:           &mode,
+		LeadDays:       &leadDays,
+		Dtstart:        &start,
+		NextDate:       nextDate,
+		Occurrences:    &occurrences,
+	}
+	detail.CreatedAt = &now
+
+	err = r.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewUpdate().
+			Model((*entity.TaskRecurrences)(nil)).
+			Set("deleted_at = ?", now).
+			Where("template_task_id = ? AND deleted_at IS NULL", *data.TaskId).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.NewInsert().Model(&detail).Exec(ctx)
+		return err
+	})
+	if err != nil {
+		return Detail{}, fmt.Errorf("error saving recurrence: %w", err)
+	}
+
+	return toDetail(detail, rule, dtstart), nil
+}
+
+func (r Repository) GetByTaskId(ctx context.Context, taskId int) (Detail, error) {
+	var detail entity.TaskRecurrences
+
+	err := r.NewSelect().
+		Model(&detail).
+		ColumnExpr("id, template_task_id, rule, mode, lead_days, occurrences, created_at").
+		ColumnExpr("to_char(dtstart, 'YYYY-MM-DD') as dtstart").
+		ColumnExpr("to_char(next_date, 'YYYY-MM-DD') as next_date").
+		Where("template_task_id = ? AND deleted_at IS NULL", taskId).
+		Scan(ctx)
+	if err != nil {
+		return Detail{}, err
+	}
+
+	rule, err := rrule.Parse(*detail.Rule)
+	if err != nil {
+		return Detail{}, fmt.Errorf("invalid stored rule: %v", err)
+	}
+
+	dtstart, _ := time.Parse(dateLayout, *detail.Dtstart)
+
+	return toDetail(detail, rule, dtstart), nil
+}
+
+// Delete stops a recurrence. Occurrences created so far are kept.
+func (r Repository) Delete(ctx context.Context, taskId int) error {
+	result, err := r.NewUpdate().
+		Model((*entity.TaskRecurrences)(nil)).
+		Set("deleted_at = ?", time.Now()).
+		Where("template_task_id = ? AND deleted_at IS NULL", taskId).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetDue lists recurrences with work to do on the given day: scheduled ones
+// whose next date (minus lead days) has arrived, and on-complete ones whose
+// latest occurrence is completed.
+func (r Repository) GetDue(ctx context.Context, today string) ([]Due, error) {
+	query := `
+		SELECT
+			r.id,
+			r.template_task_id,
+			r.rule,
+			COALESCE(r.mode, 'schedule'),
+			COALESCE(r.lead_days, 0),
+			to_char(r.dtstart, 'YYYY-MM-DD'),
+			to_char(r.next_date, 'YYYY-MM-DD'),
+			COALESCE(r.occurrences, 1)
+		FROM task_recurrences r
+		JOIN tasks tt ON tt.id = r.template_task_id AND tt.deleted_at IS NULL
+		LEFT JOIN LATERAL (
+			SELECT t.status
+			FROM tasks t
+			WHERE (t.id = r.template_task_id OR t.recurrence_id = r.id) AND t.deleted_at IS NULL
+			ORDER BY COALESCE(t.occurrence_date, '-infinity'::date) DESC, t.id DESC
+			LIMIT 1
+		) last ON true
+		WHERE r.deleted_at IS NULL AND r.next_date IS NOT NULL
+		AND (
+			(r.mode = 'schedule' AND r.next_date - COALESCE(r.lead_days, 0) <= ?::date)
+			OR (r.mode = 'on_complete' AND last.status = 'completed')
+		)
+		ORDER BY r.id`
+
+	rows, err := r.QueryContext(ctx, query, today)
+	if err != nil {
+		return nil, fmt.Errorf("error querying due recurrences: %w", err)
+	}
+	defer rows.Close()
+
+	var result []Due
+	for rows.Next() {
+		var item Due
+
+		err := rows.Scan(
+			&item.Id,
+			&item.TemplateTaskId,
+			&item.Rule,
+			&item.Mode,
+			&item.LeadDays,
+			&item.Dtstart,
+			&item.NextDate,
+			&item.Occurrences,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning due recurrence row: %w", err)
+		}
+
+		result = append(result, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating due recurrence rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// Advance moves a recurrence past an occurrence. It only succeeds when
+// next_date still holds the value the caller read, so concurrent schedulers
+// can't advance the same recurrence twice.
+func (r Repository) Advance(ctx context.Context, id int, expectedNext string, next *string) (bool, error) {
+	result, err := r.NewUpdate().
+		Model((*entity.TaskRecurrences)(nil)).
+		Set("next_date = ?::date", next).
+		Set("occurrences = occurrences + 1").
+		Set("updated_at = ?", time.Now()).
+		Where("id = ? AND next_date = ?::date AND deleted_at IS NULL", id, expectedNext).
+		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf("error advancing recurrence: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
+
+func toDetail(detail entity.TaskRecurrences, rule rrule.Rule, dtstart time.Time) Detail {
+	result := Detail{
+		Id:             detail.Id,
+		TemplateTaskId: *detail.TemplateTaskId,
+		Rule:           *detail.Rule,
+		Mode:           *detail.Mode,
+		LeadDays:       *detail.LeadDays,
+		Dtstart:        *detail.Dtstart,
+		NextDate:       detail.NextDate,
+		Occurrences:    *detail.Occurrences,
+		Upcoming:       []string{},
+	}
+
+	if detail.NextDate == nil {
+		return result
+	}
+
+	next, err := time.Parse(dateLayout, *detail.NextDate)
+	if err != nil {
+		return result
+	}
+
+	for i := 0; i < upcomingCount; i++ {
+		if rule.Count > 0 && result.Occurrences+i >= rule.Count {
+			break
+		}
+		result.Upcoming = append(result.Upcoming, next.Format(dateLayout))
+
+		n, ok := rule.Next(dtstart, next)
+		if !ok {
+			break
+		}
+		next = n
+	}
+
+	return result
+}
@@ -0,0 +1,69 @@
+package sprints
+
+import "task-management2/internal/entity"
+
+type Filter struct {
+	Limit     *int
+	Offset    *int
+	ProjectId *int
+	State     *string
+}
+
+type Create struct {
+	ProjectId *int    `json:"project_id" binding:"required"`
+	Name      *string `json:"name" binding:"required" validate:"omitempty,min=1,max=255"`
+	Goal      *string `json:"goal"`
+	StartDate *string `json:"start_date" binding:"required" validate:"omitempty,date"`
+	EndDate   *string `json:"end_date" binding:"required" validate:"omitempty,date"`
+}
+
+type Update struct {
+	Id        *int    `json:"id" form:"id"`
+	Name      *string `json:"name" validate:"omitempty,min=1,max=255"`
+	Goal      *string `json:"goal"`
+	StartDate *string `json:"start_date" validate:"omitempty,date"`
+	EndDate   *string `json:"end_date" validate:"omitempty,date"`
+}
+
+type AssignTasks struct {
+	SprintId *int  `json:"sprint_id"`
+	TaskIds  []int `json:"task_ids" binding:"required"`
+}
+
+// Close ends a sprint. Unfinished tasks move to CarryOverTo, or back to the
+// backlog when it is empty.
+type Close struct {
+	Id          *int `json:"id" form:"id"`
+	CarryOverTo *int `json:"carry_over_to"`
+}
+
+type Scope struct {
+	Tasks  int `json:"tasks"`
+	Points int `json:"points"`
+}
+
+type Report struct {
+	Sprint      entity.Sprints `json:"sprint"`
+	Committed   Scope          `json:"committed"`
+	Completed   Scope          `json:"completed"`
+	Remaining   Scope          `json:"remaining"`
+	CarriedOver Scope          `json:"carried_over"`
+	// Completed points relative to the committed ones, 0 to 100 and more
+	// when scope was added.
+	CompletionRate float64  `json:"completion_rate"`
+	Velocity       Velocity `json:"velocity"`
+}
+
+type VelocityItem struct {
+	SprintId        int    `json:"sprint_id"`
+	Name            string `json:"name"`
+	EndDate         string `json:"end_date"`
+	CommittedPoints int    `json:"committed_points"`
+	CompletedPoints int    `json:"completed_points"`
+}
+
+type Velocity struct {
+	ProjectId int            `json:"project_id"`
+	Average   float64        `json:"average"`
+	Sprints   []VelocityItem `json:"sprints"`
+}
@@ -0,0 +1,554 @@
+package sprints
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"task-management2/internal/entity"
+	basic_repo "task-management2/internal/repository/postgres/_basic_repo"
+	"task-management2/internal/repository/postgres/outbox"
+	"time"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/driver/pgdriver"
+)
+
+const (
+	StatePlanned = "planned"
+	StateActive  = "active"
+	StateClosed  = "closed"
+)
+
+const (
+	dateLayout = "2006-01-02"
+	// velocitySprints is how many closed sprints the velocity averages.
+	velocitySprints = 3
+)
+
+var (
+	ErrInvalidState       = basic_repo.Conflict("the sprint is not in a state that allows this")
+	ErrActiveSprintExists = basic_repo.Conflict("the project already has an active sprint")
+	ErrTaskNotInProject   = basic_repo.InvalidField("task_ids", "tasks must belong to the sprint's project")
+	ErrInvalidCarryOver   = basic_repo.InvalidField("carry_over_to", "carry_over_to must be another open sprint of the project")
+)
+
+type Repository struct {
+	*bun.DB
+}
+
+func NewRepository(DB *bun.DB) *Repository {
+	return &Repository{DB: DB}
+}
+
+// sprintColumns reads the DATE columns as plain dates.
+const sprintColumns = "id, project_id, name, goal, state, committed_tasks, committed_points, completed_tasks, " +
+	"completed_points, carried_over_tasks, carried_over_points, started_at, closed_at, created_at, updated_at, deleted_at, " +
+	"to_char(start_date, 'YYYY-MM-DD') as start_date, to_char(end_date, 'YYYY-MM-DD') as end_date"
+
+func (r Repository) GetAll(ctx context.Context, filter Filter) ([]entity.Sprints, int, error) {
+	var result []entity.Sprints
+
+	query := r.NewSelect().
+		Model(&result).
+		ColumnExpr(sprintColumns).
+		Where("deleted_at IS NULL").
+		Order("start_date DESC", "id DESC")
+
+	if filter.ProjectId != nil {
+		query = query.Where("project_id = ?", *filter.ProjectId)
+	}
+	if filter.State != nil {
+		query = query.Where("state = ?", *filter.State)
+	}
+	if filter.Limit != nil {
+		query = query.Limit(*filter.Limit)
+	}
+	if filter.Offset != nil {
+		query = query.Offset(*filter.Offset)
+	}
+
+	count, err := query.ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error querying sprints: %w", err)
+	}
+
+	return result, count, nil
+}
+
+func (r Repository) GetById(ctx context.Context, id int) (entity.Sprints, error) {
+	return getSprint(ctx, r.DB, id, false)
+}
+
+// getSprint reads a sprint through db, locking it when forUpdate is set.
+func getSprint(ctx context.Context, db bun.IDB, id int, forUpdate bool) (entity.Sprints, error) {
+	var detail entity.Sprints
+
+	query := db.NewSelect().
+		Model(&detail).
+		ColumnExpr(sprintColumns).
+		Where("id = ? AND deleted_at IS NULL", id)
+	if forUpdate {
+		query = query.For("UPDATE")
+	}
+
+	err := query.Scan(ctx)
+	if err != nil {
+		return entity.Sprints{}, fmt.Errorf("error getting sprint: %w", err)
+	}
+
+	return detail, nil
+}
+
+func validateDates(start, end string) error {
+	startDate, err := time.Parse(dateLayout, start)
+	if err != nil {
+		return basic_repo.InvalidField("start_date", "invalid start_date format: %v", err)
+	}
+	endDate, err := time.Parse(dateLayout, end)
+	if err != nil {
+		return basic_repo.InvalidField("end_date", "invalid end_date format: %v", err)
+	}
+	if endDate.Before(startDate) {
+		return basic_repo.InvalidField("end_date", "end_date must not be before start_date")
+	}
+
+	return nil
+}
+
+func (r Repository) Create(ctx context.Context, data Create) (entity.Sprints, error) {
+	if err := validateDates(*data.StartDate, *data.EndDate); err != nil {
+		return entity.Sprints{}, err
+	}
+
+	state := StatePlanned
+	now := time.Now()
+
+	detail := entity.Sprints{
+		ProjectId: data.ProjectId,
+		Name:      data.Name,
+		Goal:      data.Goal,
+		StartDate: data.StartDate,
+		EndDate:   data.EndDate,
+		State:     &state,
+	}
+	detail.CreatedAt = &now
+
+	_, err := r.NewInsert().Model(&detail).Exec(ctx)
+	if err != nil {
+		return entity.Sprints{}, fmt.Errorf("error creating sprint: %w", err)
+	}
+
+	return detail, nil
+}
+
+func (r Repository) Update(ctx context.Context, data Update) (entity.Sprints, error) {
+	var detail entity.Sprints
+
+	err := r.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var err error
+		detail, err = getSprint(ctx, tx, *data.Id, true)
+		if err != nil {
+			return err
+		}
+
+		if *detail.State == StateClosed {
+			return ErrInvalidState
+		}
+
+		if data.Name != nil {
+			detail.Name = data.Name
+		}
+		if data.Goal != nil {
+			detail.Goal = data.Goal
+		}
+		if data.StartDate != nil {
+			detail.StartDate = data.StartDate
+		}
+		if data.EndDate != nil {
+			detail.EndDate = data.EndDate
+		}
+
+		if err := validateDates(*detail.StartDate, *detail.EndDate); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		detail.UpdateAt = &now
+
+		_, err = tx.NewUpdate().Model(&detail).WherePK().Exec(ctx)
+		return err
+	})
+	if err != nil {
+		return entity.Sprints{}, err
+	}
+
+	return detail, nil
+}
+
+// Delete removes a sprint that isn't running. Its tasks go back to the
+// backlog.
+func (r Repository) Delete(ctx context.Context, id int) error {
+	return r.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		detail, err := getSprint(ctx, tx, id, true)
+		if err != nil {
+			return err
+		}
+
+		if *detail.State == StateActive {
+			return ErrInvalidState
+		}
+
+		_, err = tx.NewUpdate().
+			Model((*entity.Sprints)(nil)).
+			Set("deleted_at = ?", time.Now()).
+			Where("id = ?", id).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		_, err = updateTasks(ctx, tx, "sprint_id = ?", []interface{}{id}, nil)
+		return err
+	})
+}
+
+// AddTasks puts tasks of the sprint's project into the sprint, taking them
+// out of any sprint they were in.
+func (r Repository) AddTasks(ctx context.Context, data AssignTasks) ([]entity.Tasks, error) {
+	var result []entity.Tasks
+
+	if len(data.TaskIds) == 0 {
+		return nil, basic_repo.InvalidField("task_ids", "task_ids must not be empty")
+	}
+
+	err := r.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		detail, err := getSprint(ctx, tx, *data.SprintId, true)
+		if err != nil {
+			return err
+		}
+
+		if *detail.State == StateClosed {
+			return ErrInvalidState
+		}
+
+		var count int
+		err = tx.QueryRowContext(ctx,
+			"SELECT COUNT(*) FROM tasks WHERE id IN (?) AND project_id = ? AND deleted_at IS NULL",
+			bun.In(data.TaskIds), *detail.ProjectId,
+		).Scan(&count)
+		if err != nil {
+			return err
+		}
+		if count != len(unique(data.TaskIds)) {
+			return ErrTaskNotInProject
+		}
+
+		sprintId := detail.Id
+		result, err = updateTasks(ctx, tx, "id IN (?)", []interface{}{bun.In(data.TaskIds)}, &sprintId)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// RemoveTask moves a task of the sprint back to the backlog.
+func (r Repository) RemoveTask(ctx context.Context, sprintId, taskId int) error {
+	return r.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		detail, err := getSprint(ctx, tx, sprintId, true)
+		if err != nil {
+			return err
+		}
+
+		if *detail.State == StateClosed {
+			return ErrInvalidState
+		}
+
+		tasks, err := updateTasks(ctx, tx, "id = ? AND sprint_id = ?", []interface{}{taskId, sprintId}, nil)
+		if err != nil {
+			return err
+		}
+		if len(tasks) == 0 {
+			return sql.ErrNoRows
+		}
+
+		return nil
+	})
+}
+
+// Start makes a planned sprint the project's active one and records its
+// committed scope.
+func (r Repository) Start(ctx context.Context, id int) (entity.Sprints, error) {
+	var detail entity.Sprints
+
+	err := r.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var err error
+		detail, err = getSprint(ctx, tx, id, true)
+		if err != nil {
+			return err
+		}
+
+		if *detail.State != StatePlanned {
+			return ErrInvalidState
+		}
+
+		scope, err := sprintScope(ctx, tx, id, false)
+		if err != nil {
+			return err
+		}
+
+		state := StateActive
+		now := time.Now()
+		detail.State = &state
+		detail.StartedAt = &now
+		detail.UpdateAt = &now
+		detail.CommittedTasks = &scope.Tasks
+		detail.CommittedPoints = &scope.Points
+
+		_, err = tx.NewUpdate().Model(&detail).WherePK().Exec(ctx)
+
+		var pgErr pgdriver.Error
+		if errors.As(err, &pgErr) && pgErr.Field('C') == "23505" {
+			return ErrActiveSprintExists
+		}
+
+		return err
+	})
+	if err != nil {
+		return entity.Sprints{}, err
+	}
+
+	return detail, nil
+}
+
+// Close ends the active sprint, records what was completed and carries the
+// unfinished tasks over.
+func (r Repository) Close(ctx context.Context, data Close) (entity.Sprints, error) {
+	var detail entity.Sprints
+
+	err := r.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var err error
+		detail, err = getSprint(ctx, tx, *data.Id, true)
+		if err != nil {
+			return err
+		}
+
+		if *detail.State != StateActive {
+			return ErrInvalidState
+		}
+
+		if data.CarryOverTo != nil {
+			if *data.CarryOverTo == detail.Id {
+				return ErrInvalidCarryOver
+			}
+
+			target, err := getSprint(ctx, tx, *data.CarryOverTo, true)
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrInvalidCarryOver
+			}
+			if err != nil {
+				return err
+			}
+			if *target.ProjectId != *detail.ProjectId || *target.State == StateClosed {
+				return ErrInvalidCarryOver
+			}
+		}
+
+		completed, err := sprintScope(ctx, tx, detail.Id, true)
+		if err != nil {
+			return err
+		}
+		all, err := sprintScope(ctx, tx, detail.Id, false)
+		if err != nil {
+			return err
+		}
+		carried := Scope{
+			Tasks:  all.Tasks - completed.Tasks,
+			Points: all.Points - completed.Points,
+		}
+
+		_, err = updateTasks(ctx, tx,
+			"sprint_id = ? AND COALESCE(status, '') <> 'completed'",
+			[]interface{}{detail.Id},
+			data.CarryOverTo,
+		)
+		if err != nil {
+			return err
+		}
+
+		state := StateClosed
+		now := time.Now()
+		detail.State = &state
+		detail.ClosedAt = &now
+		detail.UpdateAt = &now
+		detail.CompletedTasks = &completed.Tasks
+		detail.CompletedPoints = &completed.Points
+		detail.CarriedOverTasks = &carried.Tasks
+		detail.CarriedOverPoints = &carried.Points
+
+		_, err = tx.NewUpdate().Model(&detail).WherePK().Exec(ctx)
+		return err
+	})
+	if err != nil {
+		return entity.Sprints{}, err
+	}
+
+	return detail, nil
+}
+
+// GetReport compares the committed scope with what was completed. Numbers
+// of closed sprints come from the snapshots taken on close, those of open
+// sprints are live.
+func (r Repository) GetReport(ctx context.Context, id int) (Report, error) {
+	detail, err := r.GetById(ctx, id)
+	if err != nil {
+		return Report{}, err
+	}
+
+	report := Report{Sprint: detail}
+
+	switch *detail.State {
+	case StateClosed:
+		report.Committed = Scope{Tasks: intValue(detail.CommittedTasks), Points: intValue(detail.CommittedPoints)}
+		report.Completed = Scope{Tasks: intValue(detail.CompletedTasks), Points: intValue(detail.CompletedPoints)}
+		report.CarriedOver = Scope{Tasks: intValue(detail.CarriedOverTasks), Points: intValue(detail.CarriedOverPoints)}
+	default:
+		all, err := sprintScope(ctx, r.DB, id, false)
+		if err != nil {
+			return Report{}, err
+		}
+		report.Completed, err = sprintScope(ctx, r.DB, id, true)
+		if err != nil {
+			return Report{}, err
+		}
+		report.Remaining = Scope{
+			Tasks:  all.Tasks - report.Completed.Tasks,
+			Points: all.Points - report.Completed.Points,
+		}
+
+		report.Committed = all
+		if *detail.State == StateActive {
+			report.Committed = Scope{Tasks: intValue(detail.CommittedTasks), Points: intValue(detail.CommittedPoints)}
+		}
+	}
+
+	if report.Committed.Points > 0 {
+		rate := float64(report.Completed.Points) / float64(report.Committed.Points) * 100
+		report.CompletionRate = math.Round(rate*100) / 100
+	}
+
+	report.Velocity, err = r.GetVelocity(ctx, *detail.ProjectId)
+	if err != nil {
+		return Report{}, err
+	}
+
+	return report, nil
+}
+
+// GetVelocity averages the completed points of the project's last closed
+// sprints.
+func (r Repository) GetVelocity(ctx context.Context, projectId int) (Velocity, error) {
+	result := Velocity{ProjectId: projectId, Sprints: []VelocityItem{}}
+
+	query := `
+		SELECT id, name, to_char(end_date, 'YYYY-MM-DD'), COALESCE(committed_points, 0), COALESCE(completed_points, 0)
+		FROM sprints
+		WHERE project_id = ? AND state = ? AND deleted_at IS NULL
+		ORDER BY closed_at DESC, id DESC
+		LIMIT ?`
+
+	rows, err := r.QueryContext(ctx, query, projectId, StateClosed, velocitySprints)
+	if err != nil {
+		return Velocity{}, fmt.Errorf("error querying velocity: %w", err)
+	}
+	defer rows.Close()
+
+	total := 0
+	for rows.Next() {
+		var item VelocityItem
+
+		err := rows.Scan(&item.SprintId, &item.Name, &item.EndDate, &item.CommittedPoints, &item.CompletedPoints)
+		if err != nil {
+			return Velocity{}, fmt.Errorf("error scanning velocity row: %w", err)
+		}
+
+		total += item.CompletedPoints
+		result.Sprints = append(result.Sprints, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return Velocity{}, fmt.Errorf("error iterating velocity rows: %w", err)
+	}
+
+	if len(result.Sprints) > 0 {
+		result.Average = math.Round(float64(total)/float64(len(result.Sprints))*100) / 100
+	}
+
+	return result, nil
+}
+
+// sprintScope counts the tasks and story points of a sprint, only the
+// completed ones when completed is set.
+func sprintScope(ctx context.Context, db bun.IDB, sprintId int, completed bool) (Scope, error) {
+	query := "SELECT COUNT(*), COALESCE(SUM(story_points), 0) FROM tasks WHERE sprint_id = ? AND deleted_at IS NULL"
+	if completed {
+		query += " AND status = 'completed'"
+	}
+
+	var scope Scope
+	err := db.QueryRowContext(ctx, query, sprintId).Scan(&scope.Tasks, &scope.Points)
+	if err != nil {
+		return Scope{}, fmt.Errorf("error counting sprint scope: %w", err)
+	}
+
+	return scope, nil
+}
+
+// updateTasks moves the tasks matching where into sprintId (the backlog for
+// nil) and records a task.updated event for each.
+func updateTasks(ctx context.Context, tx bun.Tx, where string, args []interface{}, sprintId *int) ([]entity.Tasks, error) {
+	var tasks []entity.Tasks
+
+	_, err := tx.NewUpdate().
+		Model(&tasks).
+		Set("sprint_id = ?", sprintId).
+		Set("updated_at = ?", time.Now()).
+		Set("version = version + 1").
+		Where("deleted_at IS NULL").
+		Where(where, args...).
+		Returning("*").
+		Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error moving tasks: %w", err)
+	}
+
+	for _, task := range tasks {
+		err = outbox.Append(ctx, tx, outbox.AggregateTask, task.Id, outbox.EventTaskUpdated, task)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tasks, nil
+}
+
+func unique(ids []int) map[int]bool {
+	result := map[int]bool{}
+	for _, id := range ids {
+		result[id] = true
+	}
+
+	return result
+}
+
+func intValue(i *int) int {
+	if i == nil {
+		return 0
+	}
+
+	return *i
+}
@@ -0,0 +1,48 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"github.com/uptrace/bun"
+	"task-management2/internal/entity"
+)
+
+// GetByProjects returns the tasks of the given projects, at most limit per
+// project when limit is set.
+func (r Repository) GetByProjects(ctx context.Context, projectIds []int, limit *int) ([]entity.Tasks, error) {
+	return r.getBatch(ctx, "project_id", projectIds, limit)
+}
+
+// GetByAssignees returns the tasks assigned to the given users, at most
+// limit per user when limit is set.
+func (r Repository) GetByAssignees(ctx context.Context, userIds []int, limit *int) ([]entity.Tasks, error) {
+	return r.getBatch(ctx, "assigned_to", userIds, limit)
+}
+
+func (r Repository) getBatch(ctx context.Context, column string, ids []int, limit *int) ([]entity.Tasks, error) {
+	list := []entity.Tasks{}
+	if len(ids) == 0 {
+		return list, nil
+	}
+
+	query := r.NewSelect().
+		Model(&list).
+		Where("? IN (?) AND deleted_at IS NULL", bun.Ident(column), bun.In(ids)).
+		Order("id")
+
+	if limit != nil {
+		query = query.Where(`id IN (
+			SELECT id FROM (
+				SELECT id, row_number() OVER (PARTITION BY ? ORDER BY id) AS n
+				FROM tasks
+				WHERE ? IN (?) AND deleted_at IS NULL
+			) ranked
+			WHERE n <= ?)`, bun.Ident(column), bun.Ident(column), bun.In(ids), *limit)
+	}
+
+	if err := query.Scan(ctx); err != nil {
+		return nil, fmt.Errorf("error getting tasks by %s: %w", column, err)
+	}
+
+	return list, nil
+}
@@ -0,0 +1,254 @@
+package tasks
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"task-management2/internal/entity"
+	basic_repo "task-management2/internal/repository/postgres/_basic_repo"
+	"task-management2/internal/repository/postgres/outbox"
+	"task-management2/internal/util/rank"
+
+	"github.com/uptrace/bun"
+)
+
+var (
+	ErrWipLimitExceeded = basic_repo.Conflict("the target column is at its WIP limit")
+	ErrInvalidNeighbour = basic_repo.Invalid("neighbours must be other tasks of the target column")
+)
+
+// Move puts a task into a board column, between AfterId and BeforeId when
+// given, at the end of the column otherwise. Moves within a project are
+// serialised, so WIP limits hold under concurrent moves.
+func (r Repository) Move(ctx context.Context, data Move) (entity.Tasks, error) {
+	var detail entity.Tasks
+
+	err := r.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		err := tx.NewSelect().Model(&detail).Where("id = ? AND deleted_at IS NULL", *data.Id).For("UPDATE").Scan(ctx)
+		if err != nil {
+			return err
+		}
+
+		if detail.Status == nil || *detail.Status != *data.Status {
+			err = enterColumn(ctx, tx, detail.ProjectId, *data.Status)
+			if err != nil {
+				return err
+			}
+		}
+
+		before := detail
+
+		err = applyUpdate(&detail, Update{Status: data.Status})
+		if err != nil {
+			return err
+		}
+
+		newRank, err := placeBetween(ctx, tx, detail, data.AfterId, data.BeforeId)
+		if err != nil {
+			return err
+		}
+		detail.Rank = &newRank
+		detail.Version++
+
+		_, err = tx.NewUpdate().Model(&detail).Where("id = ?", detail.Id).Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = notifyChanges(ctx, tx, before, detail)
+		if err != nil {
+			return err
+		}
+
+		return outbox.Append(ctx, tx, outbox.AggregateTask, detail.Id, outbox.EventTaskUpdated, detail)
+	})
+	if err != nil {
+		return entity.Tasks{}, err
+	}
+
+	return detail, nil
+}
+
+// enterColumn fails with ErrWipLimitExceeded when the column of status in
+// the project is at its WIP limit, for a task about to move into it. The
+// project is locked first, so moves into its columns are serialised and
+// tasks moved earlier in the same transaction are counted.
+func enterColumn(ctx context.Context, tx bun.Tx, projectId *int, status string) error {
+	if projectId == nil {
+		return nil
+	}
+
+	_, err := tx.ExecContext(ctx, "SELECT id FROM projects WHERE id = ? FOR UPDATE", *projectId)
+	if err != nil {
+		return err
+	}
+
+	return checkWipLimit(ctx, tx, *projectId, status)
+}
+
+func checkWipLimit(ctx context.Context, tx bun.Tx, projectId int, status string) error {
+
+	var limit, count int
+	err := tx.QueryRowContext(ctx, `
+		SELECT
+			l.wip_limit,
+			(SELECT COUNT(*) FROM tasks t WHERE t.project_id = l.project_id AND t.status = l.status AND t.deleted_at IS NULL)
+		FROM board_wip_limits l
+		WHERE l.project_id = ? AND l.status = ?`,
+		projectId, status,
+	).Scan(&limit, &count)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error checking WIP limit: %w", err)
+	}
+
+	if count >= limit {
+		return ErrWipLimitExceeded
+	}
+
+	return nil
+}
+
+// placeBetween returns a rank for task in its (new) column between the
+// given neighbours. A missing neighbour is taken from the column, so only
+// one of them needs to be sent.
+func placeBetween(ctx context.Context, tx bun.Tx, task entity.Tasks, afterId, beforeId *int) (string, error) {
+	for attempt := 0; ; attempt++ {
+		lower, upper, err := neighbourRanks(ctx, tx, task, afterId, beforeId)
+		if err != nil {
+			return "", err
+		}
+
+		result, err := rank.Between(lower, upper)
+		if err == nil && len(result) <= rank.MaxLength {
+			return result, nil
+		}
+		if attempt > 0 {
+			return "", fmt.Errorf("error ranking task: %w", err)
+		}
+
+		// neighbours tie or ranks grew too long, renumber the column once
+		err = renumber(ctx, tx, task)
+		if err != nil {
+			return "", err
+		}
+	}
+}
+
+func neighbourRanks(ctx context.Context, tx bun.Tx, task entity.Tasks, afterId, beforeId *int) (string, string, error) {
+	var lower, upper string
+	var err error
+
+	if afterId != nil {
+		lower, err = neighbourRank(ctx, tx, task, *afterId)
+		if err != nil {
+			return "", "", err
+		}
+	}
+	if beforeId != nil {
+		upper, err = neighbourRank(ctx, tx, task, *beforeId)
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	switch {
+	case afterId != nil && beforeId == nil:
+		upper, err = adjacentRank(ctx, tx, task, lower, true)
+	case afterId == nil && beforeId != nil:
+		lower, err = adjacentRank(ctx, tx, task, upper, false)
+	case afterId == nil && beforeId == nil:
+		lower, err = adjacentRank(ctx, tx, task, "", false)
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	return lower, upper, nil
+}
+
+func neighbourRank(ctx context.Context, tx bun.Tx, task entity.Tasks, id int) (string, error) {
+	if id == task.Id {
+		return "", ErrInvalidNeighbour
+	}
+
+	var result sql.NullString
+	err := tx.QueryRowContext(ctx, `
+		SELECT rank FROM tasks
+		WHERE id = ? AND project_id IS NOT DISTINCT FROM ? AND status = ? AND deleted_at IS NULL`,
+		id, task.ProjectId, *task.Status,
+	).Scan(&result)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrInvalidNeighbour
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return result.String, nil
+}
+
+// adjacentRank returns the rank right after (or before) the given one in
+// the task's column, the end (or start) of the column for an empty rank.
+func adjacentRank(ctx context.Context, tx bun.Tx, task entity.Tasks, from string, after bool) (string, error) {
+	query := `
+		SELECT rank FROM tasks
+		WHERE project_id IS NOT DISTINCT FROM ? AND status = ? AND deleted_at IS NULL AND id <> ? AND rank IS NOT NULL`
+	args := []interface{}{task.ProjectId, *task.Status, task.Id}
+
+	switch {
+	case after:
+		query += " AND rank > ? ORDER BY rank LIMIT 1"
+		args = append(args, from)
+	case from != "":
+		query += " AND rank < ? ORDER BY rank DESC LIMIT 1"
+		args = append(args, from)
+	default:
+		query += " ORDER BY rank DESC LIMIT 1"
+	}
+
+	var result string
+	err := tx.QueryRowContext(ctx, query, args...).Scan(&result)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+
+	return result, err
+}
+
+// appendRank returns a rank at the end of the task's column.
+func appendRank(ctx context.Context, tx bun.Tx, task entity.Tasks) (string, error) {
+	if task.Status == nil {
+		return "", nil
+	}
+
+	return placeBetween(ctx, tx, task, nil, nil)
+}
+
+// renumber spreads the ranks of the task's column evenly, keeping their
+// order. The task itself is left out, its rank is about to be replaced.
+func renumber(ctx context.Context, tx bun.Tx, task entity.Tasks) error {
+	var ids []int
+
+	err := tx.NewSelect().
+		Table("tasks").
+		Column("id").
+		Where("project_id IS NOT DISTINCT FROM ? AND status = ? AND deleted_at IS NULL AND id <> ?", task.ProjectId, *task.Status, task.Id).
+		OrderExpr("rank NULLS LAST, id").
+		Scan(ctx, &ids)
+	if err != nil {
+		return fmt.Errorf("error renumbering column: %w", err)
+	}
+
+	for i, r := range rank.Sequence(len(ids)) {
+		_, err = tx.ExecContext(ctx, "UPDATE tasks SET rank = ?, version = version + 1 WHERE id = ?", r, ids[i])
+		if err != nil {
+			return fmt.Errorf("error renumbering column: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,328 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"task-management2/internal/entity"
+	"task-management2/internal/pkg/validation"
+	basic_repo "task-management2/internal/repository/postgres/_basic_repo"
+	"task-management2/internal/repository/postgres/outbox"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// MaxBulkItems caps the tasks of one bulk request.
+const MaxBulkItems = 500
+
+var (
+	taskStatuses   = []string{"pending", "in_progress", "completed"}
+	taskPriorities = []string{"low", "medium", "high"}
+)
+
+// ValidStatus reports whether status is one a task can have.
+func ValidStatus(status string) bool {
+	return oneOf(status, taskStatuses)
+}
+
+// BulkError lists the items of a bulk request that failed validation.
+// Nothing is written when it is returned.
+type BulkError struct {
+	Items []BulkItemError
+}
+
+func (e *BulkError) Error() string {
+	return fmt.Sprintf("%d items failed validation, nothing was changed", len(e.Items))
+}
+
+// BulkUpdate applies data.Patch to, or deletes, all the given tasks in one
+// transaction. Every task is validated before anything is written.
+func (r Repository) BulkUpdate(ctx context.Context, data Bulk) ([]entity.Tasks, error) {
+	switch {
+	case len(data.Ids) == 0:
+		return nil, basic_repo.InvalidField("ids", "ids must not be empty")
+	case len(data.Ids) > MaxBulkItems:
+		return nil, basic_repo.InvalidField("ids", "at most %d tasks can be changed at once", MaxBulkItems)
+	case data.Delete == (data.Patch != nil):
+		return nil, basic_repo.Invalid("either patch or delete must be given")
+	}
+
+	if data.Patch != nil {
+		err := validatePatch(*data.Patch)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var result []entity.Tasks
+
+	err := r.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var found []entity.Tasks
+		err := tx.NewSelect().
+			Model(&found).
+			Where("id IN (?) AND deleted_at IS NULL", bun.In(data.Ids)).
+			Order("id").
+			For("UPDATE").
+			Scan(ctx)
+		if err != nil {
+			return err
+		}
+
+		byId := map[int]entity.Tasks{}
+		for _, task := range found {
+			byId[task.Id] = task
+		}
+
+		var items []BulkItemError
+		var tasks []entity.Tasks
+		seen := map[int]bool{}
+
+		for i, id := range data.Ids {
+			id := id
+
+			task, ok := byId[id]
+			if !ok {
+				items = append(items, BulkItemError{Index: i, Id: &id, Error: "task not found"})
+				continue
+			}
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			if data.Patch != nil {
+				err = applyPatch(&task, *data.Patch)
+				if err != nil {
+					items = append(items, BulkItemError{Index: i, Id: &id, Error: err.Error()})
+					continue
+				}
+			}
+
+			tasks = append(tasks, task)
+		}
+
+		if data.Patch != nil && len(items) == 0 {
+			err = checkReferences(ctx, tx, data.Patch.ProjectId, data.Patch.AssignedTo)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(items) > 0 {
+			return &BulkError{Items: items}
+		}
+
+		if data.Delete {
+			result, err = deleteTasks(ctx, tx, tasks)
+			return err
+		}
+
+		// the tasks saved before one are already in their new column, so
+		// tasks moving into the same column count against its WIP limit
+		// together
+		for i := range tasks {
+			err = saveUpdate(ctx, tx, byId[tasks[i].Id], &tasks[i])
+			if err != nil {
+				return err
+			}
+		}
+		result = tasks
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// BulkCreate creates all the given tasks in one transaction, or none of
+// them when any fails validation.
+func (r Repository) BulkCreate(ctx context.Context, data BulkCreate) ([]entity.Tasks, error) {
+	switch {
+	case len(data.Items) == 0:
+		return nil, basic_repo.InvalidField("items", "items must not be empty")
+	case len(data.Items) > MaxBulkItems:
+		return nil, basic_repo.InvalidField("items", "at most %d tasks can be created at once", MaxBulkItems)
+	}
+
+	var items []BulkItemError
+	for i, item := range data.Items {
+		err := validateBulkCreate(item)
+		if err != nil {
+			items = append(items, BulkItemError{Index: i, Error: err.Error()})
+		}
+	}
+	if len(items) > 0 {
+		return nil, &BulkError{Items: items}
+	}
+
+	var result []entity.Tasks
+
+	err := r.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		for i, item := range data.Items {
+			err := checkReferences(ctx, tx, item.ProjectId, item.AssignedTo)
+			if err != nil {
+				items = append(items, BulkItemError{Index: i, Error: err.Error()})
+			}
+		}
+		if len(items) > 0 {
+			return &BulkError{Items: items}
+		}
+
+		for _, item := range data.Items {
+			detail, err := createTask(ctx, tx, item)
+			if err != nil {
+				return err
+			}
+
+			result = append(result, detail)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func validateBulkCreate(data Create) error {
+	if data.Status != nil && !oneOf(*data.Status, taskStatuses) {
+		return basic_repo.InvalidField("status", "status must be one of pending, in_progress, completed")
+	}
+	if data.Priority != nil && !oneOf(*data.Priority, taskPriorities) {
+		return basic_repo.InvalidField("priority", "priority must be one of low, medium, high")
+	}
+
+	return validateCreate(data)
+}
+
+func validatePatch(patch BulkPatch) error {
+	if patch.Status != nil && !oneOf(*patch.Status, taskStatuses) {
+		return basic_repo.InvalidField("status", "status must be one of pending, in_progress, completed")
+	}
+	if patch.Priority != nil && !oneOf(*patch.Priority, taskPriorities) {
+		return basic_repo.InvalidField("priority", "priority must be one of low, medium, high")
+	}
+	if patch.DueDate != nil {
+		if _, err := time.Parse("2006-01-02", *patch.DueDate); err != nil {
+			return basic_repo.InvalidField("due_date", "invalid DueDate format: %v", err)
+		}
+	}
+	if patch.Labels != nil && (len(patch.AddLabels) > 0 || len(patch.RemoveLabels) > 0) {
+		return basic_repo.InvalidField("labels", "labels can't be combined with add_labels or remove_labels")
+	}
+
+	return nil
+}
+
+// applyPatch applies patch to a single task. Errors are about this task
+// only, the patch itself was checked by validatePatch.
+func applyPatch(detail *entity.Tasks, patch BulkPatch) error {
+	err := applyUpdate(detail, Update{
+		Status:     patch.Status,
+		Priority:   patch.Priority,
+		AssignedTo: patch.AssignedTo,
+		ProjectId:  patch.ProjectId,
+		DueDate:    patch.DueDate,
+		Labels:     patch.Labels,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(patch.AddLabels) > 0 || len(patch.RemoveLabels) > 0 {
+		remove := map[string]bool{}
+		for _, label := range normalizeLabels(patch.RemoveLabels) {
+			remove[label] = true
+		}
+
+		var labels []string
+		for _, label := range append(detail.Labels, patch.AddLabels...) {
+			if !remove[label] {
+				labels = append(labels, label)
+			}
+		}
+		detail.Labels = normalizeLabels(labels)
+	}
+
+	// dates read from the row are timestamps, patched ones plain dates
+	if detail.StartDate != nil && detail.DueDate != nil && *validation.Day(detail.DueDate) < *validation.Day(detail.StartDate) {
+		return basic_repo.InvalidField("due_date", "due_date is before the task's start_date")
+	}
+
+	return nil
+}
+
+// checkReferences makes sure the project and the assignee, when given,
+// exist. Foreign key errors would abort the whole transaction instead.
+func checkReferences(ctx context.Context, tx bun.Tx, projectId, assignedTo *int) error {
+	var exists bool
+
+	if projectId != nil {
+		err := tx.QueryRowContext(ctx,
+			"SELECT EXISTS (SELECT 1 FROM projects WHERE id = ? AND deleted_at IS NULL)",
+			*projectId,
+		).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return basic_repo.InvalidField("project_id", "project %d not found", *projectId)
+		}
+	}
+
+	if assignedTo != nil {
+		err := tx.QueryRowContext(ctx,
+			"SELECT EXISTS (SELECT 1 FROM users WHERE id = ? AND deleted_at IS NULL)",
+			*assignedTo,
+		).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return basic_repo.InvalidField("assigned_to", "user %d not found", *assignedTo)
+		}
+	}
+
+	return nil
+}
+
+func deleteTasks(ctx context.Context, tx bun.Tx, tasks []entity.Tasks) ([]entity.Tasks, error) {
+	now := time.Now()
+
+	for i := range tasks {
+		tasks[i].DeletedAt = &now
+		tasks[i].Version++
+
+		_, err := tx.NewUpdate().
+			Model((*entity.Tasks)(nil)).
+			Set("deleted_at = ?", now).
+			Set("version = version + 1").
+			Where("id = ?", tasks[i].Id).
+			Exec(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		err = outbox.Append(ctx, tx, outbox.AggregateTask, tasks[i].Id, outbox.EventTaskDeleted, tasks[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tasks, nil
+}
+
+func oneOf(s string, values []string) bool {
+	for _, v := range values {
+		if s == v {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,38 +1,76 @@
 package tasks
 
+import (
+	basic_repo "task-management2/internal/repository/postgres/_basic_repo"
+	"time"
+)
+
 type Filter struct {
-	Limit     *int
-	Offset    *int
-	ProjectId *int
+	Limit       *int
+	Offset      *int
+	ProjectId   *int
+	SprintId    *int
+	MilestoneId *int
+	Status      *string
+	AssignedTo  *int
+	Weighting   *string
+	Sparse      basic_repo.Sparse
 }
 
 type Create struct {
-	ProjectId   *int    `json:"project_id" bun:"project_id"`
-	Name        *string `json:"name" bun:"name"`
+	ProjectId   *int    `json:"project_id" bun:"project_id" validate:"required,min=1"`
+	Name        *string `json:"name" bun:"name" validate:"required,min=1,max=255"`
 	Description *string `json:"description" bun:"description"`
-	AssignedTo  *int    `json:"assigned_to" bun:"assigned_to"`
+	AssignedTo  *int    `json:"assigned_to" bun:"assigned_to" validate:"omitempty,min=1"`
 	Status      *string `json:"status" validate:"required,oneof=pending in_progress completed"`
 	Priority    *string `json:"priority" validate:"required,oneof=low medium high"`
-	DueDate     *string `json:"due_date" bun:"due_date"`
+	StartDate   *string `json:"start_date" bun:"start_date" validate:"omitempty,date"`
+	DueDate     *string `json:"due_date" bun:"due_date" validate:"required,date"`
+
+	OriginalEstimate  *int `json:"original_estimate" validate:"omitempty,min=0"`
+	RemainingEstimate *int `json:"remaining_estimate" validate:"omitempty,min=0"`
+	StoryPoints       *int `json:"story_points" validate:"omitempty,min=0"`
+
+	Labels []string `json:"labels" validate:"max=50,dive,max=50"`
+
+	// set by the recurrence scheduler only
+	RecurrenceId   *int    `json:"-"`
+	OccurrenceDate *string `json:"-"`
 }
 
 type Update struct {
 	Id          *int    `json:"id" form:"id"`
-	ProjectId   *int    `json:"project_id" bun:"project_id"`
-	Name        *string `json:"name" bun:"name"`
+	ProjectId   *int    `json:"project_id" bun:"project_id" validate:"omitempty,min=1"`
+	Name        *string `json:"name" bun:"name" validate:"omitempty,min=1,max=255"`
 	Description *string `json:"description" bun:"description"`
-	AssignedTo  *int    `json:"assigned_to" bun:"assigned_to"`
-	Status      *string `json:"status" validate:"required,oneof=pending in_progress completed"`
-	Priority    *string `json:"priority" validate:"required,oneof=low medium high"`
-	DueDate     *string `json:"due_date" bun:"due_date"`
+	AssignedTo  *int    `json:"assigned_to" bun:"assigned_to" validate:"omitempty,min=1"`
+	Status      *string `json:"status" validate:"omitempty,oneof=pending in_progress completed"`
+	Priority    *string `json:"priority" validate:"omitempty,oneof=low medium high"`
+	StartDate   *string `json:"start_date" bun:"start_date" validate:"omitempty,date"`
+	DueDate     *string `json:"due_date" bun:"due_date" validate:"omitempty,date"`
+
+	OriginalEstimate  *int `json:"original_estimate" validate:"omitempty,min=0"`
+	RemainingEstimate *int `json:"remaining_estimate" validate:"omitempty,min=0"`
+	StoryPoints       *int `json:"story_points" validate:"omitempty,min=0"`
+
+	// replaces the labels when set
+	Labels *[]string `json:"labels" validate:"omitempty,max=50,dive,max=50"`
+
+	// Version, when set, must be the task's current version.
+	Version *int `json:"-"`
 }
 
 type TaskStats struct {
-	TotalTasks      int     `json:"total_tasks"`
-	CompletedTasks  int     `json:"completed_tasks"`
-	InProgressTasks int     `json:"in_progress_tasks"`
-	PendingTasks    int     `json:"pending_tasks"`
-	Progress        float64 `json:"progress"`
+	TotalTasks        int     `json:"total_tasks"`
+	CompletedTasks    int     `json:"completed_tasks"`
+	InProgressTasks   int     `json:"in_progress_tasks"`
+	PendingTasks      int     `json:"pending_tasks"`
+	TotalPoints       int     `json:"total_points"`
+	CompletedPoints   int     `json:"completed_points"`
+	OriginalEstimate  int     `json:"original_estimate"`
+	RemainingEstimate int     `json:"remaining_estimate"`
+	Weighting         string  `json:"weighting"`
+	Progress          float64 `json:"progress"`
 }
 
 type List struct {
@@ -46,6 +84,11 @@ type List struct {
 	DueDate     string `json:"due_date"`
 }
 
+type DetailFilter struct {
+	Id     int
+	Sparse basic_repo.Sparse
+}
+
 type DetailUri struct {
 	Id int `uri:"id" binding:"required"`
 }
@@ -58,5 +101,85 @@ type Detail struct {
 	AssignedTo  *int    `json:"assigned_to" bun:"assigned_to"`
 	Status      *string `json:"status" bun:"status"`
 	Priority    *string `json:"priority" bun:"priority"`
+	StartDate   *string `json:"start_date" bun:"start_date"`
 	DueDate     *string `json:"due_date" bun:"due_date"`
+
+	OriginalEstimate  *int `json:"original_estimate" bun:"original_estimate"`
+	RemainingEstimate *int `json:"remaining_estimate" bun:"remaining_estimate"`
+	StoryPoints       *int `json:"story_points" bun:"story_points"`
+}
+
+type CalendarFilter struct {
+	From       string
+	To         string
+	Location   *time.Location
+	ProjectId  *int
+	AssignedTo *int
+}
+
+type CalendarDay struct {
+	Date  string `json:"date"`
+	Tasks []List `json:"tasks"`
+}
+
+type Calendar struct {
+	From     string        `json:"from"`
+	To       string        `json:"to"`
+	TimeZone string        `json:"time_zone"`
+	Days     []CalendarDay `json:"days"`
+}
+
+type Move struct {
+	Id       *int    `json:"id" form:"id"`
+	Status   *string `json:"status" binding:"required,oneof=pending in_progress completed"`
+	AfterId  *int    `json:"after_id"`
+	BeforeId *int    `json:"before_id"`
+}
+
+// BulkPatch is applied to every task of a bulk update. Labels replaces the
+// labels, AddLabels and RemoveLabels change them.
+type BulkPatch struct {
+	Status       *string   `json:"status"`
+	Priority     *string   `json:"priority"`
+	AssignedTo   *int      `json:"assigned_to"`
+	ProjectId    *int      `json:"project_id"`
+	DueDate      *string   `json:"due_date"`
+	Labels       *[]string `json:"labels"`
+	AddLabels    []string  `json:"add_labels"`
+	RemoveLabels []string  `json:"remove_labels"`
+}
+
+// Bulk either patches or deletes the given tasks.
+type Bulk struct {
+	Ids    []int      `json:"ids" binding:"required"`
+	Patch  *BulkPatch `json:"patch"`
+	Delete bool       `json:"delete"`
+}
+
+type BulkCreate struct {
+	Items []Create `json:"items" binding:"required" validate:"dive"`
+}
+
+type BulkItemError struct {
+	Index int    `json:"index"`
+	Id    *int   `json:"id,omitempty"`
+	Error string `json:"error"`
+}
+
+// Patchable are the fields of a task a merge patch may change.
+type Patchable struct {
+	ProjectId   *int    `json:"project_id" validate:"required,min=1"`
+	Name        *string `json:"name" validate:"required,min=1,max=255"`
+	Description *string `json:"description"`
+	AssignedTo  *int    `json:"assigned_to" validate:"omitempty,min=1"`
+	Status      *string `json:"status" validate:"required,oneof=pending in_progress completed"`
+	Priority    *string `json:"priority" validate:"required,oneof=low medium high"`
+	StartDate   *string `json:"start_date" validate:"omitempty,date"`
+	DueDate     *string `json:"due_date" validate:"omitempty,date"`
+
+	OriginalEstimate  *int `json:"original_estimate" validate:"omitempty,min=0"`
+	RemainingEstimate *int `json:"remaining_estimate" validate:"omitempty,min=0"`
+	StoryPoints       *int `json:"story_points" validate:"omitempty,min=0"`
+
+	Labels []string `json:"labels" validate:"max=50,dive,max=50"`
 }
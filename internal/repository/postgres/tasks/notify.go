@@ -0,0 +1,61 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"task-management2/internal/entity"
+	"task-management2/internal/repository/postgres/notifications"
+
+	"github.com/uptrace/bun"
+)
+
+// notifyChanges stores the notifications caused by a task write in the same
+// transaction: the new assignee is told about the assignment and watchers
+// about a status change. before is the zero value for a new task.
+func notifyChanges(ctx context.Context, tx bun.Tx, before, after entity.Tasks) error {
+	var items []entity.Notifications
+
+	if after.AssignedTo != nil && (before.AssignedTo == nil || *before.AssignedTo != *after.AssignedTo) {
+		items = append(items, notification(after, *after.AssignedTo, notifications.TypeTaskAssigned,
+			"You were assigned to "+value(after.Name),
+			fmt.Sprintf("Status: %s, priority: %s, due: %s", value(after.Status), value(after.Priority), value(after.DueDate)),
+		))
+	}
+
+	if before.Id != 0 && value(before.Status) != value(after.Status) {
+		watchers, err := notifications.WatcherIds(ctx, tx, after.Id)
+		if err != nil {
+			return err
+		}
+
+		for _, userId := range watchers {
+			items = append(items, notification(after, userId, notifications.TypeTaskStatusChanged,
+				value(after.Name)+" is now "+value(after.Status),
+				fmt.Sprintf("Status changed from %s to %s", value(before.Status), value(after.Status)),
+			))
+		}
+	}
+
+	return notifications.Insert(ctx, tx, items)
+}
+
+func notification(task entity.Tasks, userId int, notificationType, title, body string) entity.Notifications {
+	taskId := task.Id
+
+	return entity.Notifications{
+		UserId:    &userId,
+		Type:      &notificationType,
+		Title:     &title,
+		Body:      &body,
+		TaskId:    &taskId,
+		ProjectId: task.ProjectId,
+	}
+}
+
+func value(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
@@ -0,0 +1,95 @@
+package tasks
+
+import (
+	"context"
+	"task-management2/internal/entity"
+	"task-management2/internal/pkg/validation"
+	basic_repo "task-management2/internal/repository/postgres/_basic_repo"
+	"task-management2/internal/util/mergepatch"
+
+	"github.com/uptrace/bun"
+)
+
+// Patch applies a JSON merge patch to a task. Unlike Update, a member set
+// to null clears the field. The merged task is validated as a whole.
+func (r Repository) Patch(ctx context.Context, data basic_repo.MergePatch) (entity.Tasks, error) {
+	var detail entity.Tasks
+
+	err := r.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		err := tx.NewSelect().Model(&detail).Where("id = ? AND deleted_at IS NULL", *data.Id).For("UPDATE").Scan(ctx)
+		if err != nil {
+			return err
+		}
+
+		if data.Version != nil && detail.Version != *data.Version {
+			return basic_repo.ErrVersionMismatch
+		}
+
+		fields := patchable(detail)
+		if err := mergepatch.ApplyTo(&fields, data.Patch); err != nil {
+			return basic_repo.Invalid("invalid patch: %v", err)
+		}
+
+		if err := validatePatchable(fields); err != nil {
+			return err
+		}
+
+		if mergepatch.Has(data.Patch, "project_id") || mergepatch.Has(data.Patch, "assigned_to") {
+			err = checkReferences(ctx, tx, fields.ProjectId, fields.AssignedTo)
+			if err != nil {
+				return err
+			}
+		}
+
+		before := detail
+
+		detail.ProjectId = fields.ProjectId
+		detail.Name = fields.Name
+		detail.Description = fields.Description
+		detail.AssignedTo = fields.AssignedTo
+		detail.Status = fields.Status
+		detail.Priority = fields.Priority
+		detail.StartDate = fields.StartDate
+		detail.DueDate = fields.DueDate
+		detail.OriginalEstimate = fields.OriginalEstimate
+		detail.RemainingEstimate = fields.RemainingEstimate
+		detail.StoryPoints = fields.StoryPoints
+		detail.Labels = normalizeLabels(fields.Labels)
+
+		return saveUpdate(ctx, tx, before, &detail)
+	})
+	if err != nil {
+		return entity.Tasks{}, err
+	}
+
+	return detail, nil
+}
+
+func patchable(detail entity.Tasks) Patchable {
+	return Patchable{
+		ProjectId:         detail.ProjectId,
+		Name:              detail.Name,
+		Description:       detail.Description,
+		AssignedTo:        detail.AssignedTo,
+		Status:            detail.Status,
+		Priority:          detail.Priority,
+		StartDate:         validation.Day(detail.StartDate),
+		DueDate:           validation.Day(detail.DueDate),
+		OriginalEstimate:  detail.OriginalEstimate,
+		RemainingEstimate: detail.RemainingEstimate,
+		StoryPoints:       detail.StoryPoints,
+		Labels:            detail.Labels,
+	}
+}
+
+func validatePatchable(fields Patchable) error {
+	if err := validation.Struct(fields); err != nil {
+		return err
+	}
+
+	if fields.StartDate != nil && fields.DueDate != nil && *fields.DueDate < *fields.StartDate {
+		return basic_repo.InvalidField("due_date", "due_date must not be before start_date")
+	}
+
+	return nil
+}
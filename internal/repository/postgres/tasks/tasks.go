@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"github.com/uptrace/bun"
-	"math"
+	"github.com/uptrace/bun/dialect/pgdialect"
+	"strings"
 	"task-management2/internal/entity"
 	basic_repo "task-management2/internal/repository/postgres/_basic_repo"
+	"task-management2/internal/repository/postgres/outbox"
 	"time"
 )
 
@@ -18,29 +20,70 @@ func NewRepository(DB *bun.DB) *Repository {
 	return &Repository{DB: DB}
 }
 
+// columns are the fields of a task a read may select, relations the records
+// it may embed.
+var (
+	columns = []basic_repo.Column[entity.Tasks]{
+		{Name: "id", Expr: "t.id", Dest: func(t *entity.Tasks) []any { return []any{&t.Id} }, Key: true},
+		{Name: "project_id", Expr: "t.project_id", Dest: func(t *entity.Tasks) []any { return []any{&t.ProjectId} }},
+		{Name: "name", Expr: "t.name", Dest: func(t *entity.Tasks) []any { return []any{&t.Name} }},
+		{Name: "description", Expr: "t.description", Dest: func(t *entity.Tasks) []any { return []any{&t.Description} }},
+		{Name: "assigned_to", Expr: "t.assigned_to", Dest: func(t *entity.Tasks) []any { return []any{&t.AssignedTo} }},
+		{Name: "status", Expr: "t.status", Dest: func(t *entity.Tasks) []any { return []any{&t.Status} }},
+		{Name: "priority", Expr: "t.priority", Dest: func(t *entity.Tasks) []any { return []any{&t.Priority} }},
+		{Name: "start_date", Expr: "t.start_date", Dest: func(t *entity.Tasks) []any { return []any{&t.StartDate} }},
+		{Name: "due_date", Expr: "t.due_date", Dest: func(t *entity.Tasks) []any { return []any{&t.DueDate} }},
+		{Name: "original_estimate", Expr: "t.original_estimate", Dest: func(t *entity.Tasks) []any { return []any{&t.OriginalEstimate} }},
+		{Name: "remaining_estimate", Expr: "t.remaining_estimate", Dest: func(t *entity.Tasks) []any { return []any{&t.RemainingEstimate} }},
+		{Name: "story_points", Expr: "t.story_points", Dest: func(t *entity.Tasks) []any { return []any{&t.StoryPoints} }},
+		{Name: "recurrence_id", Expr: "t.recurrence_id", Dest: func(t *entity.Tasks) []any { return []any{&t.RecurrenceId} }},
+		{Name: "occurrence_date", Expr: "t.occurrence_date", Dest: func(t *entity.Tasks) []any { return []any{&t.OccurrenceDate} }},
+		{Name: "rank", Expr: "t.rank", Dest: func(t *entity.Tasks) []any { return []any{&t.Rank} }},
+		{Name: "sprint_id", Expr: "t.sprint_id", Dest: func(t *entity.Tasks) []any { return []any{&t.SprintId} }},
+		{Name: "milestone_id", Expr: "t.milestone_id", Dest: func(t *entity.Tasks) []any { return []any{&t.MilestoneId} }},
+		{Name: "labels", Expr: "t.labels", Dest: func(t *entity.Tasks) []any { return []any{pgdialect.Array(&t.Labels)} }},
+		{Name: "version", Expr: "t.version", Dest: func(t *entity.Tasks) []any { return []any{&t.Version} }, Key: true},
+		{Name: "created_at", Expr: "t.created_at", Dest: func(t *entity.Tasks) []any { return []any{&t.CreatedAt} }},
+		{Name: "updated_at", Expr: "t.updated_at", Dest: func(t *entity.Tasks) []any { return []any{&t.UpdateAt} }},
+		{Name: "deleted_at", Expr: "t.deleted_at", Dest: func(t *entity.Tasks) []any { return []any{&t.DeletedAt} }},
+	}
+
+	relations = []basic_repo.Column[entity.Tasks]{
+		{
+			Name: "assignee",
+			Expr: `CASE WHEN a.id IS NULL THEN NULL ELSE json_build_object(
+				'id', a.id, 'full_name', a.full_name, 'email', a.email, 'role', a.role) END`,
+			Join: "LEFT JOIN users a ON a.id = t.assigned_to",
+			Dest: func(t *entity.Tasks) []any { return []any{basic_repo.JSON(&t.Assignee)} },
+		},
+		{
+			Name: "project",
+			Expr: `CASE WHEN p.id IS NULL THEN NULL ELSE json_build_object(
+				'id', p.id, 'name', p.name, 'description', p.description, 'owner_id', p.owner_id) END`,
+			Join: "LEFT JOIN projects p ON p.id = t.project_id",
+			Dest: func(t *entity.Tasks) []any { return []any{basic_repo.JSON(&t.Project)} },
+		},
+	}
+)
+
 func (r Repository) GetAll(ctx context.Context, filter Filter) ([]entity.Tasks, int, error) {
+	selection, err := basic_repo.Select(columns, relations, filter.Sparse)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	baseQuery := `
 		WITH total_count AS (
 			SELECT COUNT(*) as total
 			FROM tasks t
-			LEFT JOIN users u ON u.id = t.assigned_to
 			WHERE t.deleted_at IS NULL
 			%s
 		)
 		SELECT 
-			t.id,
-			t.project_id,
-			t.name,
-			t.description,
-			t.assigned_to,
-			t.status,
-			t.priority,
-			t.due_date,
-			t.created_at,
-			t.deleted_at,
+			%s,
 			tc.total as total_count
 		FROM tasks t
-		LEFT JOIN users u ON u.id = t.assigned_to
+		%s
 		CROSS JOIN total_count tc
 		WHERE t.deleted_at IS NULL
 		%s
@@ -50,8 +93,23 @@ func (r Repository) GetAll(ctx context.Context, filter Filter) ([]entity.Tasks,
 	if filter.ProjectId != nil {
 		projectFilter = fmt.Sprintf("AND t.project_id = %d", *filter.ProjectId)
 	}
+	if filter.SprintId != nil {
+		projectFilter += fmt.Sprintf(" AND t.sprint_id = %d", *filter.SprintId)
+	}
+	if filter.MilestoneId != nil {
+		projectFilter += fmt.Sprintf(" AND t.milestone_id = %d", *filter.MilestoneId)
+	}
+	if filter.Status != nil {
+		if !ValidStatus(*filter.Status) {
+			return nil, 0, basic_repo.InvalidField("status", "invalid status: %s", *filter.Status)
+		}
+		projectFilter += fmt.Sprintf(" AND t.status = '%s'", *filter.Status)
+	}
+	if filter.AssignedTo != nil {
+		projectFilter += fmt.Sprintf(" AND t.assigned_to = %d", *filter.AssignedTo)
+	}
 
-	query := fmt.Sprintf(baseQuery, projectFilter, projectFilter)
+	query := fmt.Sprintf(baseQuery, projectFilter, selection.Columns(), selection.Joins(), projectFilter)
 
 	if filter.Offset != nil {
 		query += fmt.Sprintf(" OFFSET %d", *filter.Offset)
@@ -62,7 +120,7 @@ func (r Repository) GetAll(ctx context.Context, filter Filter) ([]entity.Tasks,
 
 	rows, err := r.QueryContext(ctx, query)
 	if err != nil {
-		return nil, 0, fmt.Errorf("error querying tasks: %v", err)
+		return nil, 0, fmt.Errorf("error querying tasks: %w", err)
 	}
 	defer rows.Close()
 
@@ -72,77 +130,203 @@ func (r Repository) GetAll(ctx context.Context, filter Filter) ([]entity.Tasks,
 	for rows.Next() {
 		var task entity.Tasks
 
-		err := rows.Scan(
-			&task.Id,
-			&task.ProjectId,
-			&task.Name,
-			&task.Description,
-			&task.AssignedTo,
-			&task.Status,
-			&task.Priority,
-			&task.DueDate,
-			&task.CreatedAt,
-			&task.DeletedAt,
-			&totalCount,
-		)
+		err := selection.Scan(rows, &task, &totalCount)
 		if err != nil {
-			return nil, 0, fmt.Errorf("error scanning task row: %v", err)
+			return nil, 0, fmt.Errorf("error scanning task row: %w", err)
 		}
 
 		result = append(result, task)
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, 0, fmt.Errorf("error iterating task rows: %v", err)
+		return nil, 0, fmt.Errorf("error iterating task rows: %w", err)
 	}
 
 	return result, totalCount, nil
 }
 
 func (r Repository) GetTaskStats(ctx context.Context, filter Filter) (TaskStats, error) {
-	query := `
-		SELECT 
-			COUNT(*) as total_tasks,
-			COUNT(CASE WHEN status = 'completed' THEN 1 END) as completed_tasks,
-			COUNT(CASE WHEN status = 'pending' THEN 1 END) as pending_tasks,
-			COUNT(CASE WHEN status = 'in_progress' THEN 1 END) as in_progress_tasks
-		FROM tasks
-		WHERE deleted_at IS NULL
-	`
+	weighting := basic_repo.WeightingCount
+	if filter.Weighting != nil {
+		weighting = *filter.Weighting
+	}
+	if !basic_repo.ValidWeighting(weighting) {
+		return TaskStats{}, basic_repo.InvalidField("weighting", "invalid weighting: %s", weighting)
+	}
 
+	whereClause := ""
 	if filter.ProjectId != nil {
-		query += fmt.Sprintf(" AND project_id = %d", *filter.ProjectId)
+		whereClause = fmt.Sprintf(" AND project_id = %d", *filter.ProjectId)
+	}
+	if filter.SprintId != nil {
+		whereClause += fmt.Sprintf(" AND sprint_id = %d", *filter.SprintId)
+	}
+	if filter.MilestoneId != nil {
+		whereClause += fmt.Sprintf(" AND milestone_id = %d", *filter.MilestoneId)
+	}
+	if filter.Status != nil {
+		if !ValidStatus(*filter.Status) {
+			return TaskStats{}, basic_repo.InvalidField("status", "invalid status: %s", *filter.Status)
+		}
+		whereClause += fmt.Sprintf(" AND status = '%s'", *filter.Status)
+	}
+	if filter.AssignedTo != nil {
+		whereClause += fmt.Sprintf(" AND assigned_to = %d", *filter.AssignedTo)
 	}
 
-	var stats TaskStats
-	var totalTasks, completedTasks, pendingTasks, inProgressTasks int
+	query := fmt.Sprintf(`
+		WITH task_stats AS (
+			SELECT %s
+			FROM tasks
+			WHERE deleted_at IS NULL%s
+		)
+		SELECT 
+			ts.total_tasks,
+			ts.completed_tasks,
+			ts.pending_tasks,
+			ts.in_progress_tasks,
+			ts.total_points,
+			ts.completed_points,
+			ts.original_estimate,
+			ts.remaining_estimate,
+			%s as progress
+		FROM task_stats ts
+	`, basic_repo.TaskStatsColumns, whereClause, basic_repo.ProgressExpr(weighting, "ts"))
+
+	stats := TaskStats{Weighting: weighting}
 
 	err := r.QueryRowContext(ctx, query).Scan(
-		&totalTasks,
-		&completedTasks,
-		&pendingTasks,
-		&inProgressTasks,
+		&stats.TotalTasks,
+		&stats.CompletedTasks,
+		&stats.PendingTasks,
+		&stats.InProgressTasks,
+		&stats.TotalPoints,
+		&stats.CompletedPoints,
+		&stats.OriginalEstimate,
+		&stats.RemainingEstimate,
+		&stats.Progress,
 	)
 	if err != nil {
-		return TaskStats{}, fmt.Errorf("error getting task stats: %v", err)
+		return TaskStats{}, fmt.Errorf("error getting task stats: %w", err)
 	}
 
-	stats = TaskStats{
-		TotalTasks:      totalTasks,
-		CompletedTasks:  completedTasks,
-		PendingTasks:    pendingTasks,
-		InProgressTasks: inProgressTasks,
+	return stats, nil
+}
+
+// GetCalendar returns the tasks due between filter.From and filter.To
+// (inclusive, YYYY-MM-DD) grouped by due day. Every day of the range is
+// present in the result, including days without tasks.
+func (r Repository) GetCalendar(ctx context.Context, filter CalendarFilter) (Calendar, error) {
+	const layout = "2006-01-02"
+
+	from, err := time.Parse(layout, filter.From)
+	if err != nil {
+		return Calendar{}, basic_repo.InvalidField("from", "invalid from date: %v", err)
+	}
+	to, err := time.Parse(layout, filter.To)
+	if err != nil {
+		return Calendar{}, basic_repo.InvalidField("to", "invalid to date: %v", err)
 	}
 
-	if stats.TotalTasks > 0 {
-		completedProgress := float64(stats.CompletedTasks) * 100.0
-		inProgressProgress := float64(stats.InProgressTasks) * 50.0
-		totalPossibleProgress := float64(stats.TotalTasks) * 100.0
+	query := `
+		SELECT 
+			t.id,
+			t.name,
+			COALESCE(t.description, ''),
+			t.project_id,
+			COALESCE(t.assigned_to, 0),
+			COALESCE(t.status, ''),
+			COALESCE(t.priority, ''),
+			to_char(t.due_date::date, 'YYYY-MM-DD')
+		FROM tasks t
+		WHERE t.deleted_at IS NULL
+		AND t.due_date::date BETWEEN ?::date AND ?::date`
 
-		stats.Progress = math.Round((completedProgress+inProgressProgress)/totalPossibleProgress*1000) / 10
+	params := []interface{}{filter.From, filter.To}
+
+	if filter.ProjectId != nil {
+		query += " AND t.project_id = ?"
+		params = append(params, *filter.ProjectId)
+	}
+	if filter.AssignedTo != nil {
+		query += " AND t.assigned_to = ?"
+		params = append(params, *filter.AssignedTo)
 	}
 
-	return stats, nil
+	query += " ORDER BY t.due_date, t.id"
+
+	rows, err := r.QueryContext(ctx, query, params...)
+	if err != nil {
+		return Calendar{}, fmt.Errorf("error querying task calendar: %w", err)
+	}
+	defer rows.Close()
+
+	byDay := make(map[string][]List)
+	for rows.Next() {
+		var item List
+
+		err := rows.Scan(
+			&item.Id,
+			&item.Name,
+			&item.Description,
+			&item.ProjectId,
+			&item.AssignedTo,
+			&item.Status,
+			&item.Priority,
+			&item.DueDate,
+		)
+		if err != nil {
+			return Calendar{}, fmt.Errorf("error scanning task calendar row: %w", err)
+		}
+
+		byDay[item.DueDate] = append(byDay[item.DueDate], item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return Calendar{}, fmt.Errorf("error iterating task calendar rows: %w", err)
+	}
+
+	result := Calendar{
+		From: filter.From,
+		To:   filter.To,
+		Days: []CalendarDay{},
+	}
+	if filter.Location != nil {
+		result.TimeZone = filter.Location.String()
+	}
+
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		date := day.Format(layout)
+		items := byDay[date]
+		if items == nil {
+			items = []List{}
+		}
+		result.Days = append(result.Days, CalendarDay{Date: date, Tasks: items})
+	}
+
+	return result, nil
+}
+
+// GetDetail reads the fields and relations of a task filter.Sparse asks
+// for.
+func (r Repository) GetDetail(ctx context.Context, filter DetailFilter) (entity.Tasks, error) {
+	selection, err := basic_repo.Select(columns, relations, filter.Sparse)
+	if err != nil {
+		return entity.Tasks{}, err
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM tasks t
+		%s
+		WHERE t.id = ? AND t.deleted_at IS NULL`, selection.Columns(), selection.Joins())
+
+	var detail entity.Tasks
+	if err := selection.Scan(r.QueryRowContext(ctx, query, filter.Id), &detail); err != nil {
+		return entity.Tasks{}, fmt.Errorf("error getting task: %w", err)
+	}
+
+	return detail, nil
 }
 
 func (r Repository) GetById(ctx context.Context, id int) (entity.Tasks, error) {
@@ -153,7 +337,7 @@ func (r Repository) GetById(ctx context.Context, id int) (entity.Tasks, error) {
 		Scan(ctx)
 
 	if err != nil {
-		return entity.Tasks{}, fmt.Errorf("error getting task: %v", err)
+		return entity.Tasks{}, fmt.Errorf("error getting task: %w", err)
 	}
 
 	return detail, nil
@@ -162,23 +346,107 @@ func (r Repository) GetById(ctx context.Context, id int) (entity.Tasks, error) {
 func (r Repository) Create(ctx context.Context, data Create) (entity.Tasks, error) {
 	var detail entity.Tasks
 
+	err := validateCreate(data)
+	if err != nil {
+		return entity.Tasks{}, err
+	}
+
+	err = r.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		err := checkReferences(ctx, tx, data.ProjectId, data.AssignedTo)
+		if err != nil {
+			return err
+		}
+
+		detail, err = createTask(ctx, tx, data)
+		return err
+	})
+	if err != nil {
+		return entity.Tasks{}, fmt.Errorf("error creating task: %w", err)
+	}
+
+	return detail, nil
+}
+
+// validateCreate checks what can be checked without the database.
+func validateCreate(data Create) error {
 	const layout = "2006-01-02"
+
+	if data.ProjectId == nil {
+		return basic_repo.InvalidField("project_id", "project_id is required")
+	}
+	if data.Name == nil || *data.Name == "" {
+		return basic_repo.InvalidField("name", "name is required")
+	}
+	if data.DueDate == nil {
+		return basic_repo.InvalidField("due_date", "due_date is required")
+	}
 	_, err := time.Parse(layout, *data.DueDate)
 	if err != nil {
-		return entity.Tasks{}, fmt.Errorf("invalid DueDate format: %v", err)
+		return basic_repo.InvalidField("due_date", "invalid DueDate format: %v", err)
+	}
+
+	if data.StartDate != nil {
+		_, err = time.Parse(layout, *data.StartDate)
+		if err != nil {
+			return basic_repo.InvalidField("start_date", "invalid StartDate format: %v", err)
+		}
 	}
 
+	return validateEffort(newTask(data))
+}
+
+func newTask(data Create) entity.Tasks {
+	var detail entity.Tasks
+
 	detail.ProjectId = data.ProjectId
 	detail.Name = data.Name
 	detail.Description = data.Description
 	detail.AssignedTo = data.AssignedTo
 	detail.Status = data.Status
 	detail.Priority = data.Priority
+	detail.StartDate = data.StartDate
 	detail.DueDate = data.DueDate
+	detail.OriginalEstimate = data.OriginalEstimate
+	detail.RemainingEstimate = data.RemainingEstimate
+	detail.StoryPoints = data.StoryPoints
+	detail.RecurrenceId = data.RecurrenceId
+	detail.OccurrenceDate = data.OccurrenceDate
+	detail.Labels = normalizeLabels(data.Labels)
+
+	// remaining work starts out as the whole estimate
+	if detail.RemainingEstimate == nil {
+		detail.RemainingEstimate = detail.OriginalEstimate
+	}
+
+	return detail
+}
+
+// createTask inserts a task validated by validateCreate within tx.
+func createTask(ctx context.Context, tx bun.Tx, data Create) (entity.Tasks, error) {
+	detail := newTask(data)
+	detail.Version = 1
 
-	_, err = r.NewInsert().Model(&detail).Exec(ctx)
+	newRank, err := appendRank(ctx, tx, detail)
 	if err != nil {
-		return entity.Tasks{}, fmt.Errorf("error creating task: %v", err)
+		return entity.Tasks{}, err
+	}
+	if newRank != "" {
+		detail.Rank = &newRank
+	}
+
+	_, err = tx.NewInsert().Model(&detail).Exec(ctx)
+	if err != nil {
+		return entity.Tasks{}, err
+	}
+
+	err = notifyChanges(ctx, tx, entity.Tasks{}, detail)
+	if err != nil {
+		return entity.Tasks{}, err
+	}
+
+	err = outbox.Append(ctx, tx, outbox.AggregateTask, detail.Id, outbox.EventTaskCreated, detail)
+	if err != nil {
+		return entity.Tasks{}, err
 	}
 
 	return detail, nil
@@ -187,11 +455,81 @@ func (r Repository) Create(ctx context.Context, data Create) (entity.Tasks, erro
 func (r Repository) Update(ctx context.Context, data Update) (entity.Tasks, error) {
 	var detail entity.Tasks
 
-	err := r.NewSelect().Model(&detail).Where("id = ?", data.Id).Scan(ctx)
+	err := r.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		err := tx.NewSelect().Model(&detail).Where("id = ? AND deleted_at IS NULL", data.Id).For("UPDATE").Scan(ctx)
+		if err != nil {
+			return err
+		}
+
+		if data.Version != nil && detail.Version != *data.Version {
+			return basic_repo.ErrVersionMismatch
+		}
+
+		err = checkReferences(ctx, tx, data.ProjectId, data.AssignedTo)
+		if err != nil {
+			return err
+		}
+
+		before := detail
+
+		err = applyUpdate(&detail, data)
+		if err != nil {
+			return err
+		}
+
+		return saveUpdate(ctx, tx, before, &detail)
+	})
 	if err != nil {
 		return entity.Tasks{}, err
 	}
 
+	return detail, nil
+}
+
+// saveUpdate writes the changes applyUpdate made to detail, before is the
+// task as it was read.
+func saveUpdate(ctx context.Context, tx bun.Tx, before entity.Tasks, detail *entity.Tasks) error {
+	// sprints and milestones belong to the old project
+	if !sameProject(before.ProjectId, detail.ProjectId) {
+		detail.SprintId = nil
+		detail.MilestoneId = nil
+	}
+
+	// a task that changes column goes to the end of the new one
+	if value(before.Status) != value(detail.Status) || !sameProject(before.ProjectId, detail.ProjectId) {
+		if detail.Status != nil {
+			err := enterColumn(ctx, tx, detail.ProjectId, *detail.Status)
+			if err != nil {
+				return err
+			}
+		}
+
+		newRank, err := appendRank(ctx, tx, *detail)
+		if err != nil {
+			return err
+		}
+		if newRank != "" {
+			detail.Rank = &newRank
+		}
+	}
+
+	detail.Version++
+
+	_, err := tx.NewUpdate().Model(detail).Where("id = ?", detail.Id).Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = notifyChanges(ctx, tx, before, *detail)
+	if err != nil {
+		return err
+	}
+
+	return outbox.Append(ctx, tx, outbox.AggregateTask, detail.Id, outbox.EventTaskUpdated, *detail)
+}
+
+// applyUpdate copies the fields set in data onto detail.
+func applyUpdate(detail *entity.Tasks, data Update) error {
 	if data.Status != nil {
 		detail.Status = data.Status
 	}
@@ -210,11 +548,115 @@ func (r Repository) Update(ctx context.Context, data Update) (entity.Tasks, erro
 	if data.Priority != nil {
 		detail.Priority = data.Priority
 	}
+	if data.StartDate != nil {
+		if _, err := time.Parse("2006-01-02", *data.StartDate); err != nil {
+			return basic_repo.InvalidField("start_date", "invalid StartDate format: %v", err)
+		}
+		detail.StartDate = data.StartDate
+	}
 	if data.DueDate != nil {
+		if _, err := time.Parse("2006-01-02", *data.DueDate); err != nil {
+			return basic_repo.InvalidField("due_date", "invalid DueDate format: %v", err)
+		}
 		detail.DueDate = data.DueDate
 	}
+	if data.OriginalEstimate != nil {
+		if detail.RemainingEstimate == nil && data.RemainingEstimate == nil {
+			detail.RemainingEstimate = data.OriginalEstimate
+		}
+		detail.OriginalEstimate = data.OriginalEstimate
+	}
+	if data.RemainingEstimate != nil {
+		detail.RemainingEstimate = data.RemainingEstimate
+	}
+	if data.StoryPoints != nil {
+		detail.StoryPoints = data.StoryPoints
+	}
+	if data.Labels != nil {
+		detail.Labels = normalizeLabels(*data.Labels)
+	}
+
+	// completing a task burns down whatever work was left on it
+	if data.Status != nil && *data.Status == "completed" && data.RemainingEstimate == nil && detail.RemainingEstimate != nil {
+		zero := 0
+		detail.RemainingEstimate = &zero
+	}
+
+	return validateEffort(*detail)
+}
+
+func (r Repository) Delete(ctx context.Context, data basic_repo.Delete) error {
+	return r.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var detail entity.Tasks
 
-	_, err = r.NewUpdate().Model(&detail).Where("id = ?", detail.Id).Exec(ctx)
+		err := tx.NewSelect().Model(&detail).Where("id = ? AND deleted_at IS NULL", *data.Id).For("UPDATE").Scan(ctx)
+		if err != nil {
+			return err
+		}
+
+		if data.Version != nil && detail.Version != *data.Version {
+			return basic_repo.ErrVersionMismatch
+		}
+
+		now := time.Now()
+		detail.DeletedAt = &now
+		detail.Version++
+
+		_, err = tx.NewUpdate().
+			Model((*entity.Tasks)(nil)).
+			Set("deleted_at = ?", now).
+			Set("version = version + 1").
+			Where("id = ?", detail.Id).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		return outbox.Append(ctx, tx, outbox.AggregateTask, detail.Id, outbox.EventTaskDeleted, detail)
+	})
+}
+
+// ErrProjectDeleted is returned when restoring a task of a deleted
+// project, the project has to be restored first.
+var ErrProjectDeleted = basic_repo.Conflict("the task's project is deleted, restore the project instead")
+
+// Restore brings back a deleted task. Consumers see it as created again.
+func (r Repository) Restore(ctx context.Context, id int) (entity.Tasks, error) {
+	var detail entity.Tasks
+
+	err := r.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		err := tx.NewSelect().Model(&detail).Where("id = ? AND deleted_at IS NOT NULL", id).For("UPDATE").Scan(ctx)
+		if err != nil {
+			return err
+		}
+
+		if detail.ProjectId != nil {
+			var deleted bool
+			err = tx.QueryRowContext(ctx,
+				"SELECT deleted_at IS NOT NULL FROM projects WHERE id = ?",
+				*detail.ProjectId,
+			).Scan(&deleted)
+			if err != nil {
+				return err
+			}
+			if deleted {
+				return ErrProjectDeleted
+			}
+		}
+
+		now := time.Now()
+		detail.DeletedAt = nil
+		detail.UpdateAt = &now
+		detail.CascadeDeleted = false
+		detail.Version++
+
+		_, err = tx.NewUpdate().Model(&detail).Where("id = ?", detail.Id).Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		return outbox.Append(ctx, tx, outbox.AggregateTask, detail.Id, outbox.EventTaskCreated, detail)
+	})
 	if err != nil {
 		return entity.Tasks{}, err
 	}
@@ -222,6 +664,43 @@ func (r Repository) Update(ctx context.Context, data Update) (entity.Tasks, erro
 	return detail, nil
 }
 
-func (r Repository) Delete(ctx context.Context, data basic_repo.Delete) error {
-	return basic_repo.BasicDelete(ctx, data, &entity.Tasks{}, r.DB)
+// normalizeLabels trims labels and drops empty and repeated ones, keeping
+// their order.
+func normalizeLabels(labels []string) []string {
+	result := []string{}
+	seen := map[string]bool{}
+
+	for _, label := range labels {
+		label = strings.TrimSpace(label)
+		if label == "" || seen[label] {
+			continue
+		}
+
+		seen[label] = true
+		result = append(result, label)
+	}
+
+	return result
+}
+
+func sameProject(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
+
+func validateEffort(detail entity.Tasks) error {
+	if detail.OriginalEstimate != nil && *detail.OriginalEstimate < 0 {
+		return basic_repo.InvalidField("original_estimate", "original_estimate must not be negative")
+	}
+	if detail.RemainingEstimate != nil && *detail.RemainingEstimate < 0 {
+		return basic_repo.InvalidField("remaining_estimate", "remaining_estimate must not be negative")
+	}
+	if detail.StoryPoints != nil && *detail.StoryPoints < 0 {
+		return basic_repo.InvalidField("story_points", "story_points must not be negative")
+	}
+
+	return nil
 }
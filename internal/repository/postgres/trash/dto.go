@@ -0,0 +1,34 @@
+package trash
+
+import "time"
+
+// Record types in the trash.
+const (
+	TypeUser    = "user"
+	TypeProject = "project"
+	TypeTask    = "task"
+)
+
+type Filter struct {
+	Limit  *int
+	Offset *int
+	Type   *string
+}
+
+type Item struct {
+	Type      string    `json:"type"`
+	Id        int       `json:"id"`
+	Name      string    `json:"name"`
+	ProjectId *int      `json:"project_id"`
+	DeletedAt time.Time `json:"deleted_at"`
+	// WithProject is set on tasks deleted along with their project, they
+	// come back when the project is restored.
+	WithProject bool       `json:"with_project"`
+	PurgeAt     *time.Time `json:"purge_at"`
+}
+
+type Purged struct {
+	Users    int64 `json:"users"`
+	Projects int64 `json:"projects"`
+	Tasks    int64 `json:"tasks"`
+}
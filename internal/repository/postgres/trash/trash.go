@@ -0,0 +1,155 @@
+package trash
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	basic_repo "task-management2/internal/repository/postgres/_basic_repo"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+type Repository struct {
+	*bun.DB
+}
+
+func NewRepository(DB *bun.DB) *Repository {
+	return &Repository{DB: DB}
+}
+
+var trashQueries = map[string]string{
+	TypeUser: `
+		SELECT 'user' as type, id, COALESCE(full_name, '') as name, NULL::int as project_id, deleted_at, false as with_project
+		FROM users WHERE deleted_at IS NOT NULL`,
+	TypeProject: `
+		SELECT 'project' as type, id, COALESCE(name, '') as name, NULL::int as project_id, deleted_at, false as with_project
+		FROM projects WHERE deleted_at IS NOT NULL`,
+	TypeTask: `
+		SELECT 'task' as type, id, COALESCE(name, '') as name, project_id, deleted_at, cascade_deleted as with_project
+		FROM tasks WHERE deleted_at IS NOT NULL`,
+}
+
+func ValidType(t string) bool {
+	_, ok := trashQueries[t]
+	return ok
+}
+
+// GetAll lists deleted users, projects and tasks, most recently deleted
+// first.
+func (r Repository) GetAll(ctx context.Context, filter Filter) ([]Item, int, error) {
+	var parts []string
+	for _, t := range []string{TypeUser, TypeProject, TypeTask} {
+		if filter.Type == nil || *filter.Type == t {
+			parts = append(parts, trashQueries[t])
+		}
+	}
+	if len(parts) == 0 {
+		return nil, 0, basic_repo.InvalidField("type", "unknown type: %s", *filter.Type)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT type, id, name, project_id, deleted_at, with_project, COUNT(*) OVER() as total_count
+		FROM (%s) trash
+		ORDER BY deleted_at DESC, type, id`, strings.Join(parts, "\n\t\tUNION ALL"))
+
+	var params []interface{}
+	if filter.Limit != nil {
+		query += " LIMIT ?"
+		params = append(params, *filter.Limit)
+	}
+	if filter.Offset != nil {
+		query += " OFFSET ?"
+		params = append(params, *filter.Offset)
+	}
+
+	rows, err := r.QueryContext(ctx, query, params...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error querying trash: %w", err)
+	}
+	defer rows.Close()
+
+	result := []Item{}
+	var totalCount int
+
+	for rows.Next() {
+		var item Item
+
+		err := rows.Scan(
+			&item.Type,
+			&item.Id,
+			&item.Name,
+			&item.ProjectId,
+			&item.DeletedAt,
+			&item.WithProject,
+			&totalCount,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error scanning trash row: %w", err)
+		}
+
+		result = append(result, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating trash rows: %w", err)
+	}
+
+	return result, totalCount, nil
+}
+
+// Purge hard deletes the records deleted before the given time. Foreign keys
+// cascade, so records that live ones still depend on are kept: projects
+// with live tasks, and users who own a project or logged work or commented
+// on live tasks. They are purged once that is no longer the case.
+func (r Repository) Purge(ctx context.Context, before time.Time) (Purged, error) {
+	var result Purged
+
+	err := r.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		res, err := tx.ExecContext(ctx,
+			"DELETE FROM tasks WHERE deleted_at < ?",
+			before,
+		)
+		if err != nil {
+			return fmt.Errorf("error purging tasks: %w", err)
+		}
+		result.Tasks, _ = res.RowsAffected()
+
+		res, err = tx.ExecContext(ctx, `
+			DELETE FROM projects p
+			WHERE p.deleted_at < ?
+				AND NOT EXISTS (SELECT 1 FROM tasks t WHERE t.project_id = p.id AND t.deleted_at IS NULL)`,
+			before,
+		)
+		if err != nil {
+			return fmt.Errorf("error purging projects: %w", err)
+		}
+		result.Projects, _ = res.RowsAffected()
+
+		res, err = tx.ExecContext(ctx, `
+			DELETE FROM users u
+			WHERE u.deleted_at < ?
+				AND NOT EXISTS (SELECT 1 FROM projects p WHERE p.owner_id = u.id)
+				AND NOT EXISTS (
+					SELECT 1 FROM worklogs w JOIN tasks t ON t.id = w.task_id
+					WHERE w.user_id = u.id AND t.deleted_at IS NULL
+				)
+				AND NOT EXISTS (
+					SELECT 1 FROM task_comments c JOIN tasks t ON t.id = c.task_id
+					WHERE c.user_id = u.id AND t.deleted_at IS NULL
+				)`,
+			before,
+		)
+		if err != nil {
+			return fmt.Errorf("error purging users: %w", err)
+		}
+		result.Users, _ = res.RowsAffected()
+
+		return nil
+	})
+	if err != nil {
+		return Purged{}, err
+	}
+
+	return result, nil
+}
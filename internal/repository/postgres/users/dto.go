@@ -1,25 +1,37 @@
 package users
 
-import "time"
+import (
+	"task-management2/internal/entity"
+	basic_repo "task-management2/internal/repository/postgres/_basic_repo"
+	"time"
+)
 
 type Filter struct {
-	Limit  *int
-	Offset *int
+	Limit  *int              `form:"limit"`
+	Offset *int              `form:"offset"`
+	Sparse basic_repo.Sparse `form:"-"`
+}
+
+type DetailFilter struct {
+	Id     int
+	Sparse basic_repo.Sparse
 }
 
 type Create struct {
-	FullName *string `json:"full_name" bun:"full_name"`
-	Email    *string `json:"email" bun:"username,unique,notnull"`
+	FullName *string `json:"full_name" bun:"full_name" validate:"required,min=1,max=255"`
+	Email    *string `json:"email" bun:"username,unique,notnull" validate:"required,email,max=255"`
 	Role     *string `json:"role" validate:"required,oneof=manager worker"`
-	Password *string `json:"password" bun:"password"`
+	Password *string `json:"password" bun:"password" validate:"required,max=255"`
 }
 
 type Update struct {
 	Id       *int    `json:"id" form:"id"`
-	FullName *string `json:"full_name" bun:"full_name"`
-	Email    *string `json:"email" bun:"username,unique,notnull"`
-	Role     *string `json:"role" bun:"role"`
-	Password *string `json:"password" bun:"password"`
+	FullName *string `json:"full_name" bun:"full_name" validate:"omitempty,min=1,max=255"`
+	Email    *string `json:"email" bun:"username,unique,notnull" validate:"omitempty,email,max=255"`
+	Role     *string `json:"role" bun:"role" validate:"omitempty,oneof=manager worker"`
+	Password *string `json:"password" bun:"password" validate:"omitempty,max=255"`
+	// Version, when set, must be the user's current version.
+	Version *int `json:"-"`
 }
 
 type User struct {
@@ -29,13 +41,6 @@ type User struct {
 	Role     *string `json:"role"`
 }
 
-type TaskStats struct {
-	PendingTasks    *int `json:"pending_tasks"`
-	InProgressTasks *int `json:"in_progress_tasks"`
-	CompletedTasks  *int `json:"completed_tasks"`
-	TaskCount       *int `json:"task_count"`
-}
-
 type List struct {
 	Id              *int64  `json:"id"`
 	FullName        *string `json:"full_name"`
@@ -45,6 +50,9 @@ type List struct {
 	InProgressTasks *int    `json:"in_progress_tasks"`
 	CompletedTasks  *int    `json:"completed_tasks"`
 	TaskCount       *int    `json:"task_count"`
+
+	// embedded on request only
+	Projects *[]entity.ProjectRef `json:"projects,omitempty"`
 }
 
 type TaskItem struct {
@@ -66,7 +74,27 @@ type Detail struct {
 	InProgressTasks *int        `json:"in_progress_tasks"`
 	CompletedTasks  *int        `json:"completed_tasks"`
 	TaskCount       *int        `json:"task_count"`
+	Version         int         `json:"version"`
 	CreatedAt       *string     `json:"created_at"`
 	UpdatedAt       *string     `json:"updated_at"`
 	Tasks           *[]TaskItem `json:"tasks"`
+
+	// embedded on request only
+	Projects *[]entity.ProjectRef `json:"projects,omitempty"`
+}
+
+type UserEvent struct {
+	Id       int     `json:"id"`
+	FullName *string `json:"full_name,omitempty"`
+	Email    *string `json:"email,omitempty"`
+	Role     *string `json:"role,omitempty"`
+}
+
+// Patchable are the fields of a user a merge patch may change. Password is
+// write-only, it is never part of the merged document.
+type Patchable struct {
+	FullName *string `json:"full_name" validate:"required,min=1,max=255"`
+	Email    *string `json:"email" validate:"required,email,max=255"`
+	Role     *string `json:"role" validate:"required,oneof=manager worker"`
+	Password *string `json:"password" validate:"omitempty,max=255"`
 }
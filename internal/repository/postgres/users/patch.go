@@ -0,0 +1,65 @@
+package users
+
+import (
+	"context"
+	"task-management2/internal/entity"
+	"task-management2/internal/pkg/validation"
+	basic_repo "task-management2/internal/repository/postgres/_basic_repo"
+	"task-management2/internal/repository/postgres/outbox"
+	"task-management2/internal/util/mergepatch"
+
+	"github.com/uptrace/bun"
+)
+
+// Patch applies a JSON merge patch to a user and returns it as GetById
+// does. None of the fields may be cleared.
+func (r Repository) Patch(ctx context.Context, data basic_repo.MergePatch) (Detail, error) {
+	var detail entity.User
+
+	err := r.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		err := tx.NewSelect().Model(&detail).Where("id = ? AND deleted_at IS NULL", *data.Id).For("UPDATE").Scan(ctx)
+		if err != nil {
+			return err
+		}
+
+		if data.Version != nil && detail.Version != *data.Version {
+			return basic_repo.ErrVersionMismatch
+		}
+
+		fields := Patchable{
+			FullName: detail.FullName,
+			Email:    detail.Email,
+			Role:     detail.Role,
+		}
+		if err := mergepatch.ApplyTo(&fields, data.Patch); err != nil {
+			return basic_repo.Invalid("invalid patch: %v", err)
+		}
+
+		if err := validation.Struct(fields); err != nil {
+			return err
+		}
+		if mergepatch.Has(data.Patch, "password") && (fields.Password == nil || *fields.Password == "") {
+			return basic_repo.InvalidField("password", "password can't be cleared")
+		}
+
+		detail.FullName = fields.FullName
+		detail.Email = fields.Email
+		detail.Role = fields.Role
+		if fields.Password != nil {
+			detail.Password = fields.Password
+		}
+		detail.Version++
+
+		_, err = tx.NewUpdate().Model(&detail).Where("id = ?", detail.Id).Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		return outbox.Append(ctx, tx, outbox.AggregateUser, detail.Id, outbox.EventUserUpdated, toEvent(detail))
+	})
+	if err != nil {
+		return Detail{}, err
+	}
+
+	return r.GetById(ctx, *data.Id)
+}
@@ -2,11 +2,14 @@ package users
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"github.com/uptrace/bun"
 	"task-management2/internal/entity"
 	basic_repo "task-management2/internal/repository/postgres/_basic_repo"
+	"task-management2/internal/repository/postgres/outbox"
+	"time"
 )
 
 type Repository struct {
@@ -35,12 +38,12 @@ func (r Repository) GetAllUsers(ctx context.Context, filter Filter) ([]User, int
 	var count int
 	err := r.QueryRowContext(ctx, countQuery).Scan(&count)
 	if err != nil {
-		return nil, 0, fmt.Errorf("error counting users: %v", err)
+		return nil, 0, fmt.Errorf("error counting users: %w", err)
 	}
 
 	rows, err := r.QueryContext(ctx, query)
 	if err != nil {
-		return nil, 0, fmt.Errorf("error querying users: %v", err)
+		return nil, 0, fmt.Errorf("error querying users: %w", err)
 	}
 	defer rows.Close()
 
@@ -56,7 +59,7 @@ func (r Repository) GetAllUsers(ctx context.Context, filter Filter) ([]User, int
 			&role,
 		)
 		if err != nil {
-			return nil, 0, fmt.Errorf("error scanning user row: %v", err)
+			return nil, 0, fmt.Errorf("error scanning user row: %w", err)
 		}
 		user.Id = &id
 		user.FullName = &fullName
@@ -66,155 +69,189 @@ func (r Repository) GetAllUsers(ctx context.Context, filter Filter) ([]User, int
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, 0, fmt.Errorf("error iterating user rows: %v", err)
+		return nil, 0, fmt.Errorf("error iterating user rows: %w", err)
 	}
 
 	return result, count, nil
 }
 
-func (r Repository) GetTaskStats(ctx context.Context) (map[int64]TaskStats, error) {
-	query := `
-        SELECT 
-            assigned_to,
-            COUNT(CASE WHEN status = 'pending' THEN 1 END) as pending_tasks,
-            COUNT(CASE WHEN status = 'in_progress' THEN 1 END) as in_progress_tasks,
-            COUNT(CASE WHEN status = 'completed' THEN 1 END) as completed_tasks
-        FROM tasks 
-        WHERE deleted_at IS NULL
-        GROUP BY assigned_to`
+// GetByIds returns the users with the given ids, missing and deleted ones
+// are left out.
+func (r Repository) GetByIds(ctx context.Context, ids []int) ([]User, error) {
+	list := []User{}
+	if len(ids) == 0 {
+		return list, nil
+	}
 
-	rows, err := r.QueryContext(ctx, query)
+	err := r.NewSelect().
+		TableExpr("users").
+		Column("id", "full_name", "email", "role").
+		Where("id IN (?) AND deleted_at IS NULL", bun.In(ids)).
+		Order("id").
+		Scan(ctx, &list)
 	if err != nil {
-		return nil, fmt.Errorf("error querying task stats: %v", err)
+		return nil, fmt.Errorf("error getting users: %w", err)
 	}
-	defer rows.Close()
 
-	stats := make(map[int64]TaskStats)
-	for rows.Next() {
-		var userId int64
-		var pending, inProgress, completed int
-		err := rows.Scan(
-			&userId,
-			&pending,
-			&inProgress,
-			&completed,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("error scanning task stats row: %v", err)
-		}
-		total := pending + inProgress + completed
-		stats[userId] = TaskStats{
-			PendingTasks:    &pending,
-			InProgressTasks: &inProgress,
-			CompletedTasks:  &completed,
-			TaskCount:       &total,
-		}
-	}
+	return list, nil
+}
 
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating task stats rows: %v", err)
+// taskStatsJoin counts the tasks of every assignee, for the task stats
+// columns.
+const taskStatsJoin = `LEFT JOIN (
+	SELECT
+		assigned_to,
+		COUNT(CASE WHEN status = 'pending' THEN 1 END) as pending_tasks,
+		COUNT(CASE WHEN status = 'in_progress' THEN 1 END) as in_progress_tasks,
+		COUNT(CASE WHEN status = 'completed' THEN 1 END) as completed_tasks
+	FROM tasks
+	WHERE deleted_at IS NULL
+	GROUP BY assigned_to
+) ts ON ts.assigned_to = u.id`
+
+// projectsJoin embeds the projects a user owns.
+const projectsJoin = `LEFT JOIN LATERAL (
+	SELECT COALESCE(json_agg(json_build_object(
+		'id', p.id, 'name', p.name, 'description', p.description, 'owner_id', p.owner_id
+	) ORDER BY p.id), '[]'::json) AS projects
+	FROM projects p
+	WHERE p.owner_id = u.id AND p.deleted_at IS NULL
+) op ON true`
+
+// listColumns are the fields of a user of a list a read may select,
+// listRelations the records it may embed. Users without tasks have no task
+// stats.
+var (
+	listColumns = []basic_repo.Column[List]{
+		{Name: "id", Expr: "u.id", Dest: func(u *List) []any { return []any{&u.Id} }, Key: true},
+		{Name: "full_name", Expr: "u.full_name", Dest: func(u *List) []any { return []any{&u.FullName} }},
+		{Name: "email", Expr: "u.email", Dest: func(u *List) []any { return []any{&u.Email} }},
+		{Name: "role", Expr: "u.role", Dest: func(u *List) []any { return []any{&u.Role} }},
+		{Name: "pending_tasks", Expr: "ts.pending_tasks", Join: taskStatsJoin, Dest: func(u *List) []any { return []any{&u.PendingTasks} }},
+		{Name: "in_progress_tasks", Expr: "ts.in_progress_tasks", Join: taskStatsJoin, Dest: func(u *List) []any { return []any{&u.InProgressTasks} }},
+		{Name: "completed_tasks", Expr: "ts.completed_tasks", Join: taskStatsJoin, Dest: func(u *List) []any { return []any{&u.CompletedTasks} }},
+		{
+			Name: "task_count",
+			Expr: "ts.pending_tasks + ts.in_progress_tasks + ts.completed_tasks",
+			Join: taskStatsJoin,
+			Dest: func(u *List) []any { return []any{&u.TaskCount} },
+		},
 	}
 
-	return stats, nil
-}
+	listRelations = []basic_repo.Column[List]{
+		{Name: "projects", Expr: "op.projects", Join: projectsJoin, Dest: func(u *List) []any { return []any{basic_repo.JSON(&u.Projects)} }},
+	}
+)
 
 func (r Repository) GetAll(ctx context.Context, filter Filter) ([]List, int, error) {
-	usersList, count, err := r.GetAllUsers(ctx, filter)
+	selection, err := basic_repo.Select(listColumns, listRelations, filter.Sparse)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	taskStats, err := r.GetTaskStats(ctx)
+	query := fmt.Sprintf(`
+		SELECT 
+			%s
+		FROM users u
+		%s
+		WHERE u.deleted_at IS NULL
+		ORDER BY u.id`, selection.Columns(), selection.Joins())
+
+	if filter.Limit != nil {
+		query += fmt.Sprintf(" LIMIT %d", *filter.Limit)
+	}
+	if filter.Offset != nil {
+		query += fmt.Sprintf(" OFFSET %d", *filter.Offset)
+	}
+
+	var count int
+	err = r.QueryRowContext(ctx, "SELECT COUNT(*) FROM users WHERE deleted_at IS NULL").Scan(&count)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, fmt.Errorf("error counting users: %w", err)
+	}
+
+	rows, err := r.QueryContext(ctx, query)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error querying users: %w", err)
 	}
+	defer rows.Close()
 
 	var result []List
-	for _, user := range usersList {
-		if user.Id == nil {
-			continue
+	for rows.Next() {
+		var user List
+		if err := selection.Scan(rows, &user); err != nil {
+			return nil, 0, fmt.Errorf("error scanning user row: %w", err)
 		}
-		stats := taskStats[*user.Id]
-		result = append(result, List{
-			Id:              user.Id,
-			FullName:        user.FullName,
-			Email:           user.Email,
-			Role:            user.Role,
-			PendingTasks:    stats.PendingTasks,
-			InProgressTasks: stats.InProgressTasks,
-			CompletedTasks:  stats.CompletedTasks,
-			TaskCount:       stats.TaskCount,
-		})
+		result = append(result, user)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating user rows: %w", err)
 	}
 
 	return result, count, nil
 }
 
-func (r Repository) getUserDetails(ctx context.Context, userId int) (Detail, error) {
-	query := `
-		SELECT 
-			id,
-			full_name,
-			email,
-			role,
-			created_at at time zone 'UTC'
-		FROM users
-		WHERE id = ? AND deleted_at IS NULL`
-
-	var result Detail
-	var id int64
-	var fullName, email, role, createdAt string
-
-	err := r.QueryRow(query, userId).Scan(
-		&id,
-		&fullName,
-		&email,
-		&role,
-		&createdAt,
-	)
-	if err != nil {
-		return Detail{}, fmt.Errorf("error getting user details: %w", err)
+// detailColumns are the fields of a user a read may select, detailRelations
+// the records it may embed.
+var (
+	detailColumns = []basic_repo.Column[Detail]{
+		{Name: "id", Expr: "u.id", Dest: func(u *Detail) []any { return []any{&u.Id} }, Key: true},
+		{Name: "full_name", Expr: "u.full_name", Dest: func(u *Detail) []any { return []any{&u.FullName} }},
+		{Name: "email", Expr: "u.email", Dest: func(u *Detail) []any { return []any{&u.Email} }},
+		{Name: "role", Expr: "u.role", Dest: func(u *Detail) []any { return []any{&u.Role} }},
+		{Name: "pending_tasks", Expr: "COALESCE(ts.pending_tasks, 0)", Join: taskStatsJoin, Dest: func(u *Detail) []any { return []any{&u.PendingTasks} }},
+		{Name: "in_progress_tasks", Expr: "COALESCE(ts.in_progress_tasks, 0)", Join: taskStatsJoin, Dest: func(u *Detail) []any { return []any{&u.InProgressTasks} }},
+		{Name: "completed_tasks", Expr: "COALESCE(ts.completed_tasks, 0)", Join: taskStatsJoin, Dest: func(u *Detail) []any { return []any{&u.CompletedTasks} }},
+		{
+			Name: "task_count",
+			Expr: "COALESCE(ts.pending_tasks + ts.in_progress_tasks + ts.completed_tasks, 0)",
+			Join: taskStatsJoin,
+			Dest: func(u *Detail) []any { return []any{&u.TaskCount} },
+		},
+		{Name: "version", Expr: "u.version", Dest: func(u *Detail) []any { return []any{&u.Version} }, Key: true},
+		{Name: "created_at", Expr: "u.created_at at time zone 'UTC'", Dest: func(u *Detail) []any { return []any{&u.CreatedAt} }},
+		{Name: "tasks", Expr: "ut.tasks", Join: `LEFT JOIN LATERAL (
+			SELECT COALESCE(json_agg(json_build_object(
+				'id', id,
+				'name', name,
+				'description', description,
+				'status', status,
+				'priority', priority,
+				'due_date', to_char(due_date at time zone 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"'),
+				'created_at', to_char(created_at at time zone 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"')
+			) ORDER BY created_at DESC), '[]'::json) AS tasks
+			FROM tasks
+			WHERE deleted_at IS NULL AND assigned_to = u.id
+		) ut ON true`, Dest: func(u *Detail) []any { return []any{basic_repo.JSON(&u.Tasks)} }},
 	}
 
-	result.Id = &id
-	result.FullName = &fullName
-	result.Email = &email
-	result.Role = &role
-	result.CreatedAt = &createdAt
-
-	return result, nil
-}
+	detailRelations = []basic_repo.Column[Detail]{
+		{Name: "projects", Expr: "op.projects", Join: projectsJoin, Dest: func(u *Detail) []any { return []any{basic_repo.JSON(&u.Projects)} }},
+	}
+)
 
-func (r Repository) getTaskStatsCount(ctx context.Context, userId int) (TaskStats, error) {
-	query := `
-        SELECT 
-            COUNT(CASE WHEN status = 'pending' THEN 1 END) as pending_tasks,
-            COUNT(CASE WHEN status = 'in_progress' THEN 1 END) as in_progress_tasks,
-            COUNT(CASE WHEN status = 'completed' THEN 1 END) as completed_tasks,
-            COUNT(*) as total_tasks
-        FROM tasks
-        WHERE deleted_at IS NULL AND assigned_to = ?
-        GROUP BY assigned_to`
-
-	var taskStats TaskStats
-	var pending, inProgress, completed, total int
-
-	err := r.QueryRow(query, userId).Scan(
-		&pending,
-		&inProgress,
-		&completed,
-		&total,
-	)
+// GetDetail reads the fields and relations of a user filter.Sparse asks
+// for, with one query.
+func (r Repository) GetDetail(ctx context.Context, filter DetailFilter) (Detail, error) {
+	selection, err := basic_repo.Select(detailColumns, detailRelations, filter.Sparse)
 	if err != nil {
-		return TaskStats{}, fmt.Errorf("error getting task stats: %w", err)
+		return Detail{}, err
 	}
 
-	taskStats.PendingTasks = &pending
-	taskStats.InProgressTasks = &inProgress
-	taskStats.CompletedTasks = &completed
-	taskStats.TaskCount = &total
+	query := fmt.Sprintf(`
+		SELECT 
+			%s
+		FROM users u
+		%s
+		WHERE u.id = ? AND u.deleted_at IS NULL`, selection.Columns(), selection.Joins())
 
-	return taskStats, nil
+	var result Detail
+	if err := selection.Scan(r.QueryRowContext(ctx, query, filter.Id), &result); err != nil {
+		return Detail{}, fmt.Errorf("error getting user details: %w", err)
+	}
+
+	return result, nil
 }
 
 func (r Repository) getUserTasks(ctx context.Context, userId int) ([]TaskItem, error) {
@@ -251,29 +288,13 @@ func (r Repository) getUserTasks(ctx context.Context, userId int) ([]TaskItem, e
 	return tasks, nil
 }
 
-func (r Repository) GetById(ctx context.Context, userId int) (Detail, error) {
-	result, err := r.getUserDetails(ctx, userId)
-	if err != nil {
-		return Detail{}, err
-	}
-
-	taskStats, err := r.getTaskStatsCount(ctx, userId)
-	if err != nil {
-		return Detail{}, err
-	}
-
-	tasks, err := r.getUserTasks(ctx, userId)
-	if err != nil {
-		return Detail{}, err
-	}
-
-	result.PendingTasks = taskStats.PendingTasks
-	result.InProgressTasks = taskStats.InProgressTasks
-	result.CompletedTasks = taskStats.CompletedTasks
-	result.TaskCount = taskStats.TaskCount
-	result.Tasks = &tasks
+// GetUserTasks returns the tasks assigned to the user, newest first.
+func (r Repository) GetUserTasks(ctx context.Context, userId int) ([]TaskItem, error) {
+	return r.getUserTasks(ctx, userId)
+}
 
-	return result, nil
+func (r Repository) GetById(ctx context.Context, userId int) (Detail, error) {
+	return r.GetDetail(ctx, DetailFilter{Id: userId})
 }
 
 func (r Repository) Create(ctx context.Context, data Create) (entity.User, error) {
@@ -283,8 +304,16 @@ func (r Repository) Create(ctx context.Context, data Create) (entity.User, error
 	detail.Password = data.Password
 	detail.FullName = data.FullName
 	detail.Role = data.Role
+	detail.Version = 1
+
+	err := r.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewInsert().Model(&detail).Exec(ctx)
+		if err != nil {
+			return err
+		}
 
-	_, err := r.NewInsert().Model(&detail).Exec(ctx)
+		return outbox.Append(ctx, tx, outbox.AggregateUser, detail.Id, outbox.EventUserCreated, toEvent(detail))
+	})
 
 	return detail, err
 }
@@ -292,31 +321,109 @@ func (r Repository) Create(ctx context.Context, data Create) (entity.User, error
 func (r Repository) Update(ctx context.Context, data Update) (entity.User, error) {
 	var detail entity.User
 
-	err := r.NewSelect().Model(&detail).Where("id = ?", data.Id).Scan(ctx)
-	if err != nil {
-		return entity.User{}, err
-	}
+	err := r.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		err := tx.NewSelect().Model(&detail).Where("id = ? AND deleted_at IS NULL", data.Id).For("UPDATE").Scan(ctx)
+		if err != nil {
+			return err
+		}
 
-	if data.FullName != nil {
-		detail.FullName = data.FullName
-	}
-	if data.Role != nil {
-		detail.Role = data.Role
-	}
-	if data.Email != nil {
-		detail.Email = data.Email
-	}
-	if data.Password != nil {
-		detail.Password = data.Password
-	}
+		if data.Version != nil && detail.Version != *data.Version {
+			return basic_repo.ErrVersionMismatch
+		}
+
+		if data.FullName != nil {
+			detail.FullName = data.FullName
+		}
+		if data.Role != nil {
+			detail.Role = data.Role
+		}
+		if data.Email != nil {
+			detail.Email = data.Email
+		}
+		if data.Password != nil {
+			detail.Password = data.Password
+		}
+		detail.Version++
+
+		_, err = tx.NewUpdate().Model(&detail).Where("id = ?", detail.Id).Exec(ctx)
+		if err != nil {
+			return err
+		}
 
-	_, err = r.NewUpdate().Model(&detail).Where("id = ?", detail.Id).Exec(ctx)
+		return outbox.Append(ctx, tx, outbox.AggregateUser, detail.Id, outbox.EventUserUpdated, toEvent(detail))
+	})
 
 	return detail, err
 }
 
 func (r Repository) Delete(ctx context.Context, data basic_repo.Delete) error {
-	return basic_repo.BasicDelete(ctx, data, &entity.User{}, r.DB)
+	return r.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		var version int
+		err := tx.QueryRowContext(ctx,
+			"SELECT version FROM users WHERE id = ? AND deleted_at IS NULL FOR UPDATE",
+			*data.Id,
+		).Scan(&version)
+		if err != nil {
+			return err
+		}
+
+		if data.Version != nil && version != *data.Version {
+			return basic_repo.ErrVersionMismatch
+		}
+
+		_, err = tx.NewUpdate().
+			Model((*entity.User)(nil)).
+			Set("deleted_at = ?", time.Now()).
+			Set("version = version + 1").
+			Where("id = ?", *data.Id).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		return outbox.Append(ctx, tx, outbox.AggregateUser, *data.Id, outbox.EventUserDeleted, UserEvent{Id: *data.Id})
+	})
+}
+
+// Restore brings back a deleted user and returns it as GetById does.
+// Consumers see the user as created again.
+func (r Repository) Restore(ctx context.Context, id int) (Detail, error) {
+	var detail entity.User
+
+	err := r.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewUpdate().
+			Model(&detail).
+			Set("deleted_at = NULL").
+			Set("updated_at = ?", time.Now()).
+			Set("version = version + 1").
+			Where("id = ? AND deleted_at IS NOT NULL", id).
+			Returning("*").
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+
+		if detail.Id == 0 {
+			return sql.ErrNoRows
+		}
+
+		return outbox.Append(ctx, tx, outbox.AggregateUser, detail.Id, outbox.EventUserCreated, toEvent(detail))
+	})
+	if err != nil {
+		return Detail{}, err
+	}
+
+	return r.GetById(ctx, id)
+}
+
+// toEvent is the payload of user events, without the password.
+func toEvent(detail entity.User) UserEvent {
+	return UserEvent{
+		Id:       detail.Id,
+		FullName: detail.FullName,
+		Email:    detail.Email,
+		Role:     detail.Role,
+	}
 }
 
 func NewRepository(DB *bun.DB) *Repository {
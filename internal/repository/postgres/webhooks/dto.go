@@ -0,0 +1,72 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Filter struct {
+	Limit     *int
+	Offset    *int
+	ProjectId *int
+}
+
+type Create struct {
+	ProjectId *int     `json:"project_id"`
+	Url       *string  `json:"url" binding:"required" validate:"omitempty,http_url,max=2048"`
+	Secret    *string  `json:"secret"`
+	Events    []string `json:"events" binding:"required"`
+	Active    *bool    `json:"active"`
+}
+
+type Update struct {
+	Id     *int     `json:"id" form:"id"`
+	Url    *string  `json:"url" validate:"omitempty,http_url,max=2048"`
+	Secret *string  `json:"secret"`
+	Events []string `json:"events"`
+	Active *bool    `json:"active"`
+}
+
+type DeliveryFilter struct {
+	Limit     *int
+	Offset    *int
+	WebhookId *int
+	Status    *string
+}
+
+type Delivery struct {
+	Id            int             `json:"id"`
+	WebhookId     int             `json:"webhook_id"`
+	Event         string          `json:"event"`
+	Payload       json.RawMessage `json:"payload"`
+	Status        string          `json:"status"`
+	Attempts      int             `json:"attempts"`
+	NextAttemptAt *time.Time      `json:"next_attempt_at"`
+	ResponseCode  *int            `json:"response_code"`
+	ResponseBody  *string         `json:"response_body"`
+	Error         *string         `json:"error"`
+	DeliveredAt   *time.Time      `json:"delivered_at"`
+	CreatedAt     *time.Time      `json:"created_at"`
+}
+
+// Due is a delivery claimed by the dispatcher, with what it needs to send it.
+type Due struct {
+	Id        int
+	WebhookId int
+	Event     string
+	Payload   string
+	Attempts  int
+	Url       string
+	Secret    string
+}
+
+// Result is the outcome of one delivery attempt. A failed attempt is retried
+// at RetryAt, or given up on when RetryAt is nil.
+type Result struct {
+	Id           int
+	Succeeded    bool
+	ResponseCode *int
+	ResponseBody *string
+	Error        *string
+	RetryAt      *time.Time
+}
@@ -0,0 +1,457 @@
+package webhooks
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"task-management2/internal/entity"
+	basic_repo "task-management2/internal/repository/postgres/_basic_repo"
+	"task-management2/internal/repository/postgres/outbox"
+	"task-management2/internal/util/hash"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Webhooks can subscribe to these domain events, see package outbox.
+const (
+	EventTaskCreated    = outbox.EventTaskCreated
+	EventTaskUpdated    = outbox.EventTaskUpdated
+	EventTaskDeleted    = outbox.EventTaskDeleted
+	EventProjectCreated = outbox.EventProjectCreated
+	EventProjectUpdated = outbox.EventProjectUpdated
+	EventProjectDeleted = outbox.EventProjectDeleted
+	EventPing           = "ping"
+)
+
+const (
+	StatusPending   = "pending"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+var events = map[string]bool{
+	EventTaskCreated:    true,
+	EventTaskUpdated:    true,
+	EventTaskDeleted:    true,
+	EventProjectCreated: true,
+	EventProjectUpdated: true,
+	EventProjectDeleted: true,
+}
+
+type Repository struct {
+	*bun.DB
+}
+
+func NewRepository(DB *bun.DB) *Repository {
+	return &Repository{DB: DB}
+}
+
+// payload is the JSON body posted to subscribers.
+type payload struct {
+	Id         *int64      `json:"id,omitempty"`
+	Event      string      `json:"event"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Data       interface{} `json:"data"`
+}
+
+// Enqueue queues a delivery of a domain event for every active webhook
+// subscribed to it, either on projectId or on all projects. The outbox may
+// hand over an event more than once, deliveries are unique per webhook and
+// event id.
+func (r Repository) Enqueue(ctx context.Context, eventId int64, event string, occurredAt time.Time, projectId *int, data interface{}) error {
+	body, err := json.Marshal(payload{
+		Id:         &eventId,
+		Event:      event,
+		OccurredAt: occurredAt.UTC(),
+		Data:       data,
+	})
+	if err != nil {
+		return fmt.Errorf("error encoding webhook payload: %w", err)
+	}
+
+	query := `
+		INSERT INTO webhook_deliveries (webhook_id, outbox_event_id, event, payload, status, attempts, next_attempt_at, created_at)
+		SELECT id, ?, ?, ?::jsonb, ?, 0, now(), now()
+		FROM webhooks
+		WHERE deleted_at IS NULL AND active
+		AND ? = ANY(events)
+		AND (project_id IS NULL OR project_id = ?)
+		ON CONFLICT (webhook_id, outbox_event_id) WHERE outbox_event_id IS NOT NULL DO NOTHING`
+
+	_, err = r.ExecContext(ctx, query, eventId, event, string(body), StatusPending, event, projectId)
+	if err != nil {
+		return fmt.Errorf("error enqueuing webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+func validate(detail entity.Webhooks) error {
+	u, err := url.Parse(*detail.Url)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return basic_repo.InvalidField("url", "url must be an absolute http or https url")
+	}
+
+	if len(detail.Events) == 0 {
+		return basic_repo.InvalidField("events", "events must not be empty")
+	}
+	for _, event := range detail.Events {
+		if !events[event] {
+			return basic_repo.InvalidField("events", "unknown event %q", event)
+		}
+	}
+
+	return nil
+}
+
+func (r Repository) GetAll(ctx context.Context, filter Filter) ([]entity.Webhooks, int, error) {
+	var result []entity.Webhooks
+
+	query := r.NewSelect().
+		Model(&result).
+		Where("deleted_at IS NULL").
+		Order("id")
+
+	if filter.ProjectId != nil {
+		query = query.Where("project_id = ?", *filter.ProjectId)
+	}
+	if filter.Limit != nil {
+		query = query.Limit(*filter.Limit)
+	}
+	if filter.Offset != nil {
+		query = query.Offset(*filter.Offset)
+	}
+
+	count, err := query.ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error querying webhooks: %w", err)
+	}
+
+	return result, count, nil
+}
+
+func (r Repository) GetById(ctx context.Context, id int) (entity.Webhooks, error) {
+	var detail entity.Webhooks
+
+	err := r.NewSelect().
+		Model(&detail).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Scan(ctx)
+	if err != nil {
+		return entity.Webhooks{}, fmt.Errorf("error getting webhook: %w", err)
+	}
+
+	return detail, nil
+}
+
+// Create stores a webhook. Without a secret in data one is generated; the
+// secret is returned here only, later reads don't expose it.
+func (r Repository) Create(ctx context.Context, data Create) (entity.Webhooks, string, error) {
+	secret := ""
+	if data.Secret != nil && *data.Secret != "" {
+		secret = *data.Secret
+	} else {
+		token, err := hash.NewToken(32)
+		if err != nil {
+			return entity.Webhooks{}, "", err
+		}
+		secret = token
+	}
+
+	active := true
+	if data.Active != nil {
+		active = *data.Active
+	}
+
+	now := time.Now()
+	detail := entity.Webhooks{
+		ProjectId: data.ProjectId,
+		Url:       data.Url,
+		Secret:    &secret,
+		Events:    data.Events,
+		Active:    &active,
+	}
+	detail.CreatedAt = &now
+
+	if err := validate(detail); err != nil {
+		return entity.Webhooks{}, "", err
+	}
+
+	_, err := r.NewInsert().Model(&detail).Exec(ctx)
+	if err != nil {
+		return entity.Webhooks{}, "", fmt.Errorf("error creating webhook: %w", err)
+	}
+
+	return detail, secret, nil
+}
+
+func (r Repository) Update(ctx context.Context, data Update) (entity.Webhooks, error) {
+	detail, err := r.GetById(ctx, *data.Id)
+	if err != nil {
+		return entity.Webhooks{}, err
+	}
+
+	if data.Url != nil {
+		detail.Url = data.Url
+	}
+	if data.Secret != nil && *data.Secret != "" {
+		detail.Secret = data.Secret
+	}
+	if data.Events != nil {
+		detail.Events = data.Events
+	}
+	if data.Active != nil {
+		detail.Active = data.Active
+	}
+
+	if err := validate(detail); err != nil {
+		return entity.Webhooks{}, err
+	}
+
+	now := time.Now()
+	detail.UpdateAt = &now
+
+	_, err = r.NewUpdate().Model(&detail).WherePK().Exec(ctx)
+	if err != nil {
+		return entity.Webhooks{}, fmt.Errorf("error updating webhook: %w", err)
+	}
+
+	return detail, nil
+}
+
+func (r Repository) Delete(ctx context.Context, id int) error {
+	result, err := r.NewUpdate().
+		Model((*entity.Webhooks)(nil)).
+		Set("deleted_at = ?", time.Now()).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// Ping queues a ping delivery to a single webhook, whatever events it is
+// subscribed to.
+func (r Repository) Ping(ctx context.Context, id int) (Delivery, error) {
+	detail, err := r.GetById(ctx, id)
+	if err != nil {
+		return Delivery{}, err
+	}
+
+	body, err := json.Marshal(payload{
+		Event:      EventPing,
+		OccurredAt: time.Now().UTC(),
+		Data:       detail,
+	})
+	if err != nil {
+		return Delivery{}, err
+	}
+
+	return r.insertDelivery(ctx, detail.Id, EventPing, string(body))
+}
+
+func (r Repository) GetDeliveries(ctx context.Context, filter DeliveryFilter) ([]Delivery, int, error) {
+	var rows []entity.WebhookDeliveries
+
+	query := r.NewSelect().
+		Model(&rows).
+		Where("webhook_id = ? AND deleted_at IS NULL", *filter.WebhookId).
+		Order("id DESC")
+
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
+	}
+	if filter.Limit != nil {
+		query = query.Limit(*filter.Limit)
+	}
+	if filter.Offset != nil {
+		query = query.Offset(*filter.Offset)
+	}
+
+	count, err := query.ScanAndCount(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error querying webhook deliveries: %w", err)
+	}
+
+	result := make([]Delivery, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, toDelivery(row))
+	}
+
+	return result, count, nil
+}
+
+// Redeliver queues a new delivery with the payload of an earlier one. The
+// original stays in the log as it was.
+func (r Repository) Redeliver(ctx context.Context, webhookId, deliveryId int) (Delivery, error) {
+	var original entity.WebhookDeliveries
+
+	err := r.NewSelect().
+		Model(&original).
+		Where("id = ? AND webhook_id = ? AND deleted_at IS NULL", deliveryId, webhookId).
+		Scan(ctx)
+	if err != nil {
+		return Delivery{}, fmt.Errorf("error getting webhook delivery: %w", err)
+	}
+
+	return r.insertDelivery(ctx, webhookId, *original.Event, *original.Payload)
+}
+
+func (r Repository) insertDelivery(ctx context.Context, webhookId int, event, body string) (Delivery, error) {
+	status := StatusPending
+	attempts := 0
+	now := time.Now()
+
+	detail := entity.WebhookDeliveries{
+		WebhookId:     &webhookId,
+		Event:         &event,
+		Payload:       &body,
+		Status:        &status,
+		Attempts:      &attempts,
+		NextAttemptAt: &now,
+	}
+	detail.CreatedAt = &now
+
+	_, err := r.NewInsert().Model(&detail).Exec(ctx)
+	if err != nil {
+		return Delivery{}, fmt.Errorf("error creating webhook delivery: %w", err)
+	}
+
+	return toDelivery(detail), nil
+}
+
+// ClaimDue takes up to limit pending deliveries whose attempt is due and
+// pushes their next attempt lease into the future, so other dispatchers skip
+// them while this one sends. A dispatcher that dies mid-send leaves the
+// delivery to be retried once the lease ran out.
+func (r Repository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]Due, error) {
+	query := `
+		WITH due AS (
+			SELECT d.id
+			FROM webhook_deliveries d
+			JOIN webhooks w ON w.id = d.webhook_id AND w.deleted_at IS NULL AND w.active
+			WHERE d.status = ? AND d.next_attempt_at <= now() AND d.deleted_at IS NULL
+			ORDER BY d.next_attempt_at, d.id
+			LIMIT ?
+			FOR UPDATE OF d SKIP LOCKED
+		)
+		UPDATE webhook_deliveries d
+		SET next_attempt_at = now() + ? * interval '1 second',
+			attempts = d.attempts + 1,
+			updated_at = now()
+		FROM due, webhooks w
+		WHERE d.id = due.id AND w.id = d.webhook_id
+		RETURNING d.id, d.webhook_id, d.event, d.payload::text, d.attempts, w.url, w.secret`
+
+	rows, err := r.QueryContext(ctx, query, StatusPending, limit, int(lease.Seconds()))
+	if err != nil {
+		return nil, fmt.Errorf("error claiming webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var result []Due
+	for rows.Next() {
+		var item Due
+
+		err := rows.Scan(
+			&item.Id,
+			&item.WebhookId,
+			&item.Event,
+			&item.Payload,
+			&item.Attempts,
+			&item.Url,
+			&item.Secret,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning webhook delivery row: %w", err)
+		}
+
+		result = append(result, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating webhook delivery rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// RecordResult stores the outcome of a delivery attempt.
+func (r Repository) RecordResult(ctx context.Context, result Result) error {
+	now := time.Now()
+
+	query := r.NewUpdate().
+		Model((*entity.WebhookDeliveries)(nil)).
+		Set("response_code = ?", result.ResponseCode).
+		Set("response_body = ?", result.ResponseBody).
+		Set("error = ?", result.Error).
+		Set("updated_at = ?", now).
+		Where("id = ?", result.Id)
+
+	switch {
+	case result.Succeeded:
+		query = query.
+			Set("status = ?", StatusSucceeded).
+			Set("delivered_at = ?", now).
+			Set("next_attempt_at = NULL")
+	case result.RetryAt != nil:
+		query = query.
+			Set("status = ?", StatusPending).
+			Set("next_attempt_at = ?", *result.RetryAt)
+	default:
+		query = query.
+			Set("status = ?", StatusFailed).
+			Set("next_attempt_at = NULL")
+	}
+
+	_, err := query.Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("error recording webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+func toDelivery(detail entity.WebhookDeliveries) Delivery {
+	result := Delivery{
+		Id:            detail.Id,
+		NextAttemptAt: detail.NextAttemptAt,
+		ResponseCode:  detail.ResponseCode,
+		ResponseBody:  detail.ResponseBody,
+		Error:         detail.Error,
+		DeliveredAt:   detail.DeliveredAt,
+		CreatedAt:     detail.CreatedAt,
+	}
+
+	if detail.WebhookId != nil {
+		result.WebhookId = *detail.WebhookId
+	}
+	if detail.Event != nil {
+		result.Event = *detail.Event
+	}
+	if detail.Payload != nil {
+		result.Payload = json.RawMessage(*detail.Payload)
+	}
+	if detail.Status != nil {
+		result.Status = *detail.Status
+	}
+	if detail.Attempts != nil {
+		result.Attempts = *detail.Attempts
+	}
+
+	return result
+}
@@ -0,0 +1,80 @@
+package worklogs
+
+import "time"
+
+type Filter struct {
+	Limit     *int
+	Offset    *int
+	TaskId    *int
+	UserId    *int
+	ProjectId *int
+	From      *string
+	To        *string
+	Location  *time.Location
+}
+
+type Create struct {
+	TaskId    *int    `json:"task_id" binding:"required"`
+	UserId    *int    `json:"user_id" binding:"required"`
+	StartedAt *string `json:"started_at" binding:"required"`
+	Duration  *int    `json:"duration" binding:"required"`
+	Note      *string `json:"note"`
+}
+
+type Update struct {
+	Id        *int    `json:"id" form:"id"`
+	StartedAt *string `json:"started_at"`
+	Duration  *int    `json:"duration"`
+	Note      *string `json:"note"`
+}
+
+type StartTimer struct {
+	TaskId *int    `json:"task_id" binding:"required"`
+	UserId *int    `json:"user_id" binding:"required"`
+	Note   *string `json:"note"`
+}
+
+type StopTimer struct {
+	UserId *int    `json:"user_id" binding:"required"`
+	Note   *string `json:"note"`
+}
+
+type List struct {
+	Id          int        `json:"id"`
+	TaskId      int        `json:"task_id"`
+	TaskName    string     `json:"task_name"`
+	ProjectId   int        `json:"project_id"`
+	ProjectName string     `json:"project_name"`
+	UserId      int        `json:"user_id"`
+	UserName    string     `json:"user_name"`
+	StartedAt   *time.Time `json:"started_at"`
+	Duration    int        `json:"duration"`
+	Running     bool       `json:"running"`
+	Note        string     `json:"note"`
+}
+
+type Total struct {
+	Id       int    `json:"id"`
+	Name     string `json:"name"`
+	Duration int    `json:"duration"`
+	Entries  int    `json:"entries"`
+}
+
+type TimesheetRow struct {
+	Date        string `json:"date"`
+	UserId      int    `json:"user_id"`
+	UserName    string `json:"user_name"`
+	ProjectId   int    `json:"project_id"`
+	ProjectName string `json:"project_name"`
+	TaskId      int    `json:"task_id"`
+	TaskName    string `json:"task_name"`
+	Duration    int    `json:"duration"`
+}
+
+type Timesheet struct {
+	From     *string        `json:"from"`
+	To       *string        `json:"to"`
+	TimeZone string         `json:"time_zone"`
+	Rows     []TimesheetRow `json:"rows"`
+	Total    int            `json:"total"`
+}
@@ -0,0 +1,424 @@
+package worklogs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"task-management2/internal/entity"
+	basic_repo "task-management2/internal/repository/postgres/_basic_repo"
+	"time"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/driver/pgdriver"
+)
+
+var (
+	ErrTimerRunning    = basic_repo.Conflict("a timer is already running for this user")
+	ErrTimerNotRunning = basic_repo.Conflict("no running timer for this user")
+	ErrInvalidGroupBy  = basic_repo.InvalidField("group_by", "group_by must be one of task, user, project")
+)
+
+// MaxDuration caps a single worklog entry.
+const MaxDuration = 24 * 60 * 60
+
+// durationExpr is the logged time of an entry in seconds; running timers
+// count up to now.
+const durationExpr = `COALESCE(w.duration, GREATEST(EXTRACT(EPOCH FROM ((now() AT TIME ZONE 'UTC') - w.started_at)), 0)::int)`
+
+type Repository struct {
+	*bun.DB
+}
+
+func NewRepository(DB *bun.DB) *Repository {
+	return &Repository{DB: DB}
+}
+
+func (r Repository) buildWhereAndParams(filter Filter) (string, []interface{}) {
+	var whereClause string
+	var params []interface{}
+
+	tz := "UTC"
+	if filter.Location != nil {
+		tz = filter.Location.String()
+	}
+
+	if filter.TaskId != nil {
+		whereClause += " AND w.task_id = ?"
+		params = append(params, *filter.TaskId)
+	}
+	if filter.UserId != nil {
+		whereClause += " AND w.user_id = ?"
+		params = append(params, *filter.UserId)
+	}
+	if filter.ProjectId != nil {
+		whereClause += " AND t.project_id = ?"
+		params = append(params, *filter.ProjectId)
+	}
+	if filter.From != nil {
+		whereClause += " AND (w.started_at AT TIME ZONE 'UTC' AT TIME ZONE ?)::date >= ?::date"
+		params = append(params, tz, *filter.From)
+	}
+	if filter.To != nil {
+		whereClause += " AND (w.started_at AT TIME ZONE 'UTC' AT TIME ZONE ?)::date <= ?::date"
+		params = append(params, tz, *filter.To)
+	}
+
+	return whereClause, params
+}
+
+func (r Repository) GetAll(ctx context.Context, filter Filter) ([]List, int, error) {
+	whereClause, params := r.buildWhereAndParams(filter)
+
+	countQuery := `
+		SELECT COUNT(*)
+		FROM worklogs w
+		JOIN tasks t ON t.id = w.task_id
+		WHERE w.deleted_at IS NULL` + whereClause
+
+	var count int
+	err := r.QueryRowContext(ctx, countQuery, params...).Scan(&count)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error counting worklogs: %w", err)
+	}
+
+	query := `
+		SELECT
+			w.id,
+			w.task_id,
+			COALESCE(t.name, ''),
+			t.project_id,
+			COALESCE(p.name, ''),
+			w.user_id,
+			COALESCE(u.full_name, ''),
+			w.started_at,
+			` + durationExpr + `,
+			w.duration IS NULL,
+			COALESCE(w.note, '')
+		FROM worklogs w
+		JOIN tasks t ON t.id = w.task_id
+		LEFT JOIN projects p ON p.id = t.project_id
+		LEFT JOIN users u ON u.id = w.user_id
+		WHERE w.deleted_at IS NULL` + whereClause + `
+		ORDER BY w.started_at DESC, w.id DESC`
+
+	if filter.Limit != nil {
+		query += fmt.Sprintf(" LIMIT %d", *filter.Limit)
+	}
+	if filter.Offset != nil {
+		query += fmt.Sprintf(" OFFSET %d", *filter.Offset)
+	}
+
+	rows, err := r.QueryContext(ctx, query, params...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error querying worklogs: %w", err)
+	}
+	defer rows.Close()
+
+	var result []List
+	for rows.Next() {
+		var item List
+
+		err := rows.Scan(
+			&item.Id,
+			&item.TaskId,
+			&item.TaskName,
+			&item.ProjectId,
+			&item.ProjectName,
+			&item.UserId,
+			&item.UserName,
+			&item.StartedAt,
+			&item.Duration,
+			&item.Running,
+			&item.Note,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error scanning worklog row: %w", err)
+		}
+
+		result = append(result, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating worklog rows: %w", err)
+	}
+
+	return result, count, nil
+}
+
+// GetTotals sums logged time grouped by task, user or project.
+func (r Repository) GetTotals(ctx context.Context, groupBy string, filter Filter) ([]Total, error) {
+	var idExpr, nameExpr string
+	switch groupBy {
+	case "task":
+		idExpr, nameExpr = "t.id", "t.name"
+	case "user":
+		idExpr, nameExpr = "u.id", "u.full_name"
+	case "project":
+		idExpr, nameExpr = "p.id", "p.name"
+	default:
+		return nil, ErrInvalidGroupBy
+	}
+
+	whereClause, params := r.buildWhereAndParams(filter)
+
+	query := fmt.Sprintf(`
+		SELECT
+			%s,
+			COALESCE(%s, ''),
+			COALESCE(SUM(%s), 0),
+			COUNT(*)
+		FROM worklogs w
+		JOIN tasks t ON t.id = w.task_id
+		JOIN projects p ON p.id = t.project_id
+		JOIN users u ON u.id = w.user_id
+		WHERE w.deleted_at IS NULL%s
+		GROUP BY %s, %s
+		ORDER BY %s`,
+		idExpr, nameExpr, durationExpr, whereClause, idExpr, nameExpr, idExpr,
+	)
+
+	rows, err := r.QueryContext(ctx, query, params...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying worklog totals: %w", err)
+	}
+	defer rows.Close()
+
+	result := []Total{}
+	for rows.Next() {
+		var item Total
+
+		err := rows.Scan(
+			&item.Id,
+			&item.Name,
+			&item.Duration,
+			&item.Entries,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning worklog total row: %w", err)
+		}
+
+		result = append(result, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating worklog total rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetTimesheet returns logged time per day, user and task. Days are taken in
+// filter.Location so an entry started late in the evening lands on the
+// caller's day.
+func (r Repository) GetTimesheet(ctx context.Context, filter Filter) (Timesheet, error) {
+	tz := "UTC"
+	if filter.Location != nil {
+		tz = filter.Location.String()
+	}
+
+	whereClause, params := r.buildWhereAndParams(filter)
+	params = append([]interface{}{tz}, params...)
+
+	query := `
+		SELECT
+			to_char((w.started_at AT TIME ZONE 'UTC' AT TIME ZONE ?)::date, 'YYYY-MM-DD') as day,
+			u.id,
+			COALESCE(u.full_name, ''),
+			p.id,
+			COALESCE(p.name, ''),
+			t.id,
+			COALESCE(t.name, ''),
+			SUM(` + durationExpr + `)
+		FROM worklogs w
+		JOIN tasks t ON t.id = w.task_id
+		JOIN projects p ON p.id = t.project_id
+		JOIN users u ON u.id = w.user_id
+		WHERE w.deleted_at IS NULL` + whereClause + `
+		GROUP BY day, u.id, u.full_name, p.id, p.name, t.id, t.name
+		ORDER BY day, u.id, p.id, t.id`
+
+	rows, err := r.QueryContext(ctx, query, params...)
+	if err != nil {
+		return Timesheet{}, fmt.Errorf("error querying timesheet: %w", err)
+	}
+	defer rows.Close()
+
+	result := Timesheet{
+		From:     filter.From,
+		To:       filter.To,
+		TimeZone: tz,
+		Rows:     []TimesheetRow{},
+	}
+
+	for rows.Next() {
+		var item TimesheetRow
+
+		err := rows.Scan(
+			&item.Date,
+			&item.UserId,
+			&item.UserName,
+			&item.ProjectId,
+			&item.ProjectName,
+			&item.TaskId,
+			&item.TaskName,
+			&item.Duration,
+		)
+		if err != nil {
+			return Timesheet{}, fmt.Errorf("error scanning timesheet row: %w", err)
+		}
+
+		result.Total += item.Duration
+		result.Rows = append(result.Rows, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return Timesheet{}, fmt.Errorf("error iterating timesheet rows: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r Repository) GetById(ctx context.Context, id int) (entity.Worklogs, error) {
+	var detail entity.Worklogs
+	err := r.NewSelect().
+		Model(&detail).
+		Where("id = ? AND deleted_at IS NULL", id).
+		Scan(ctx)
+
+	if err != nil {
+		return entity.Worklogs{}, fmt.Errorf("error getting worklog: %w", err)
+	}
+
+	return detail, nil
+}
+
+func (r Repository) Create(ctx context.Context, data Create) (entity.Worklogs, error) {
+	var detail entity.Worklogs
+
+	startedAt, err := time.Parse(time.RFC3339, *data.StartedAt)
+	if err != nil {
+		return entity.Worklogs{}, basic_repo.InvalidField("started_at", "invalid StartedAt format: %v", err)
+	}
+	startedAt = startedAt.UTC()
+
+	if *data.Duration <= 0 || *data.Duration > MaxDuration {
+		return entity.Worklogs{}, basic_repo.InvalidField("duration", "duration must be between 1 and %d seconds", MaxDuration)
+	}
+
+	now := time.Now().UTC()
+	detail.TaskId = data.TaskId
+	detail.UserId = data.UserId
+	detail.StartedAt = &startedAt
+	detail.Duration = data.Duration
+	detail.Note = data.Note
+	detail.CreatedAt = &now
+
+	_, err = r.NewInsert().Model(&detail).Exec(ctx)
+	if err != nil {
+		return entity.Worklogs{}, fmt.Errorf("error creating worklog: %w", err)
+	}
+
+	return detail, nil
+}
+
+func (r Repository) Update(ctx context.Context, data Update) (entity.Worklogs, error) {
+	var detail entity.Worklogs
+
+	err := r.NewSelect().Model(&detail).Where("id = ? AND deleted_at IS NULL", data.Id).Scan(ctx)
+	if err != nil {
+		return entity.Worklogs{}, err
+	}
+
+	if data.StartedAt != nil {
+		startedAt, err := time.Parse(time.RFC3339, *data.StartedAt)
+		if err != nil {
+			return entity.Worklogs{}, basic_repo.InvalidField("started_at", "invalid StartedAt format: %v", err)
+		}
+		startedAt = startedAt.UTC()
+		detail.StartedAt = &startedAt
+	}
+	if data.Duration != nil {
+		if detail.Duration == nil {
+			return entity.Worklogs{}, basic_repo.Conflict("duration of a running timer can't be changed, stop it first")
+		}
+		if *data.Duration <= 0 || *data.Duration > MaxDuration {
+			return entity.Worklogs{}, basic_repo.InvalidField("duration", "duration must be between 1 and %d seconds", MaxDuration)
+		}
+		detail.Duration = data.Duration
+	}
+	if data.Note != nil {
+		detail.Note = data.Note
+	}
+
+	now := time.Now().UTC()
+	detail.UpdateAt = &now
+
+	_, err = r.NewUpdate().Model(&detail).Where("id = ?", detail.Id).Exec(ctx)
+	if err != nil {
+		return entity.Worklogs{}, err
+	}
+
+	return detail, nil
+}
+
+func (r Repository) Delete(ctx context.Context, data basic_repo.Delete) error {
+	return basic_repo.BasicDelete(ctx, data, &entity.Worklogs{}, r.DB)
+}
+
+// StartTimer opens a worklog without a duration. The partial unique index on
+// running entries guarantees one running timer per user, also when two
+// requests race.
+func (r Repository) StartTimer(ctx context.Context, data StartTimer) (entity.Worklogs, error) {
+	var detail entity.Worklogs
+
+	now := time.Now().UTC()
+	detail.TaskId = data.TaskId
+	detail.UserId = data.UserId
+	detail.StartedAt = &now
+	detail.Note = data.Note
+	detail.CreatedAt = &now
+
+	_, err := r.NewInsert().Model(&detail).Exec(ctx)
+	if err != nil {
+		var pgErr pgdriver.Error
+		if errors.As(err, &pgErr) && pgErr.Field('C') == "23505" {
+			return entity.Worklogs{}, ErrTimerRunning
+		}
+		return entity.Worklogs{}, fmt.Errorf("error starting timer: %w", err)
+	}
+
+	return detail, nil
+}
+
+func (r Repository) StopTimer(ctx context.Context, data StopTimer) (entity.Worklogs, error) {
+	var detail entity.Worklogs
+
+	now := time.Now().UTC()
+
+	query := r.NewUpdate().
+		Model(&detail).
+		Set("duration = LEAST(GREATEST(EXTRACT(EPOCH FROM (?::timestamp - started_at))::int, 1), ?)", now, MaxDuration).
+		Set("updated_at = ?", now).
+		Where("user_id = ? AND duration IS NULL AND deleted_at IS NULL", *data.UserId).
+		Returning("*")
+
+	if data.Note != nil {
+		query = query.Set("note = ?", *data.Note)
+	}
+
+	result, err := query.Exec(ctx)
+	if err != nil {
+		return entity.Worklogs{}, fmt.Errorf("error stopping timer: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return entity.Worklogs{}, err
+	}
+
+	if rowsAffected == 0 {
+		return entity.Worklogs{}, ErrTimerNotRunning
+	}
+
+	return detail, nil
+}
@@ -0,0 +1,20 @@
+package calendar
+
+import (
+	"github.com/gin-gonic/gin"
+	"task-management2/internal/controller/http/v1/calendar"
+)
+
+func Router(g *gin.RouterGroup, calendarController *calendar.Controller) {
+	calendarG := g.Group("/calendar")
+	{
+		// create-token
+		calendarG.POST("/token/:user_id", calendarController.CreateToken)
+		// revoke-token
+		calendarG.DELETE("/token/:user_id", calendarController.RevokeToken)
+		// user-feed
+		calendarG.GET("/user/:file", calendarController.UserFeed)
+		// project-feed
+		calendarG.GET("/project/:file", calendarController.ProjectFeed)
+	}
+}
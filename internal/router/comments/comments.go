@@ -0,0 +1,18 @@
+package comments
+
+import (
+	"github.com/gin-gonic/gin"
+	"task-management2/internal/controller/http/v1/comments"
+)
+
+func Router(g *gin.RouterGroup, commentsController *comments.Controller) {
+	taskG := g.Group("/task")
+	{
+		// get-list
+		taskG.GET("/:id/comments", commentsController.GetList)
+		// create
+		taskG.POST("/:id/comments", commentsController.Create)
+		// delete
+		taskG.DELETE("/:id/comments/:comment_id", commentsController.Delete)
+	}
+}
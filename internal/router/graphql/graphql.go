@@ -0,0 +1,11 @@
+package graphql
+
+import (
+	"github.com/gin-gonic/gin"
+	"task-management2/internal/controller/http/v1/graphql"
+)
+
+func Router(g *gin.RouterGroup, graphqlController *graphql.Controller) {
+	// users, projects and tasks with their relations, see schema.graphql
+	g.POST("/graphql", graphqlController.Query)
+}
@@ -0,0 +1,34 @@
+package notifications
+
+import (
+	"github.com/gin-gonic/gin"
+	"task-management2/internal/controller/http/v1/notifications"
+)
+
+func Router(g *gin.RouterGroup, notificationsController *notifications.Controller) {
+	notificationG := g.Group("/notification")
+	{
+		// get-list
+		notificationG.GET("/list", notificationsController.GetList)
+		// unread-count
+		notificationG.GET("/unread-count", notificationsController.UnreadCount)
+		// mark-all-read
+		notificationG.POST("/read-all", notificationsController.MarkAllRead)
+		// mark-read
+		notificationG.POST("/:id/read", notificationsController.MarkRead)
+		// get-preferences
+		notificationG.GET("/preferences/:user_id", notificationsController.GetPreferences)
+		// update-preferences
+		notificationG.PUT("/preferences/:user_id", notificationsController.UpdatePreferences)
+	}
+
+	taskG := g.Group("/task")
+	{
+		// get-watchers
+		taskG.GET("/:id/watchers", notificationsController.GetWatchers)
+		// watch
+		taskG.POST("/:id/watchers", notificationsController.AddWatcher)
+		// unwatch
+		taskG.DELETE("/:id/watchers/:user_id", notificationsController.RemoveWatcher)
+	}
+}
@@ -0,0 +1,13 @@
+package openapi
+
+import (
+	"github.com/gin-gonic/gin"
+	"task-management2/internal/pkg/openapi"
+)
+
+func Router(g *gin.RouterGroup, spec *openapi.Spec) {
+	// OpenAPI document
+	g.GET("/openapi.json", spec.Handler)
+	// interactive docs
+	g.GET("/docs", openapi.Docs(g.BasePath()+"/openapi.json"))
+}
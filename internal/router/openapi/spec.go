@@ -0,0 +1,380 @@
+package openapi
+
+import (
+	"net/http"
+	"strings"
+
+	basic_controller "task-management2/internal/controller/http/v1/_basic_controller"
+	"task-management2/internal/controller/http/v1/graphql"
+	"task-management2/internal/entity"
+	"task-management2/internal/pkg/openapi"
+	"task-management2/internal/repository/postgres/calendar"
+	"task-management2/internal/repository/postgres/comments"
+	"task-management2/internal/repository/postgres/notifications"
+	"task-management2/internal/repository/postgres/projects"
+	"task-management2/internal/repository/postgres/recurrences"
+	"task-management2/internal/repository/postgres/sprints"
+	"task-management2/internal/repository/postgres/tasks"
+	"task-management2/internal/repository/postgres/trash"
+	"task-management2/internal/repository/postgres/users"
+	"task-management2/internal/repository/postgres/webhooks"
+	"task-management2/internal/repository/postgres/worklogs"
+)
+
+const v1 = "/api/v1"
+
+var (
+	ok = openapi.Message(nil)
+
+	limit  = openapi.Param{Name: "limit", Type: "integer", Description: "page size"}
+	offset = openapi.Param{Name: "offset", Type: "integer", Description: "page number, starting at 1"}
+
+	fields = openapi.Param{Name: "fields", Description: "comma separated fields to return, every field by default"}
+
+	weighting = openapi.Param{Name: "weighting", Enum: []string{"count", "points", "estimate"}, Description: "what progress is measured in"}
+	date      = openapi.Param{Type: "string", Format: "date"}
+
+	timeZone       = openapi.Param{Name: "tz", Description: "IANA time zone days are taken in, UTC by default"}
+	timeZoneHeader = openapi.Param{Name: "X-Timezone", Description: "IANA time zone days are taken in, the tz parameter wins"}
+
+	authorization = openapi.Param{Name: "Authorization", Description: "Bearer and the token of the user, see POST /calendar/token/{user_id}"}
+
+	ifMatch     = openapi.Param{Name: "If-Match", Description: "ETag of the version being changed, 412 when it is stale"}
+	ifNoneMatch = openapi.Param{Name: "If-None-Match", Description: "ETag of a cached copy, 304 when it is current"}
+)
+
+func integer(name, description string) openapi.Param {
+	return openapi.Param{Name: name, Type: "integer", Description: description}
+}
+
+func include(relations ...string) openapi.Param {
+	return openapi.Param{Name: "include", Description: "comma separated related records to embed: " + strings.Join(relations, ", ")}
+}
+
+func named(p openapi.Param, name string) openapi.Param {
+	p.Name = name
+	return p
+}
+
+// Spec is the OpenAPI document of every route of the API. A new route needs
+// an entry, TestSpecCoversEveryRoute fails on a route missing here.
+func Spec() *openapi.Spec {
+	spec := openapi.New("Task management API", "1.0.0")
+
+	spec.Errors(openapi.Object{
+		"status":  false,
+		"code":    "",
+		"message": "",
+		"fields":  []basic_controller.FieldError{},
+	})
+
+	spec.Add(
+		openapi.Operation{Method: http.MethodGet, Path: "/api/openapi.json", Tag: "docs", Summary: "This document", Response: openapi.Object{}},
+		openapi.Operation{Method: http.MethodGet, Path: "/api/docs", Tag: "docs", Summary: "Interactive API docs", Response: openapi.Binary{}, ResponseType: "text/html"},
+		openapi.Operation{Method: http.MethodPost, Path: "/api/graphql", Tag: "graphql", Summary: "Query users, projects and tasks with GraphQL",
+			Body: graphql.Request{}, Response: openapi.Object{"data": openapi.Object{}, "errors": []openapi.Object{}}},
+		openapi.Operation{Method: http.MethodGet, Path: v1 + "/time", Tag: "time", Summary: "Server time", Response: openapi.Message(openapi.Object{
+			"time": "", "time_in_seconds": 0, "unix": 0, "date": "", "week_day": 0, "full_date": "",
+			"month": 0, "day": 0, "year": 0, "hour": 0, "minute": 0, "second": 0,
+		})},
+	)
+
+	spec.Add(userOperations()...)
+	spec.Add(taskOperations()...)
+	spec.Add(projectOperations()...)
+	spec.Add(sprintOperations()...)
+	spec.Add(worklogOperations()...)
+	spec.Add(notificationOperations()...)
+	spec.Add(webhookOperations()...)
+	spec.Add(calendarOperations()...)
+	spec.Add(
+		openapi.Operation{Method: http.MethodGet, Path: v1 + "/export/excel", Tag: "export", Summary: "Export users, projects, tasks and worklogs as a spreadsheet",
+			Response: openapi.Binary{}, ResponseType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"},
+		openapi.Operation{Method: http.MethodGet, Path: v1 + "/trash", Tag: "trash", Summary: "List deleted users, projects and tasks",
+			Query:    []openapi.Param{{Name: "type", Enum: []string{"user", "project", "task"}}, limit, offset},
+			Response: openapi.List([]trash.Item{})},
+		openapi.Operation{Method: http.MethodGet, Path: v1 + "/stream", Tag: "stream", Summary: "Server-sent events of the changes a user may see",
+			Query: []openapi.Param{
+				{Name: "token", Description: "token of the user, for EventSource which can't send the Authorization header"},
+				integer("project_id", "only events of this project"),
+				integer("last_event_id", "resume after this event, the Last-Event-ID header wins"),
+			},
+			Headers:  []openapi.Param{authorization, {Name: "Last-Event-ID", Type: "integer"}},
+			Response: openapi.Binary{}, ResponseType: "text/event-stream"},
+	)
+
+	return spec
+}
+
+func userOperations() []openapi.Operation {
+	const path = v1 + "/user"
+
+	return []openapi.Operation{
+		{Method: http.MethodGet, Path: path + "/list", Tag: "users", Summary: "List users",
+			Query:    []openapi.Param{limit, integer("offset", "users to skip"), fields, include("projects")},
+			Response: openapi.List([]users.List{})},
+		{Method: http.MethodGet, Path: path + "/:id", Tag: "users", Summary: "Get a user",
+			Query: []openapi.Param{fields, include("projects")}, Headers: []openapi.Param{ifNoneMatch},
+			Response: openapi.Message(users.Detail{})},
+		{Method: http.MethodPost, Path: path + "/create", Tag: "users", Summary: "Create a user",
+			Body: users.Create{}, Response: openapi.Object{"message": "", "data": entity.User{}}},
+		{Method: http.MethodPut, Path: path + "/:id", Tag: "users", Summary: "Update a user",
+			Headers: []openapi.Param{ifMatch}, Body: users.Update{}, Response: openapi.Object{"message": "", "data": entity.User{}}},
+		{Method: http.MethodPatch, Path: path + "/:id", Tag: "users", Summary: "Merge patch a user",
+			Headers: []openapi.Param{ifMatch}, Body: users.Patchable{}, BodyType: "application/merge-patch+json",
+			Response: openapi.Object{"message": "", "data": users.Detail{}}},
+		{Method: http.MethodDelete, Path: path + "/:id", Tag: "users", Summary: "Move a user to the trash",
+			Headers: []openapi.Param{ifMatch}, Response: ok},
+		{Method: http.MethodPost, Path: path + "/:id/restore", Tag: "users", Summary: "Restore a deleted user",
+			Response: openapi.Message(users.Detail{})},
+	}
+}
+
+func taskOperations() []openapi.Operation {
+	const path = v1 + "/task"
+
+	return []openapi.Operation{
+		{Method: http.MethodGet, Path: path + "/list", Tag: "tasks", Summary: "List tasks",
+			Query: []openapi.Param{
+				integer("project_id", ""), integer("sprint_id", ""), integer("milestone_id", ""),
+				{Name: "status", Enum: []string{"pending", "in_progress", "completed"}}, integer("assigned_to", ""),
+				limit, offset, weighting, fields, include("assignee", "project"),
+			},
+			Response: openapi.Object{"data": []entity.Tasks{}, "count": 0, "task_stats": tasks.TaskStats{}}},
+		{Method: http.MethodGet, Path: path + "/calendar", Tag: "tasks", Summary: "Tasks by due date",
+			Query: []openapi.Param{
+				named(date, "from"), named(date, "to"),
+				integer("project_id", ""), integer("assigned_to", ""), timeZone,
+			},
+			Headers:  []openapi.Param{timeZoneHeader},
+			Response: openapi.Data(tasks.Calendar{})},
+		{Method: http.MethodPost, Path: path + "/create", Tag: "tasks", Summary: "Create a task",
+			Body: tasks.Create{}, Status: http.StatusCreated, Response: openapi.Data(entity.Tasks{})},
+		{Method: http.MethodPost, Path: path + "/bulk", Tag: "tasks", Summary: "Update or delete several tasks at once",
+			Body: tasks.Bulk{}, Response: openapi.List([]entity.Tasks{})},
+		{Method: http.MethodPost, Path: path + "/bulk/create", Tag: "tasks", Summary: "Create several tasks at once",
+			Body: tasks.BulkCreate{}, Status: http.StatusCreated, Response: openapi.List([]entity.Tasks{})},
+		{Method: http.MethodGet, Path: path + "/:id", Tag: "tasks", Summary: "Get a task",
+			Query: []openapi.Param{fields, include("assignee", "project")}, Headers: []openapi.Param{ifNoneMatch},
+			Response: openapi.Data(entity.Tasks{})},
+		{Method: http.MethodPut, Path: path + "/:id", Tag: "tasks", Summary: "Update a task",
+			Headers: []openapi.Param{ifMatch}, Body: tasks.Update{}, Response: openapi.Data(entity.Tasks{})},
+		{Method: http.MethodPatch, Path: path + "/:id", Tag: "tasks", Summary: "Merge patch a task",
+			Headers: []openapi.Param{ifMatch}, Body: tasks.Patchable{}, BodyType: "application/merge-patch+json",
+			Response: openapi.Data(entity.Tasks{})},
+		{Method: http.MethodDelete, Path: path + "/:id", Tag: "tasks", Summary: "Move a task to the trash",
+			Headers: []openapi.Param{ifMatch}, Response: ok},
+		{Method: http.MethodPost, Path: path + "/:id/move", Tag: "tasks", Summary: "Move a task on the board",
+			Body: tasks.Move{}, Response: openapi.Data(entity.Tasks{})},
+		{Method: http.MethodPost, Path: path + "/:id/restore", Tag: "tasks", Summary: "Restore a deleted task",
+			Response: openapi.Message(entity.Tasks{})},
+
+		{Method: http.MethodGet, Path: path + "/:id/comments", Tag: "comments", Summary: "List the comments of a task",
+			Query: []openapi.Param{limit, offset}, Response: openapi.List([]comments.List{})},
+		{Method: http.MethodPost, Path: path + "/:id/comments", Tag: "comments", Summary: "Comment on a task",
+			Body: comments.Create{}, Status: http.StatusCreated, Response: openapi.Data(entity.TaskComments{})},
+		{Method: http.MethodDelete, Path: path + "/:id/comments/:comment_id", Tag: "comments", Summary: "Delete a comment",
+			Response: ok},
+
+		{Method: http.MethodGet, Path: path + "/:id/recurrence", Tag: "recurrences", Summary: "Get the recurrence of a task",
+			Response: openapi.Data(recurrences.Detail{})},
+		{Method: http.MethodPut, Path: path + "/:id/recurrence", Tag: "recurrences", Summary: "Set the recurrence of a task",
+			Body: recurrences.Create{}, Response: openapi.Data(recurrences.Detail{})},
+		{Method: http.MethodDelete, Path: path + "/:id/recurrence", Tag: "recurrences", Summary: "Stop a task recurring",
+			Response: ok},
+
+		{Method: http.MethodGet, Path: path + "/:id/watchers", Tag: "notifications", Summary: "List the users watching a task",
+			Response: openapi.Data([]int{})},
+		{Method: http.MethodPost, Path: path + "/:id/watchers", Tag: "notifications", Summary: "Watch a task",
+			Body: notifications.Watcher{}, Response: ok},
+		{Method: http.MethodDelete, Path: path + "/:id/watchers/:user_id", Tag: "notifications", Summary: "Stop watching a task",
+			Response: ok},
+	}
+}
+
+func projectOperations() []openapi.Operation {
+	const path = v1 + "/projects"
+	const milestone = path + "/:id/milestones/:milestone_id"
+
+	return []openapi.Operation{
+		{Method: http.MethodGet, Path: path + "/list", Tag: "projects", Summary: "List projects with task statistics",
+			Query:    []openapi.Param{integer("owner_id", ""), limit, offset, weighting, fields, include("owner")},
+			Response: openapi.Message(openapi.Object{"results": []projects.List{}, "count": 0})},
+		{Method: http.MethodPost, Path: path + "/create", Tag: "projects", Summary: "Create a project",
+			Body: projects.Create{}, Response: openapi.Message(entity.Projects{})},
+		{Method: http.MethodGet, Path: path + "/:id", Tag: "projects", Summary: "Get a project",
+			Query: []openapi.Param{weighting, fields, include("owner")}, Headers: []openapi.Param{ifNoneMatch},
+			Response: openapi.Message(projects.Detail{})},
+		{Method: http.MethodPut, Path: path + "/:id", Tag: "projects", Summary: "Update a project",
+			Headers: []openapi.Param{ifMatch}, Body: projects.Update{}, Response: openapi.Message(entity.Projects{})},
+		{Method: http.MethodPatch, Path: path + "/:id", Tag: "projects", Summary: "Merge patch a project",
+			Headers: []openapi.Param{ifMatch}, Body: projects.Patchable{}, BodyType: "application/merge-patch+json",
+			Response: openapi.Message(entity.Projects{})},
+		{Method: http.MethodDelete, Path: path + "/:id", Tag: "projects", Summary: "Move a project and its tasks to the trash",
+			Headers: []openapi.Param{ifMatch}, Response: ok},
+		{Method: http.MethodPost, Path: path + "/:id/restore", Tag: "projects", Summary: "Restore a deleted project",
+			Response: openapi.Message(entity.Projects{})},
+		{Method: http.MethodGet, Path: path + "/:id/timeline", Tag: "projects", Summary: "Milestones and sprints of a project over time",
+			Response: openapi.Message(projects.Timeline{})},
+		{Method: http.MethodGet, Path: path + "/:id/board", Tag: "projects", Summary: "Kanban board of a project",
+			Response: openapi.Message(projects.Board{})},
+		{Method: http.MethodPut, Path: path + "/:id/board/wip-limits", Tag: "projects", Summary: "Set the WIP limits of the board columns",
+			Body: projects.WipLimits{}, Response: openapi.Message(map[string]int{})},
+
+		{Method: http.MethodGet, Path: path + "/:id/milestones", Tag: "milestones", Summary: "List the milestones of a project",
+			Query:    []openapi.Param{weighting, integer("at_risk_days", "days before the due date a milestone is at risk")},
+			Response: openapi.Message([]projects.Milestone{})},
+		{Method: http.MethodPost, Path: path + "/:id/milestones", Tag: "milestones", Summary: "Create a milestone",
+			Body: projects.MilestoneCreate{}, Status: http.StatusCreated, Response: openapi.Message(entity.Milestones{})},
+		{Method: http.MethodGet, Path: milestone, Tag: "milestones", Summary: "Get a milestone",
+			Query:    []openapi.Param{weighting, integer("at_risk_days", "days before the due date a milestone is at risk")},
+			Response: openapi.Message(projects.Milestone{})},
+		{Method: http.MethodPut, Path: milestone, Tag: "milestones", Summary: "Update a milestone",
+			Body: projects.MilestoneUpdate{}, Response: openapi.Message(entity.Milestones{})},
+		{Method: http.MethodDelete, Path: milestone, Tag: "milestones", Summary: "Delete a milestone",
+			Response: ok},
+		{Method: http.MethodPost, Path: milestone + "/tasks", Tag: "milestones", Summary: "Add tasks to a milestone",
+			Body: projects.MilestoneTasks{}, Response: openapi.Message([]entity.Tasks{})},
+		{Method: http.MethodDelete, Path: milestone + "/tasks/:task_id", Tag: "milestones", Summary: "Remove a task from a milestone",
+			Response: ok},
+	}
+}
+
+func sprintOperations() []openapi.Operation {
+	const path = v1 + "/sprint"
+
+	return []openapi.Operation{
+		{Method: http.MethodGet, Path: path + "/list", Tag: "sprints", Summary: "List sprints",
+			Query: []openapi.Param{
+				integer("project_id", ""),
+				{Name: "state", Enum: []string{sprints.StatePlanned, sprints.StateActive, sprints.StateClosed}},
+				limit, offset,
+			},
+			Response: openapi.List([]entity.Sprints{})},
+		{Method: http.MethodGet, Path: path + "/velocity", Tag: "sprints", Summary: "Velocity of the closed sprints of a project",
+			Query:    []openapi.Param{{Name: "project_id", Type: "integer", Required: true}},
+			Response: openapi.Data(sprints.Velocity{})},
+		{Method: http.MethodPost, Path: path + "/create", Tag: "sprints", Summary: "Create a sprint",
+			Body: sprints.Create{}, Status: http.StatusCreated, Response: openapi.Data(entity.Sprints{})},
+		{Method: http.MethodGet, Path: path + "/:id", Tag: "sprints", Summary: "Get a sprint",
+			Response: openapi.Data(entity.Sprints{})},
+		{Method: http.MethodPut, Path: path + "/:id", Tag: "sprints", Summary: "Update a sprint",
+			Body: sprints.Update{}, Response: openapi.Data(entity.Sprints{})},
+		{Method: http.MethodDelete, Path: path + "/:id", Tag: "sprints", Summary: "Delete a sprint",
+			Response: ok},
+		{Method: http.MethodPost, Path: path + "/:id/start", Tag: "sprints", Summary: "Start a sprint",
+			Response: openapi.Data(entity.Sprints{})},
+		{Method: http.MethodPost, Path: path + "/:id/close", Tag: "sprints", Summary: "Close a sprint",
+			Body: sprints.Close{}, Response: openapi.Data(entity.Sprints{})},
+		{Method: http.MethodGet, Path: path + "/:id/report", Tag: "sprints", Summary: "Burndown and completion of a sprint",
+			Response: openapi.Data(sprints.Report{})},
+		{Method: http.MethodPost, Path: path + "/:id/tasks", Tag: "sprints", Summary: "Add tasks to a sprint",
+			Body: sprints.AssignTasks{}, Response: openapi.Data([]entity.Tasks{})},
+		{Method: http.MethodDelete, Path: path + "/:id/tasks/:task_id", Tag: "sprints", Summary: "Remove a task from a sprint",
+			Response: ok},
+	}
+}
+
+func worklogOperations() []openapi.Operation {
+	const path = v1 + "/worklog"
+
+	filter := []openapi.Param{
+		integer("task_id", ""), integer("user_id", ""), integer("project_id", ""),
+		named(date, "from"), named(date, "to"), timeZone,
+	}
+
+	return []openapi.Operation{
+		{Method: http.MethodGet, Path: path + "/list", Tag: "worklogs", Summary: "List worklogs",
+			Query: append(filter, limit, offset), Response: openapi.List([]worklogs.List{})},
+		{Method: http.MethodGet, Path: path + "/totals", Tag: "worklogs", Summary: "Logged time per task, user or project",
+			Query:    append(filter, openapi.Param{Name: "group_by", Enum: []string{"task", "user", "project"}}),
+			Response: openapi.Object{"data": []worklogs.Total{}, "group_by": "", "total": 0}},
+		{Method: http.MethodGet, Path: path + "/timesheet", Tag: "worklogs", Summary: "Logged time per user and day",
+			Query: filter, Headers: []openapi.Param{timeZoneHeader},
+			Response: openapi.Data(worklogs.Timesheet{})},
+		{Method: http.MethodPost, Path: path + "/create", Tag: "worklogs", Summary: "Log time",
+			Body: worklogs.Create{}, Status: http.StatusCreated, Response: openapi.Data(entity.Worklogs{})},
+		{Method: http.MethodPost, Path: path + "/timer/start", Tag: "worklogs", Summary: "Start a timer",
+			Body: worklogs.StartTimer{}, Status: http.StatusCreated, Response: openapi.Data(entity.Worklogs{})},
+		{Method: http.MethodPost, Path: path + "/timer/stop", Tag: "worklogs", Summary: "Stop the running timer",
+			Body: worklogs.StopTimer{}, Response: openapi.Data(entity.Worklogs{})},
+		{Method: http.MethodGet, Path: path + "/:id", Tag: "worklogs", Summary: "Get a worklog",
+			Response: openapi.Data(entity.Worklogs{})},
+		{Method: http.MethodPut, Path: path + "/:id", Tag: "worklogs", Summary: "Update a worklog",
+			Body: worklogs.Update{}, Response: openapi.Data(entity.Worklogs{})},
+		{Method: http.MethodDelete, Path: path + "/:id", Tag: "worklogs", Summary: "Delete a worklog",
+			Response: ok},
+	}
+}
+
+func notificationOperations() []openapi.Operation {
+	const path = v1 + "/notification"
+
+	return []openapi.Operation{
+		{Method: http.MethodGet, Path: path + "/list", Tag: "notifications", Summary: "List the notifications of a user",
+			Query: []openapi.Param{
+				{Name: "user_id", Type: "integer", Required: true},
+				{Name: "unread", Type: "boolean"},
+				limit, offset,
+			},
+			Response: openapi.Object{"data": []entity.Notifications{}, "count": 0, "unread": 0}},
+		{Method: http.MethodGet, Path: path + "/unread-count", Tag: "notifications", Summary: "Count the unread notifications of a user",
+			Query:    []openapi.Param{{Name: "user_id", Type: "integer", Required: true}},
+			Response: openapi.Object{"unread": 0}},
+		{Method: http.MethodPost, Path: path + "/:id/read", Tag: "notifications", Summary: "Mark a notification read",
+			Response: openapi.Data(entity.Notifications{})},
+		{Method: http.MethodPost, Path: path + "/read-all", Tag: "notifications", Summary: "Mark every notification of a user read",
+			Body: notifications.MarkAllRead{}, Response: openapi.Object{"message": "", "status": true, "updated": 0}},
+		{Method: http.MethodGet, Path: path + "/preferences/:user_id", Tag: "notifications", Summary: "Get the notification preferences of a user",
+			Response: openapi.Data(entity.NotificationPreferences{})},
+		{Method: http.MethodPut, Path: path + "/preferences/:user_id", Tag: "notifications", Summary: "Set the notification preferences of a user",
+			Body: notifications.UpdatePreferences{}, Response: openapi.Data(entity.NotificationPreferences{})},
+	}
+}
+
+func webhookOperations() []openapi.Operation {
+	const path = v1 + "/webhook"
+
+	return []openapi.Operation{
+		{Method: http.MethodGet, Path: path + "/list", Tag: "webhooks", Summary: "List webhooks",
+			Query: []openapi.Param{integer("project_id", ""), limit, offset}, Response: openapi.List([]entity.Webhooks{})},
+		{Method: http.MethodPost, Path: path + "/create", Tag: "webhooks", Summary: "Create a webhook, the secret is only returned here",
+			Body: webhooks.Create{}, Status: http.StatusCreated, Response: openapi.Object{"data": entity.Webhooks{}, "secret": ""}},
+		{Method: http.MethodGet, Path: path + "/:id", Tag: "webhooks", Summary: "Get a webhook",
+			Response: openapi.Data(entity.Webhooks{})},
+		{Method: http.MethodPut, Path: path + "/:id", Tag: "webhooks", Summary: "Update a webhook",
+			Body: webhooks.Update{}, Response: openapi.Data(entity.Webhooks{})},
+		{Method: http.MethodDelete, Path: path + "/:id", Tag: "webhooks", Summary: "Delete a webhook",
+			Response: ok},
+		{Method: http.MethodPost, Path: path + "/:id/ping", Tag: "webhooks", Summary: "Queue a ping delivery",
+			Status: http.StatusAccepted, Response: openapi.Data(webhooks.Delivery{})},
+		{Method: http.MethodGet, Path: path + "/:id/deliveries", Tag: "webhooks", Summary: "List the deliveries of a webhook",
+			Query: []openapi.Param{
+				{Name: "status", Enum: []string{webhooks.StatusPending, webhooks.StatusSucceeded, webhooks.StatusFailed}},
+				limit, offset,
+			},
+			Response: openapi.List([]webhooks.Delivery{})},
+		{Method: http.MethodPost, Path: path + "/:id/deliveries/:delivery_id/redeliver", Tag: "webhooks", Summary: "Queue a delivery again",
+			Status: http.StatusAccepted, Response: openapi.Data(webhooks.Delivery{})},
+	}
+}
+
+func calendarOperations() []openapi.Operation {
+	const path = v1 + "/calendar"
+
+	token := []openapi.Param{{Name: "token", Description: "token of the user, unless sent as a bearer token"}}
+	// the first token is created with the user's email and password as
+	// basic auth, the next ones with the current token as well
+	owner := []openapi.Param{authorization}
+
+	return []openapi.Operation{
+		{Method: http.MethodGet, Path: path + "/user/:file", Tag: "calendar", Summary: "iCalendar feed of the tasks of a user, file is {user_id}.ics",
+			Query: token, Headers: []openapi.Param{authorization}, Response: openapi.Binary{}, ResponseType: "text/calendar"},
+		{Method: http.MethodGet, Path: path + "/project/:file", Tag: "calendar", Summary: "iCalendar feed of the tasks of a project, file is {project_id}.ics",
+			Query: token, Headers: []openapi.Param{authorization}, Response: openapi.Binary{}, ResponseType: "text/calendar"},
+		{Method: http.MethodPost, Path: path + "/token/:user_id", Tag: "calendar", Summary: "Create the token of a user, replacing the old one",
+			Headers: owner, Status: http.StatusCreated, Response: openapi.Message(openapi.Object{"token": calendar.Token{}, "user_feed": "", "project_feed": ""})},
+		{Method: http.MethodDelete, Path: path + "/token/:user_id", Tag: "calendar", Summary: "Revoke the token of a user",
+			Headers: owner, Response: ok},
+	}
+}
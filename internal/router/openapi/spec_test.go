@@ -0,0 +1,52 @@
+package openapi_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"task-management2/internal/controller/http/v1/calendar"
+	"task-management2/internal/controller/http/v1/comments"
+	"task-management2/internal/controller/http/v1/export"
+	"task-management2/internal/controller/http/v1/graphql"
+	"task-management2/internal/controller/http/v1/notifications"
+	"task-management2/internal/controller/http/v1/projects"
+	"task-management2/internal/controller/http/v1/recurrences"
+	"task-management2/internal/controller/http/v1/sprints"
+	"task-management2/internal/controller/http/v1/stream"
+	"task-management2/internal/controller/http/v1/tasks"
+	"task-management2/internal/controller/http/v1/trash"
+	"task-management2/internal/controller/http/v1/users"
+	"task-management2/internal/controller/http/v1/webhooks"
+	"task-management2/internal/controller/http/v1/worklogs"
+	"task-management2/internal/router"
+	"task-management2/internal/router/openapi"
+)
+
+// The routes are only registered, no request is served, so the controllers
+// go without repositories.
+func TestSpecCoversEveryRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	spec := openapi.Spec()
+	r := router.New(spec, router.Controllers{
+		Users:         users.NewController(nil),
+		Tasks:         tasks.NewController(nil),
+		Projects:      projects.NewController(nil),
+		Export:        export.NewController(nil, nil, nil, nil),
+		Calendar:      calendar.NewController(nil, nil),
+		Worklogs:      worklogs.NewController(nil),
+		Recurrences:   recurrences.NewController(nil),
+		Notifications: notifications.NewController(nil),
+		Comments:      comments.NewController(nil),
+		Webhooks:      webhooks.NewController(nil),
+		Sprints:       sprints.NewController(nil),
+		Stream:        stream.NewController(nil, nil),
+		Trash:         trash.NewController(nil, time.Hour),
+		GraphQL:       graphql.NewController(nil, nil, nil),
+	})
+
+	if err := spec.Check(r.Routes()); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -12,12 +12,36 @@ func Router(g *gin.RouterGroup, projectsController *projects.Controller) {
 		userG.GET("/list", projectsController.GetProjectsWithStats)
 		// get-detail
 		userG.GET("/:id", projectsController.ProjectGetDetail)
+		// timeline
+		userG.GET("/:id/timeline", projectsController.ProjectTimeline)
+		// kanban board
+		userG.GET("/:id/board", projectsController.ProjectBoard)
+		// board WIP limits
+		userG.PUT("/:id/board/wip-limits", projectsController.ProjectWipLimits)
+		// milestones with progress and health
+		userG.GET("/:id/milestones", projectsController.MilestoneList)
+		// milestone detail
+		userG.GET("/:id/milestones/:milestone_id", projectsController.MilestoneGetDetail)
+		// create milestone
+		userG.POST("/:id/milestones", projectsController.MilestoneCreate)
+		// update milestone
+		userG.PUT("/:id/milestones/:milestone_id", projectsController.MilestoneUpdate)
+		// delete milestone
+		userG.DELETE("/:id/milestones/:milestone_id", projectsController.MilestoneDelete)
+		// link tasks to a milestone
+		userG.POST("/:id/milestones/:milestone_id/tasks", projectsController.MilestoneAddTasks)
+		// unlink a task
+		userG.DELETE("/:id/milestones/:milestone_id/tasks/:task_id", projectsController.MilestoneRemoveTask)
 		// create
 		userG.POST("/create", projectsController.ProjectCreate)
 		// update
 		userG.PUT("/:id", projectsController.ProjectUpdate)
+		// merge patch
+		userG.PATCH("/:id", projectsController.ProjectPatch)
 		// delete
 		userG.DELETE("/:id", projectsController.ProjectDelete)
+		// restore from the trash
+		userG.POST("/:id/restore", projectsController.ProjectRestore)
 
 	}
 }
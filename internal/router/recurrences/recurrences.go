@@ -0,0 +1,18 @@
+package recurrences
+
+import (
+	"github.com/gin-gonic/gin"
+	"task-management2/internal/controller/http/v1/recurrences"
+)
+
+func Router(g *gin.RouterGroup, recurrencesController *recurrences.Controller) {
+	taskG := g.Group("/task")
+	{
+		// get-recurrence
+		taskG.GET("/:id/recurrence", recurrencesController.GetDetail)
+		// set-recurrence
+		taskG.PUT("/:id/recurrence", recurrencesController.Upsert)
+		// delete-recurrence
+		taskG.DELETE("/:id/recurrence", recurrencesController.Delete)
+	}
+}
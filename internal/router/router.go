@@ -0,0 +1,123 @@
+package router
+
+import (
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+
+	calendar_controller "task-management2/internal/controller/http/v1/calendar"
+	comments_controller "task-management2/internal/controller/http/v1/comments"
+	export_controller "task-management2/internal/controller/http/v1/export"
+	graphql_controller "task-management2/internal/controller/http/v1/graphql"
+	notifications_controller "task-management2/internal/controller/http/v1/notifications"
+	projects_controller "task-management2/internal/controller/http/v1/projects"
+	recurrences_controller "task-management2/internal/controller/http/v1/recurrences"
+	sprints_controller "task-management2/internal/controller/http/v1/sprints"
+	stream_controller "task-management2/internal/controller/http/v1/stream"
+	tasks_controller "task-management2/internal/controller/http/v1/tasks"
+	trash_controller "task-management2/internal/controller/http/v1/trash"
+	users_controller "task-management2/internal/controller/http/v1/users"
+	webhooks_controller "task-management2/internal/controller/http/v1/webhooks"
+	worklogs_controller "task-management2/internal/controller/http/v1/worklogs"
+	"task-management2/internal/pkg/openapi"
+	calendar_router "task-management2/internal/router/calendar"
+	comment_router "task-management2/internal/router/comments"
+	"task-management2/internal/router/export"
+	graphql_router "task-management2/internal/router/graphql"
+	notification_router "task-management2/internal/router/notifications"
+	openapi_router "task-management2/internal/router/openapi"
+	project_router "task-management2/internal/router/projects"
+	recurrence_router "task-management2/internal/router/recurrences"
+	sprint_router "task-management2/internal/router/sprints"
+	stream_router "task-management2/internal/router/stream"
+	task_router "task-management2/internal/router/tasks"
+	trash_router "task-management2/internal/router/trash"
+	user_router "task-management2/internal/router/users"
+	webhook_router "task-management2/internal/router/webhooks"
+	worklog_router "task-management2/internal/router/worklogs"
+)
+
+// Controllers are the controllers the HTTP API is served by.
+type Controllers struct {
+	Users         *users_controller.Controller
+	Tasks         *tasks_controller.Controller
+	Projects      *projects_controller.Controller
+	Export        *export_controller.Controller
+	Calendar      *calendar_controller.Controller
+	Worklogs      *worklogs_controller.Controller
+	Recurrences   *recurrences_controller.Controller
+	Notifications *notifications_controller.Controller
+	Comments      *comments_controller.Controller
+	Webhooks      *webhooks_controller.Controller
+	Sprints       *sprints_controller.Controller
+	Stream        *stream_controller.Controller
+	Trash         *trash_controller.Controller
+	GraphQL       *graphql_controller.Controller
+}
+
+// New returns the engine of the HTTP API, with every route of ctrl and the
+// OpenAPI document spec.
+func New(spec *openapi.Spec, ctrl Controllers) *gin.Engine {
+	r := gin.Default()
+	r.MaxMultipartMemory = 16 << 20
+
+	r.Use(cors.New(cors.Config{
+		AllowOrigins:     []string{"*"},
+		AllowMethods:     []string{"*"},
+		AllowHeaders:     []string{"*"},
+		ExposeHeaders:    []string{"*"},
+		AllowCredentials: true,
+		AllowOriginFunc: func(origin string) bool {
+			return true
+		},
+		MaxAge: 12 * time.Hour,
+	}))
+
+	api := r.Group("api")
+	{
+		openapi_router.Router(api, spec)
+		graphql_router.Router(api, ctrl.GraphQL)
+
+		v1 := api.Group("v1")
+
+		v1.GET("/time", func(c *gin.Context) {
+			now := time.Now()
+			c.JSON(http.StatusOK, gin.H{
+				"message": "ok!",
+				"status":  true,
+				"data": map[string]interface{}{
+					"time":            now.Format("15:04"),
+					"time_in_seconds": now.Hour()*3600 + now.Minute()*60 + now.Second(),
+					"unix":            now.Unix(),
+					"date":            now.Format("02.01.2006"),
+					"week_day":        now.Weekday(),
+					"full_date":       now.Format("02.01.2006 15:04:06"),
+					"month":           now.Month(),
+					"day":             now.Day(),
+					"year":            now.Year(),
+					"hour":            now.Hour(),
+					"minute":          now.Minute(),
+					"second":          now.Second(),
+				},
+			})
+		})
+
+		// Routers
+		user_router.Router(v1, ctrl.Users)
+		task_router.Router(v1, ctrl.Tasks)
+		project_router.Router(v1, ctrl.Projects)
+		export.Router(v1, ctrl.Export)
+		calendar_router.Router(v1, ctrl.Calendar)
+		worklog_router.Router(v1, ctrl.Worklogs)
+		recurrence_router.Router(v1, ctrl.Recurrences)
+		notification_router.Router(v1, ctrl.Notifications)
+		comment_router.Router(v1, ctrl.Comments)
+		webhook_router.Router(v1, ctrl.Webhooks)
+		sprint_router.Router(v1, ctrl.Sprints)
+		stream_router.Router(v1, ctrl.Stream)
+		trash_router.Router(v1, ctrl.Trash)
+	}
+
+	return r
+}
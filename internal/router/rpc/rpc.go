@@ -0,0 +1,22 @@
+package rpc
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	"task-management2/internal/controller/grpc/v1/projects"
+	"task-management2/internal/controller/grpc/v1/tasks"
+	"task-management2/internal/controller/grpc/v1/users"
+	"task-management2/internal/grpc/pb"
+)
+
+func Router(s *grpc.Server, userController *users.Controller, projectController *projects.Controller, taskController *tasks.Controller) {
+	// taskmanagement.v1.UserService
+	pb.RegisterUserServiceServer(s, userController)
+	// taskmanagement.v1.ProjectService
+	pb.RegisterProjectServiceServer(s, projectController)
+	// taskmanagement.v1.TaskService, with the WatchTasks event stream
+	pb.RegisterTaskServiceServer(s, taskController)
+
+	// lets grpcurl and similar tools list the services
+	reflection.Register(s)
+}
@@ -0,0 +1,34 @@
+package sprints
+
+import (
+	"github.com/gin-gonic/gin"
+	"task-management2/internal/controller/http/v1/sprints"
+)
+
+func Router(g *gin.RouterGroup, sprintsController *sprints.Controller) {
+	sprintG := g.Group("/sprint")
+	{
+		// get-list
+		sprintG.GET("/list", sprintsController.GetList)
+		// velocity of the last closed sprints
+		sprintG.GET("/velocity", sprintsController.GetVelocity)
+		// get-detail
+		sprintG.GET("/:id", sprintsController.GetDetail)
+		// create
+		sprintG.POST("/create", sprintsController.Create)
+		// update
+		sprintG.PUT("/:id", sprintsController.Update)
+		// delete
+		sprintG.DELETE("/:id", sprintsController.Delete)
+		// start
+		sprintG.POST("/:id/start", sprintsController.Start)
+		// close, carrying unfinished tasks over
+		sprintG.POST("/:id/close", sprintsController.Close)
+		// add tasks
+		sprintG.POST("/:id/tasks", sprintsController.AddTasks)
+		// remove a task
+		sprintG.DELETE("/:id/tasks/:task_id", sprintsController.RemoveTask)
+		// burn-up report
+		sprintG.GET("/:id/report", sprintsController.GetReport)
+	}
+}
@@ -0,0 +1,11 @@
+package stream
+
+import (
+	"github.com/gin-gonic/gin"
+	"task-management2/internal/controller/http/v1/stream"
+)
+
+func Router(g *gin.RouterGroup, streamController *stream.Controller) {
+	// server-sent events for task and project changes
+	g.GET("/stream", streamController.Stream)
+}
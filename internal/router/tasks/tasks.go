@@ -10,13 +10,25 @@ func Router(g *gin.RouterGroup, tasksController *tasks.Controller) {
 	{
 		// get-list
 		userG.GET("/list", tasksController.GetList)
+		// calendar
+		userG.GET("/calendar", tasksController.GetCalendar)
 		// get-detail
 		userG.GET("/:id", tasksController.GetDetail)
 		// create
 		userG.POST("/create", tasksController.Create)
 		// update
 		userG.PUT("/:id", tasksController.Update)
+		// merge patch
+		userG.PATCH("/:id", tasksController.Patch)
 		// delete
 		userG.DELETE("/:id", tasksController.Delete)
+		// restore from the trash
+		userG.POST("/:id/restore", tasksController.Restore)
+		// patch or delete many tasks at once
+		userG.POST("/bulk", tasksController.Bulk)
+		// create many tasks at once
+		userG.POST("/bulk/create", tasksController.BulkCreate)
+		// move on the board
+		userG.POST("/:id/move", tasksController.Move)
 	}
 }
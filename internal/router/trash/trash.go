@@ -0,0 +1,14 @@
+package trash
+
+import (
+	"github.com/gin-gonic/gin"
+	"task-management2/internal/controller/http/v1/trash"
+)
+
+func Router(g *gin.RouterGroup, trashController *trash.Controller) {
+	trashG := g.Group("/trash")
+	{
+		// deleted users, projects and tasks
+		trashG.GET("", trashController.GetList)
+	}
+}
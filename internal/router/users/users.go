@@ -16,8 +16,12 @@ func Router(g *gin.RouterGroup, userController *users.Controller) {
 		userG.POST("/create", userController.Create)
 		// update
 		userG.PUT("/:id", userController.Update)
+		// merge patch
+		userG.PATCH("/:id", userController.Patch)
 		// delete
 		userG.DELETE("/:id", userController.Delete)
+		// restore from the trash
+		userG.POST("/:id/restore", userController.Restore)
 
 	}
 }
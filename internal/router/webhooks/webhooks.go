@@ -0,0 +1,28 @@
+package webhooks
+
+import (
+	"github.com/gin-gonic/gin"
+	"task-management2/internal/controller/http/v1/webhooks"
+)
+
+func Router(g *gin.RouterGroup, webhooksController *webhooks.Controller) {
+	webhookG := g.Group("/webhook")
+	{
+		// get-list
+		webhookG.GET("/list", webhooksController.GetList)
+		// get-detail
+		webhookG.GET("/:id", webhooksController.GetDetail)
+		// create
+		webhookG.POST("/create", webhooksController.Create)
+		// update
+		webhookG.PUT("/:id", webhooksController.Update)
+		// delete
+		webhookG.DELETE("/:id", webhooksController.Delete)
+		// send a ping delivery
+		webhookG.POST("/:id/ping", webhooksController.Ping)
+		// delivery log
+		webhookG.GET("/:id/deliveries", webhooksController.GetDeliveries)
+		// redeliver
+		webhookG.POST("/:id/deliveries/:delivery_id/redeliver", webhooksController.Redeliver)
+	}
+}
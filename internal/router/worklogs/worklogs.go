@@ -0,0 +1,30 @@
+package worklogs
+
+import (
+	"github.com/gin-gonic/gin"
+	"task-management2/internal/controller/http/v1/worklogs"
+)
+
+func Router(g *gin.RouterGroup, worklogsController *worklogs.Controller) {
+	worklogG := g.Group("/worklog")
+	{
+		// get-list
+		worklogG.GET("/list", worklogsController.GetList)
+		// totals per task, user or project
+		worklogG.GET("/totals", worklogsController.GetTotals)
+		// timesheet report
+		worklogG.GET("/timesheet", worklogsController.GetTimesheet)
+		// get-detail
+		worklogG.GET("/:id", worklogsController.GetDetail)
+		// create
+		worklogG.POST("/create", worklogsController.Create)
+		// update
+		worklogG.PUT("/:id", worklogsController.Update)
+		// delete
+		worklogG.DELETE("/:id", worklogsController.Delete)
+		// start-timer
+		worklogG.POST("/timer/start", worklogsController.StartTimer)
+		// stop-timer
+		worklogG.POST("/timer/stop", worklogsController.StopTimer)
+	}
+}
@@ -0,0 +1,113 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"time"
+)
+
+const (
+	dateLayout     = "20060102"
+	dateTimeLayout = "20060102T150405Z"
+	maxLineOctets  = 75
+)
+
+// Calendar writes an RFC 5545 VCALENDAR object. Properties are written in
+// the order they are added, with text escaping and line folding applied.
+type Calendar struct {
+	buf bytes.Buffer
+}
+
+func NewCalendar(prodId, name string) *Calendar {
+	c := &Calendar{}
+	c.line("BEGIN:VCALENDAR")
+	c.line("VERSION:2.0")
+	c.line("PRODID:" + prodId)
+	c.line("CALSCALE:GREGORIAN")
+	c.line("METHOD:PUBLISH")
+	if name != "" {
+		c.Text("X-WR-CALNAME", name)
+	}
+
+	return c
+}
+
+func (c *Calendar) Begin(component string) {
+	c.line("BEGIN:" + component)
+}
+
+func (c *Calendar) End(component string) {
+	c.line("END:" + component)
+}
+
+// Text writes a TEXT valued property.
+func (c *Calendar) Text(name, value string) {
+	c.line(name + ":" + EscapeText(value))
+}
+
+// Raw writes a property whose value is already in iCalendar format.
+func (c *Calendar) Raw(name, value string) {
+	c.line(name + ":" + value)
+}
+
+// Date writes a DATE valued property, e.g. DUE;VALUE=DATE:20250122.
+func (c *Calendar) Date(name string, t time.Time) {
+	c.line(name + ";VALUE=DATE:" + t.Format(dateLayout))
+}
+
+// DateTime writes a UTC DATE-TIME valued property.
+func (c *Calendar) DateTime(name string, t time.Time) {
+	c.line(name + ":" + t.UTC().Format(dateTimeLayout))
+}
+
+func (c *Calendar) Bytes() []byte {
+	out := bytes.Buffer{}
+	out.Write(c.buf.Bytes())
+	out.WriteString("END:VCALENDAR\r\n")
+
+	return out.Bytes()
+}
+
+func (c *Calendar) line(s string) {
+	c.buf.WriteString(fold(s))
+	c.buf.WriteString("\r\n")
+}
+
+// EscapeText escapes a TEXT value as described in RFC 5545 section 3.3.11.
+func EscapeText(s string) string {
+	r := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	)
+
+	return r.Replace(s)
+}
+
+// fold splits content lines longer than 75 octets, never breaking inside a
+// multi-byte UTF-8 sequence.
+func fold(s string) string {
+	if len(s) <= maxLineOctets {
+		return s
+	}
+
+	var b strings.Builder
+	limit := maxLineOctets
+	n := 0
+	for _, r := range s {
+		size := len(string(r))
+		if n+size > limit {
+			b.WriteString("\r\n ")
+			n = 0
+			// continuation lines start with a space which counts toward the limit
+			limit = maxLineOctets - 1
+		}
+		b.WriteRune(r)
+		n += size
+	}
+
+	return b.String()
+}
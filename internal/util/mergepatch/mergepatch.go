@@ -0,0 +1,87 @@
+// Package mergepatch applies JSON merge patches (RFC 7396): members of the
+// patch replace those of the document, null removes them and nested
+// objects are merged recursively.
+package mergepatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// ContentType is the media type of merge patches.
+const ContentType = "application/merge-patch+json"
+
+// Apply merges patch into the JSON document doc.
+func Apply(doc, patch []byte) ([]byte, error) {
+	var target, p interface{}
+
+	if err := json.Unmarshal(doc, &target); err != nil {
+		return nil, fmt.Errorf("mergepatch: invalid document: %v", err)
+	}
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, fmt.Errorf("mergepatch: invalid patch: %v", err)
+	}
+
+	return json.Marshal(merge(target, p))
+}
+
+// ApplyTo merges patch into the JSON encoding of v, a pointer to a struct,
+// and decodes the result back into a zeroed v. Members of the patch that v
+// has no field for are an error, so read-only fields can be left out of v
+// to reject them.
+func ApplyTo(v interface{}, patch []byte) error {
+	doc, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	merged, err := Apply(doc, patch)
+	if err != nil {
+		return err
+	}
+
+	// removed members must end up as zero values
+	elem := reflect.ValueOf(v).Elem()
+	elem.Set(reflect.Zero(elem.Type()))
+
+	dec := json.NewDecoder(bytes.NewReader(merged))
+	dec.DisallowUnknownFields()
+
+	return dec.Decode(v)
+}
+
+// Has reports whether patch sets the member name, to null or otherwise.
+func Has(patch []byte, name string) bool {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &members); err != nil {
+		return false
+	}
+
+	_, ok := members[name]
+	return ok
+}
+
+func merge(target, patch interface{}) interface{} {
+	members, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	doc, ok := target.(map[string]interface{})
+	if !ok {
+		doc = map[string]interface{}{}
+	}
+
+	for name, value := range members {
+		if value == nil {
+			delete(doc, name)
+			continue
+		}
+
+		doc[name] = merge(doc[name], value)
+	}
+
+	return doc
+}
@@ -0,0 +1,109 @@
+// Package rank generates fractional ranks: strings that sort between two
+// existing ranks, so an item can be moved without renumbering its
+// neighbours. Ranks are base-62 digits compared byte by byte (COLLATE "C" in
+// Postgres) and never end in '0', which keeps a gap between any two of them.
+package rank
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const digits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// MaxLength is the rank length past which callers should renumber the list
+// with Sequence; repeated inserts at the same spot make ranks grow.
+const MaxLength = 32
+
+var ErrOrder = errors.New("rank: lower bound must sort before upper bound")
+
+// Between returns a rank after a and before b. An empty a means the start of
+// the list, an empty b its end.
+func Between(a, b string) (string, error) {
+	if err := validate(a); err != nil {
+		return "", err
+	}
+	if err := validate(b); err != nil {
+		return "", err
+	}
+	if b != "" && a >= b {
+		return "", ErrOrder
+	}
+
+	return midpoint(a, b), nil
+}
+
+// Sequence returns n evenly spread ranks in ascending order, used to
+// renumber a whole list.
+func Sequence(n int) []string {
+	result := make([]string, n)
+	for i := range result {
+		// fixed width keeps the ranks from being prefixes of each other
+		result[i] = fmt.Sprintf("%08x", i+1) + "V"
+	}
+
+	return result
+}
+
+func validate(s string) error {
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(digits, s[i]) < 0 {
+			return fmt.Errorf("rank: invalid character %q in %q", s[i], s)
+		}
+	}
+	if strings.HasSuffix(s, "0") {
+		return fmt.Errorf("rank: %q ends in 0", s)
+	}
+
+	return nil
+}
+
+func midpoint(a, b string) string {
+	if b != "" {
+		// skip the common prefix, a is padded with zeros
+		n := 0
+		for n < len(b) {
+			ca := byte('0')
+			if n < len(a) {
+				ca = a[n]
+			}
+			if ca != b[n] {
+				break
+			}
+			n++
+		}
+
+		if n > 0 {
+			rest := ""
+			if n < len(a) {
+				rest = a[n:]
+			}
+			return b[:n] + midpoint(rest, b[n:])
+		}
+	}
+
+	da := 0
+	if a != "" {
+		da = strings.IndexByte(digits, a[0])
+	}
+	db := len(digits)
+	if b != "" {
+		db = strings.IndexByte(digits, b[0])
+	}
+
+	if db-da > 1 {
+		return string(digits[(da+db)/2])
+	}
+
+	// the first digits are consecutive
+	if b != "" && len(b) > 1 {
+		return b[:1]
+	}
+
+	rest := ""
+	if a != "" {
+		rest = a[1:]
+	}
+	return string(digits[da]) + midpoint(rest, "")
+}
@@ -3,6 +3,7 @@ package request_header
 import (
 	"github.com/gin-gonic/gin"
 	"strings"
+	"time"
 )
 
 func GetTokenFromHeader(c *gin.Context) string {
@@ -18,3 +19,17 @@ func GetTokenFromHeader(c *gin.Context) string {
 
 	return parts[1]
 }
+
+// GetLocation returns the caller's time zone from the "tz" query parameter or
+// the X-Timezone header (IANA name, e.g. "Asia/Tashkent"), defaulting to UTC.
+func GetLocation(c *gin.Context) (*time.Location, error) {
+	name := c.Query("tz")
+	if name == "" {
+		name = c.GetHeader("X-Timezone")
+	}
+	if name == "" {
+		return time.UTC, nil
+	}
+
+	return time.LoadLocation(name)
+}
@@ -0,0 +1,219 @@
+package rrule
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	Daily   = "DAILY"
+	Weekly  = "WEEKLY"
+	Monthly = "MONTHLY"
+)
+
+const dateLayout = "20060102"
+
+// searchDays bounds how far Next looks ahead for a matching day.
+const searchDays = 5 * 366
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Rule is the subset of an RFC 5545 RRULE supported for recurring tasks:
+// FREQ (DAILY, WEEKLY, MONTHLY), INTERVAL, BYDAY (plain weekdays), UNTIL
+// and COUNT. Occurrences are whole days; weeks start on Monday.
+type Rule struct {
+	Freq     string
+	Interval int
+	ByDay    []time.Weekday
+	Until    *time.Time
+	Count    int
+}
+
+// Parse parses a rule such as "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;COUNT=10".
+// An optional "RRULE:" prefix is accepted.
+func Parse(s string) (Rule, error) {
+	rule := Rule{Interval: 1}
+
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	if s == "" {
+		return Rule{}, fmt.Errorf("empty rule")
+	}
+
+	for _, part := range strings.Split(s, ";") {
+		if part == "" {
+			continue
+		}
+
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return Rule{}, fmt.Errorf("invalid rule part %q", part)
+		}
+
+		switch strings.ToUpper(name) {
+		case "FREQ":
+			rule.Freq = strings.ToUpper(value)
+			if rule.Freq != Daily && rule.Freq != Weekly && rule.Freq != Monthly {
+				return Rule{}, fmt.Errorf("unsupported FREQ %q", value)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return Rule{}, fmt.Errorf("INTERVAL must be a positive integer")
+			}
+			rule.Interval = n
+		case "BYDAY":
+			for _, d := range strings.Split(value, ",") {
+				wd, ok := weekdays[strings.ToUpper(d)]
+				if !ok {
+					return Rule{}, fmt.Errorf("unsupported BYDAY value %q", d)
+				}
+				rule.ByDay = append(rule.ByDay, wd)
+			}
+		case "UNTIL":
+			// date-time values are cut to their date
+			if len(value) < len(dateLayout) {
+				return Rule{}, fmt.Errorf("invalid UNTIL %q", value)
+			}
+			until, err := time.Parse(dateLayout, value[:len(dateLayout)])
+			if err != nil {
+				return Rule{}, fmt.Errorf("invalid UNTIL %q", value)
+			}
+			rule.Until = &until
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return Rule{}, fmt.Errorf("COUNT must be a positive integer")
+			}
+			rule.Count = n
+		default:
+			return Rule{}, fmt.Errorf("unsupported rule part %q", name)
+		}
+	}
+
+	if rule.Freq == "" {
+		return Rule{}, fmt.Errorf("FREQ is required")
+	}
+	if rule.Until != nil && rule.Count > 0 {
+		return Rule{}, fmt.Errorf("UNTIL and COUNT must not both be set")
+	}
+
+	sort.Slice(rule.ByDay, func(i, j int) bool {
+		return weekdayIndex(rule.ByDay[i]) < weekdayIndex(rule.ByDay[j])
+	})
+
+	return rule, nil
+}
+
+// String formats the rule in its canonical form.
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, 0, len(r.ByDay))
+		for _, wd := range r.ByDay {
+			for name, d := range weekdays {
+				if d == wd {
+					days = append(days, name)
+				}
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.Format(dateLayout))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// Next returns the first occurrence of the rule started at dtstart that
+// falls strictly after the given day. COUNT is not applied here since it
+// depends on how many occurrences were already produced; the caller keeps
+// that tally.
+func (r Rule) Next(dtstart, after time.Time) (time.Time, bool) {
+	dtstart = day(dtstart)
+	d := day(after).AddDate(0, 0, 1)
+	if d.Before(dtstart) {
+		d = dtstart
+	}
+
+	for i := 0; i < searchDays; i++ {
+		if r.Until != nil && d.After(*r.Until) {
+			return time.Time{}, false
+		}
+		if r.matches(dtstart, d) {
+			return d, true
+		}
+		d = d.AddDate(0, 0, 1)
+	}
+
+	return time.Time{}, false
+}
+
+func (r Rule) matches(dtstart, d time.Time) bool {
+	switch r.Freq {
+	case Daily:
+		days := int(d.Sub(dtstart).Hours() / 24)
+		return days%r.Interval == 0 && r.onDay(d, dtstart.Weekday(), false)
+	case Weekly:
+		weeks := int(weekStart(d).Sub(weekStart(dtstart)).Hours() / 24 / 7)
+		return weeks%r.Interval == 0 && r.onDay(d, dtstart.Weekday(), true)
+	case Monthly:
+		months := (d.Year()-dtstart.Year())*12 + int(d.Month()) - int(dtstart.Month())
+		if months%r.Interval != 0 {
+			return false
+		}
+		if len(r.ByDay) == 0 {
+			// months without that day (e.g. the 31st) are skipped
+			return d.Day() == dtstart.Day()
+		}
+		return r.onDay(d, dtstart.Weekday(), false)
+	}
+
+	return false
+}
+
+// onDay reports whether d is on one of the BYDAY weekdays. Without BYDAY
+// every day matches, or only the start weekday when defaultToStart is set.
+func (r Rule) onDay(d time.Time, start time.Weekday, defaultToStart bool) bool {
+	if len(r.ByDay) == 0 {
+		return !defaultToStart || d.Weekday() == start
+	}
+
+	for _, wd := range r.ByDay {
+		if d.Weekday() == wd {
+			return true
+		}
+	}
+
+	return false
+}
+
+func day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func weekStart(t time.Time) time.Time {
+	return t.AddDate(0, 0, -weekdayIndex(t.Weekday()))
+}
+
+// weekdayIndex numbers weekdays from Monday (0) to Sunday (6).
+func weekdayIndex(wd time.Weekday) int {
+	return (int(wd) + 6) % 7
+}
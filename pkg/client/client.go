@@ -0,0 +1,254 @@
+// Package client is the Go client of the task management API. It has typed
+// methods for the users, projects, tasks and export endpoints:
+//
+//	c := client.New("http://localhost:8080")
+//	task, err := c.Tasks.Get(ctx, 42)
+//	if client.IsNotFound(err) {
+//		...
+//	}
+//
+// Requests are retried with exponential backoff on 5xx responses and
+// network errors, as long as the method is idempotent. Lists can be walked
+// page by page with the All methods.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BasePath is where the API is mounted on the server.
+const BasePath = "/api/v1"
+
+// MergePatchType is the content type of the Patch methods.
+const MergePatchType = "application/merge-patch+json"
+
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	header     http.Header
+
+	retries    int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+
+	Users    *Users
+	Projects *Projects
+	Tasks    *Tasks
+	Export   *Export
+}
+
+type Option func(*Client)
+
+// WithHTTPClient sets the client requests are sent with, http.DefaultClient
+// by default.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithRetries sets how often a failed request is retried and the backoff
+// between attempts, which doubles from min up to max. 0 disables retries.
+func WithRetries(retries int, min, max time.Duration) Option {
+	return func(c *Client) {
+		c.retries = retries
+		c.minBackoff = min
+		c.maxBackoff = max
+	}
+}
+
+// WithHeader adds a header to every request.
+func WithHeader(key, value string) Option {
+	return func(c *Client) {
+		c.header.Add(key, value)
+	}
+}
+
+// New returns a client of the API served at baseURL, such as
+// http://localhost:8080.
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/") + BasePath,
+		httpClient: http.DefaultClient,
+		header:     http.Header{},
+		retries:    3,
+		minBackoff: 100 * time.Millisecond,
+		maxBackoff: 2 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.Users = &Users{c}
+	c.Projects = &Projects{c}
+	c.Tasks = &Tasks{c}
+	c.Export = &Export{c}
+
+	return c
+}
+
+type request struct {
+	method      string
+	path        string
+	query       url.Values
+	header      http.Header
+	body        interface{}
+	contentType string
+	// version is sent as If-Match
+	version *int
+}
+
+// envelope is the body of most responses, {"data": ..., "count": ...}.
+type envelope[T any] struct {
+	Data  T   `json:"data"`
+	Count int `json:"count"`
+}
+
+// do sends req and decodes the response body into out, unless out is nil.
+func (c *Client) do(ctx context.Context, req request, out interface{}) error {
+	res, err := c.send(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if out == nil {
+		_, _ = io.Copy(io.Discard, res.Body)
+		return nil
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(out); err != nil {
+		return fmt.Errorf("client: decoding %s %s: %w", req.method, req.path, err)
+	}
+
+	return nil
+}
+
+// send sends req, retrying it when allowed. Responses other than 2xx are
+// returned as *Error. The caller closes the body of the response.
+func (c *Client) send(ctx context.Context, req request) (*http.Response, error) {
+	var body []byte
+	if req.body != nil {
+		var err error
+		if body, err = json.Marshal(req.body); err != nil {
+			return nil, fmt.Errorf("client: encoding %s %s: %w", req.method, req.path, err)
+		}
+	}
+
+	backoff := c.minBackoff
+	for attempt := 0; ; attempt++ {
+		res, err := c.attempt(ctx, req, body)
+		if err == nil && res.StatusCode < http.StatusInternalServerError {
+			if res.StatusCode >= http.StatusBadRequest {
+				defer res.Body.Close()
+				return nil, decodeError(res)
+			}
+			return res, nil
+		}
+
+		if ctx.Err() != nil || attempt >= c.retries || !retryable(req.method) {
+			if err != nil {
+				return nil, err
+			}
+			defer res.Body.Close()
+			return nil, decodeError(res)
+		}
+		if res != nil {
+			_, _ = io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+
+		// full jitter, so clients failing together don't retry together
+		wait := time.Duration(rand.Int63n(int64(backoff) + 1))
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+
+		backoff *= 2
+		if backoff > c.maxBackoff {
+			backoff = c.maxBackoff
+		}
+	}
+}
+
+func (c *Client) attempt(ctx context.Context, req request, body []byte) (*http.Response, error) {
+	u := c.baseURL + req.path
+	if len(req.query) > 0 {
+		u += "?" + req.query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, u, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, values := range c.header {
+		httpReq.Header[key] = values
+	}
+	for key, values := range req.header {
+		httpReq.Header[key] = values
+	}
+	if body != nil {
+		contentType := req.contentType
+		if contentType == "" {
+			contentType = "application/json"
+		}
+		httpReq.Header.Set("Content-Type", contentType)
+	}
+	if req.version != nil {
+		httpReq.Header.Set("If-Match", strconv.Quote(strconv.Itoa(*req.version)))
+	}
+
+	return c.httpClient.Do(httpReq)
+}
+
+// retryable reports whether a request may be sent again after a failure
+// without the risk of applying it twice.
+func retryable(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+func decodeError(res *http.Response) error {
+	e := &Error{StatusCode: res.StatusCode}
+
+	data, _ := io.ReadAll(res.Body)
+	if err := json.Unmarshal(data, e); err != nil || e.Message == "" {
+		e.Message = strings.TrimSpace(string(data))
+		if e.Message == "" {
+			e.Message = http.StatusText(res.StatusCode)
+		}
+	}
+
+	return e
+}
+
+func idPath(format string, ids ...int) string {
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	return fmt.Sprintf(format, args...)
+}
@@ -0,0 +1,361 @@
+package client_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"task-management2/internal/controller/http/v1/calendar"
+	"task-management2/internal/controller/http/v1/comments"
+	"task-management2/internal/controller/http/v1/export"
+	"task-management2/internal/controller/http/v1/graphql"
+	"task-management2/internal/controller/http/v1/notifications"
+	"task-management2/internal/controller/http/v1/projects"
+	"task-management2/internal/controller/http/v1/recurrences"
+	"task-management2/internal/controller/http/v1/sprints"
+	"task-management2/internal/controller/http/v1/stream"
+	tasks_controller "task-management2/internal/controller/http/v1/tasks"
+	"task-management2/internal/controller/http/v1/trash"
+	users_controller "task-management2/internal/controller/http/v1/users"
+	"task-management2/internal/controller/http/v1/webhooks"
+	"task-management2/internal/controller/http/v1/worklogs"
+	"task-management2/internal/entity"
+	"task-management2/internal/pkg/validation"
+	basic_repo "task-management2/internal/repository/postgres/_basic_repo"
+	"task-management2/internal/repository/postgres/tasks"
+	"task-management2/internal/repository/postgres/users"
+	"task-management2/internal/router"
+	"task-management2/internal/router/openapi"
+	"task-management2/pkg/client"
+)
+
+func TestMain(m *testing.M) {
+	gin.SetMode(gin.TestMode)
+	validation.Install()
+
+	os.Exit(m.Run())
+}
+
+// userRepo serves the users given, methods the tests don't need panic.
+type userRepo struct {
+	users_controller.Repository
+
+	mu      sync.Mutex
+	users   []users.List
+	filters []users.Filter
+	updates []users.Update
+}
+
+func (r *userRepo) GetAll(_ context.Context, filter users.Filter) ([]users.List, int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.filters = append(r.filters, filter)
+
+	list := r.users
+	if filter.Offset != nil {
+		list = list[min(*filter.Offset, len(list)):]
+	}
+	if filter.Limit != nil {
+		list = list[:min(*filter.Limit, len(list))]
+	}
+
+	return list, len(r.users), nil
+}
+
+func (r *userRepo) Create(_ context.Context, data users.Create) (entity.User, error) {
+	return entity.User{}, basic_repo.Conflict("a user with email %s already exists", *data.Email)
+}
+
+func (r *userRepo) Update(_ context.Context, data users.Update) (entity.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.updates = append(r.updates, data)
+
+	return entity.User{FullName: data.FullName, Version: 2}, nil
+}
+
+// taskRepo holds task 1 at version 2. The first failures reads of it fail
+// with an internal error.
+type taskRepo struct {
+	tasks_controller.Repository
+
+	mu       sync.Mutex
+	failures int
+	reads    int
+	creates  int
+	filters  []tasks.Filter
+}
+
+func (r *taskRepo) GetAll(_ context.Context, filter tasks.Filter) ([]entity.Tasks, int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.filters = append(r.filters, filter)
+
+	return nil, 0, nil
+}
+
+func (r *taskRepo) GetTaskStats(context.Context, tasks.Filter) (tasks.TaskStats, error) {
+	return tasks.TaskStats{}, nil
+}
+
+func (r *taskRepo) GetDetail(_ context.Context, filter tasks.DetailFilter) (entity.Tasks, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reads++
+
+	if r.reads <= r.failures {
+		return entity.Tasks{}, errors.New("connection reset by peer")
+	}
+	if filter.Id != 1 {
+		return entity.Tasks{}, sql.ErrNoRows
+	}
+
+	name := "first"
+	return entity.Tasks{Name: &name, Version: 2}, nil
+}
+
+func (r *taskRepo) Update(_ context.Context, data tasks.Update) (entity.Tasks, error) {
+	if data.Version != nil && *data.Version != 2 {
+		return entity.Tasks{}, basic_repo.ErrVersionMismatch
+	}
+
+	return entity.Tasks{Name: data.Name, Version: 3}, nil
+}
+
+func (r *taskRepo) Create(context.Context, tasks.Create) (entity.Tasks, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.creates++
+
+	return entity.Tasks{}, errors.New("connection reset by peer")
+}
+
+// newClient runs the router of the API over the given repositories and
+// returns a client of it, retrying without a noticeable backoff.
+func newClient(t *testing.T, userRepo users_controller.Repository, taskRepo tasks_controller.Repository) *client.Client {
+	t.Helper()
+
+	r := router.New(openapi.Spec(), router.Controllers{
+		Users:         users_controller.NewController(userRepo),
+		Tasks:         tasks_controller.NewController(taskRepo),
+		Projects:      projects.NewController(nil),
+		Export:        export.NewController(nil, nil, nil, nil),
+		Calendar:      calendar.NewController(nil, nil),
+		Worklogs:      worklogs.NewController(nil),
+		Recurrences:   recurrences.NewController(nil),
+		Notifications: notifications.NewController(nil),
+		Comments:      comments.NewController(nil),
+		Webhooks:      webhooks.NewController(nil),
+		Sprints:       sprints.NewController(nil),
+		Stream:        stream.NewController(nil, nil),
+		Trash:         trash.NewController(nil, time.Hour),
+		GraphQL:       graphql.NewController(nil, nil, nil),
+	})
+
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+
+	return client.New(server.URL, client.WithRetries(3, time.Millisecond, time.Millisecond))
+}
+
+func TestErrors(t *testing.T) {
+	c := newClient(t, &userRepo{}, &taskRepo{})
+	ctx := context.Background()
+
+	_, err := c.Tasks.Get(ctx, 2)
+	if !client.IsNotFound(err) {
+		t.Errorf("get of a missing task: want 404, got %v", err)
+	}
+
+	email := "ann@example.com"
+	_, err = c.Users.Create(ctx, client.CreateUser{
+		FullName: ptr("Ann"), Email: &email, Role: ptr("worker"), Password: ptr("secret"),
+	})
+	if !client.IsConflict(err) {
+		t.Errorf("create of a duplicate user: want 409, got %v", err)
+	}
+
+	_, err = c.Tasks.Update(ctx, 1, client.UpdateTask{Name: ptr("renamed"), Version: ptr(1)})
+	if !client.IsPreconditionFailed(err) {
+		t.Errorf("update of a stale version: want 412, got %v", err)
+	}
+
+	task, err := c.Tasks.Update(ctx, 1, client.UpdateTask{Name: ptr("renamed"), Version: ptr(2)})
+	if err != nil || task.Version != 3 {
+		t.Errorf("update of the current version: got %+v, %v", task, err)
+	}
+
+	_, err = c.Users.Create(ctx, client.CreateUser{Email: ptr("not an email")})
+	var e *client.Error
+	if !client.IsValidation(err) || !errors.As(err, &e) {
+		t.Fatalf("create of an invalid user: want 422, got %v", err)
+	}
+	fields := map[string]bool{}
+	for _, f := range e.Fields {
+		fields[f.Field] = true
+	}
+	for _, field := range []string{"full_name", "email", "role", "password"} {
+		if !fields[field] {
+			t.Errorf("create of an invalid user: %s missing from %+v", field, e.Fields)
+		}
+	}
+
+	// items of a bulk create are held to the rules of a single create
+	_, err = c.Tasks.BulkCreate(ctx, client.BulkCreate{Items: []client.CreateTask{
+		{ProjectId: ptr(1), Name: ptr("first"), Status: ptr("pending"), Priority: ptr("low"), DueDate: ptr("2026-01-31")},
+		{ProjectId: ptr(1), Name: ptr("second"), Status: ptr("done"), DueDate: ptr("31.01.2026")},
+	}})
+	if !client.IsValidation(err) || !errors.As(err, &e) {
+		t.Fatalf("bulk create of an invalid item: want 422, got %v", err)
+	}
+	fields = map[string]bool{}
+	for _, f := range e.Fields {
+		fields[f.Field] = true
+	}
+	for _, field := range []string{"items[1].status", "items[1].priority", "items[1].due_date"} {
+		if !fields[field] {
+			t.Errorf("bulk create of an invalid item: %s missing from %+v", field, e.Fields)
+		}
+	}
+}
+
+func TestRetry(t *testing.T) {
+	repo := &taskRepo{failures: 2}
+	c := newClient(t, &userRepo{}, repo)
+	ctx := context.Background()
+
+	task, err := c.Tasks.Get(ctx, 1)
+	if err != nil || task.Name == nil || *task.Name != "first" {
+		t.Fatalf("get after two 500s: got %+v, %v", task, err)
+	}
+	if repo.reads != 3 {
+		t.Errorf("get after two 500s: want 3 attempts, got %d", repo.reads)
+	}
+
+	// a create isn't idempotent, it is sent once
+	_, err = c.Tasks.Create(ctx, client.CreateTask{
+		ProjectId: ptr(1), Name: ptr("second"), Status: ptr("pending"), Priority: ptr("low"), DueDate: ptr("2026-01-31"),
+	})
+	var e *client.Error
+	if !errors.As(err, &e) || e.StatusCode != 500 {
+		t.Errorf("failing create: want 500, got %v", err)
+	}
+	if repo.creates != 1 {
+		t.Errorf("failing create: want 1 attempt, got %d", repo.creates)
+	}
+
+	repo.reads, repo.failures = 0, 10
+	if _, err := c.Tasks.Get(ctx, 1); err == nil {
+		t.Error("get failing every time: want an error")
+	}
+	if repo.reads != 4 {
+		t.Errorf("get failing every time: want 4 attempts, got %d", repo.reads)
+	}
+}
+
+func TestUsersListPage(t *testing.T) {
+	repo := &userRepo{users: make([]users.List, 25)}
+	c := newClient(t, repo, &taskRepo{})
+
+	list, count, err := c.Users.List(context.Background(), client.ListOptions{Limit: 10, Page: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 5 || count != 25 {
+		t.Errorf("third page of 10: got %d users of %d", len(list), count)
+	}
+
+	filter := repo.filters[0]
+	if filter.Limit == nil || *filter.Limit != 10 || filter.Offset == nil || *filter.Offset != 20 {
+		t.Errorf("third page of 10: want limit 10 offset 20, got %v %v", filter.Limit, filter.Offset)
+	}
+}
+
+func TestTasksListFilters(t *testing.T) {
+	repo := &taskRepo{}
+	c := newClient(t, &userRepo{}, repo)
+
+	_, err := c.Tasks.List(context.Background(), client.TaskListOptions{Status: "in_progress", AssignedTo: ptr(3)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filter := repo.filters[0]
+	if filter.Status == nil || *filter.Status != "in_progress" || filter.AssignedTo == nil || *filter.AssignedTo != 3 {
+		t.Errorf("want status in_progress assigned to 3, got %v %v", filter.Status, filter.AssignedTo)
+	}
+
+	_, err = c.Tasks.List(context.Background(), client.TaskListOptions{Status: "done"})
+	var e *client.Error
+	if !errors.As(err, &e) || e.StatusCode != 400 {
+		t.Errorf("unknown status: want 400, got %v", err)
+	}
+}
+
+func TestUsersUpdate(t *testing.T) {
+	repo := &userRepo{}
+	c := newClient(t, repo, &taskRepo{})
+
+	user, err := c.Users.Update(context.Background(), 7, client.UpdateUser{FullName: ptr("Ann")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id := repo.updates[0].Id; id == nil || *id != 7 {
+		t.Errorf("want user 7 updated, got %v", id)
+	}
+	if user.Version != 2 {
+		t.Errorf("want version 2 in the response, got %d", user.Version)
+	}
+}
+
+func TestAll(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		users       int
+		pageSize    int
+		wantFetches int
+	}{
+		{"partial last page", 5, 2, 3},
+		{"full last page", 4, 2, 2},
+		{"empty", 0, 2, 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := &userRepo{users: make([]users.List, tc.users)}
+			for i := range repo.users {
+				repo.users[i].Id = ptr(int64(i + 1))
+			}
+			c := newClient(t, repo, &taskRepo{})
+
+			var ids []int64
+			for user, err := range c.Users.All(context.Background(), tc.pageSize) {
+				if err != nil {
+					t.Fatal(err)
+				}
+				ids = append(ids, *user.Id)
+			}
+
+			if len(ids) != tc.users {
+				t.Errorf("want %d users, got %v", tc.users, ids)
+			}
+			for i, id := range ids {
+				if id != int64(i+1) {
+					t.Errorf("want the users in order, got %v", ids)
+					break
+				}
+			}
+			if len(repo.filters) != tc.wantFetches {
+				t.Errorf("want %d pages fetched, got %d", tc.wantFetches, len(repo.filters))
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Error is an error response of the API.
+type Error struct {
+	StatusCode int `json:"-"`
+	// Code is the machine readable kind of the error, such as not_found or
+	// validation_failed.
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Fields  []FieldError `json:"fields,omitempty"`
+}
+
+// FieldError is a request field that failed validation.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	if len(e.Fields) == 0 {
+		return fmt.Sprintf("%d %s", e.StatusCode, e.Message)
+	}
+
+	msg := fmt.Sprintf("%d %s:", e.StatusCode, e.Message)
+	for _, f := range e.Fields {
+		msg += fmt.Sprintf(" %s %s;", f.Field, f.Message)
+	}
+
+	return msg[:len(msg)-1]
+}
+
+func hasStatus(err error, status int) bool {
+	var e *Error
+	return errors.As(err, &e) && e.StatusCode == status
+}
+
+// IsNotFound reports whether the record doesn't exist.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict reports whether the request conflicts with another record,
+// such as a duplicate email.
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+// IsValidation reports whether the request failed validation, Error.Fields
+// lists the offending fields.
+func IsValidation(err error) bool {
+	return hasStatus(err, http.StatusUnprocessableEntity)
+}
+
+// IsPreconditionFailed reports whether the record was changed since the
+// version the request was made for.
+func IsPreconditionFailed(err error) bool {
+	return hasStatus(err, http.StatusPreconditionFailed)
+}
@@ -0,0 +1,24 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+// Export is the /export endpoints.
+type Export struct {
+	c *Client
+}
+
+// Excel writes the spreadsheet of users, projects, tasks and worklogs to w.
+func (s *Export) Excel(ctx context.Context, w io.Writer) error {
+	res, err := s.c.send(ctx, request{method: http.MethodGet, path: "/export/excel"})
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	_, err = io.Copy(w, res.Body)
+	return err
+}
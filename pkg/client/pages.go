@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/url"
+	"strconv"
+)
+
+// DefaultPageSize is the page size of the All methods when none is given.
+const DefaultPageSize = 50
+
+// ListOptions selects a page of a list. Page starts at 1, zero values leave
+// the server defaults.
+type ListOptions struct {
+	Limit int
+	Page  int
+}
+
+func (o ListOptions) values(q url.Values) url.Values {
+	if q == nil {
+		q = url.Values{}
+	}
+	if o.Limit > 0 {
+		q.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Page > 0 {
+		if o.Limit <= 0 {
+			// the server needs a limit to find the page
+			q.Set("limit", strconv.Itoa(DefaultPageSize))
+		}
+		q.Set("offset", strconv.Itoa(o.Page))
+	}
+
+	return q
+}
+
+// pages walks a list page by page, fetch returns a page and the size of the
+// whole list. It stops at the first error, which is yielded last.
+func pages[T any](ctx context.Context, pageSize int, fetch func(ctx context.Context, page ListOptions) ([]T, int, error)) iter.Seq2[T, error] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	return func(yield func(T, error) bool) {
+		seen := 0
+		for page := 1; ; page++ {
+			list, count, err := fetch(ctx, ListOptions{Limit: pageSize, Page: page})
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range list {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			seen += len(list)
+			if len(list) < pageSize || seen >= count {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"strconv"
+)
+
+// Projects is the /projects endpoints.
+type Projects struct {
+	c *Client
+}
+
+type ProjectListOptions struct {
+	ListOptions
+	OwnerId *int
+	// Weighting is what progress is measured in: count, points or
+	// estimate.
+	Weighting string
+}
+
+// List returns a page of projects with their task stats and the number of
+// projects.
+func (s *Projects) List(ctx context.Context, opts ProjectListOptions) ([]ProjectListItem, int, error) {
+	q := opts.values(nil)
+	if opts.OwnerId != nil {
+		q.Set("owner_id", strconv.Itoa(*opts.OwnerId))
+	}
+	if opts.Weighting != "" {
+		q.Set("weighting", opts.Weighting)
+	}
+
+	var out envelope[struct {
+		Results []ProjectListItem `json:"results"`
+		Count   int               `json:"count"`
+	}]
+	err := s.c.do(ctx, request{method: http.MethodGet, path: "/projects/list", query: q}, &out)
+
+	return out.Data.Results, out.Data.Count, err
+}
+
+// All walks every project matching opts, pageSize at a time.
+func (s *Projects) All(ctx context.Context, opts ProjectListOptions, pageSize int) iter.Seq2[ProjectListItem, error] {
+	return pages(ctx, pageSize, func(ctx context.Context, page ListOptions) ([]ProjectListItem, int, error) {
+		opts.ListOptions = page
+		return s.List(ctx, opts)
+	})
+}
+
+func (s *Projects) Get(ctx context.Context, id int) (ProjectDetail, error) {
+	var out envelope[ProjectDetail]
+	err := s.c.do(ctx, request{method: http.MethodGet, path: idPath("/projects/%d", id)}, &out)
+
+	return out.Data, err
+}
+
+func (s *Projects) Create(ctx context.Context, data CreateProject) (Project, error) {
+	var out envelope[Project]
+	err := s.c.do(ctx, request{method: http.MethodPost, path: "/projects/create", body: data}, &out)
+
+	return out.Data, err
+}
+
+// Update changes the project. When data.Version is set the update fails
+// with 412 if the project was changed since, see IsPreconditionFailed.
+func (s *Projects) Update(ctx context.Context, id int, data UpdateProject) (Project, error) {
+	var out envelope[Project]
+	err := s.c.do(ctx, request{method: http.MethodPut, path: idPath("/projects/%d", id), body: data, version: data.Version}, &out)
+
+	return out.Data, err
+}
+
+// Patch applies a JSON merge patch (RFC 7396), members set to nil are
+// cleared. patch is usually a map or a ProjectPatchable.
+func (s *Projects) Patch(ctx context.Context, id int, patch interface{}, version *int) (Project, error) {
+	var out envelope[Project]
+	err := s.c.do(ctx, request{
+		method:      http.MethodPatch,
+		path:        idPath("/projects/%d", id),
+		body:        patch,
+		contentType: MergePatchType,
+		version:     version,
+	}, &out)
+
+	return out.Data, err
+}
+
+// Delete moves the project and its tasks to the trash.
+func (s *Projects) Delete(ctx context.Context, id int, version *int) error {
+	return s.c.do(ctx, request{method: http.MethodDelete, path: idPath("/projects/%d", id), version: version}, nil)
+}
+
+// Restore takes the project and the tasks deleted with it out of the trash.
+func (s *Projects) Restore(ctx context.Context, id int) (Project, error) {
+	var out envelope[Project]
+	err := s.c.do(ctx, request{method: http.MethodPost, path: idPath("/projects/%d/restore", id)}, &out)
+
+	return out.Data, err
+}
+
+func (s *Projects) Board(ctx context.Context, id int) (Board, error) {
+	var out envelope[Board]
+	err := s.c.do(ctx, request{method: http.MethodGet, path: idPath("/projects/%d/board", id)}, &out)
+
+	return out.Data, err
+}
+
+// SetWipLimits sets the work in progress limit of board columns by status,
+// 0 removes the limit. It returns the limits of all columns.
+func (s *Projects) SetWipLimits(ctx context.Context, id int, limits map[string]int) (map[string]int, error) {
+	var out envelope[map[string]int]
+	err := s.c.do(ctx, request{
+		method: http.MethodPut,
+		path:   idPath("/projects/%d/board/wip-limits", id),
+		body:   map[string]interface{}{"limits": limits},
+	}, &out)
+
+	return out.Data, err
+}
+
+func (s *Projects) Timeline(ctx context.Context, id int) (Timeline, error) {
+	var out envelope[Timeline]
+	err := s.c.do(ctx, request{method: http.MethodGet, path: idPath("/projects/%d/timeline", id)}, &out)
+
+	return out.Data, err
+}
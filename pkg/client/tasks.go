@@ -0,0 +1,180 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Tasks is the /task endpoints.
+type Tasks struct {
+	c *Client
+}
+
+type TaskListOptions struct {
+	ListOptions
+	ProjectId   *int
+	SprintId    *int
+	MilestoneId *int
+	AssignedTo  *int
+	// Status is pending, in_progress or completed, any by default.
+	Status string
+	// Weighting is what the stats are measured in: count, points or
+	// estimate.
+	Weighting string
+}
+
+// TaskList is a page of tasks with the stats of every task matching the
+// filter.
+type TaskList struct {
+	Tasks []Task    `json:"data"`
+	Count int       `json:"count"`
+	Stats TaskStats `json:"task_stats"`
+}
+
+func (s *Tasks) List(ctx context.Context, opts TaskListOptions) (TaskList, error) {
+	q := opts.values(nil)
+	for name, id := range map[string]*int{
+		"project_id":   opts.ProjectId,
+		"sprint_id":    opts.SprintId,
+		"milestone_id": opts.MilestoneId,
+		"assigned_to":  opts.AssignedTo,
+	} {
+		if id != nil {
+			q.Set(name, strconv.Itoa(*id))
+		}
+	}
+	if opts.Status != "" {
+		q.Set("status", opts.Status)
+	}
+	if opts.Weighting != "" {
+		q.Set("weighting", opts.Weighting)
+	}
+
+	var out TaskList
+	err := s.c.do(ctx, request{method: http.MethodGet, path: "/task/list", query: q}, &out)
+
+	return out, err
+}
+
+// All walks every task matching opts, pageSize at a time.
+func (s *Tasks) All(ctx context.Context, opts TaskListOptions, pageSize int) iter.Seq2[Task, error] {
+	return pages(ctx, pageSize, func(ctx context.Context, page ListOptions) ([]Task, int, error) {
+		opts.ListOptions = page
+		list, err := s.List(ctx, opts)
+		return list.Tasks, list.Count, err
+	})
+}
+
+type CalendarOptions struct {
+	// From and To are dates (YYYY-MM-DD) or RFC 3339 times, the current
+	// month by default.
+	From string
+	To   string
+
+	ProjectId  *int
+	AssignedTo *int
+	// TimeZone is the IANA time zone days are taken in, UTC by default.
+	TimeZone string
+}
+
+// Calendar returns the tasks due in a range of days.
+func (s *Tasks) Calendar(ctx context.Context, opts CalendarOptions) (Calendar, error) {
+	q := url.Values{}
+	for name, value := range map[string]string{"from": opts.From, "to": opts.To, "tz": opts.TimeZone} {
+		if value != "" {
+			q[name] = []string{value}
+		}
+	}
+	if opts.ProjectId != nil {
+		q["project_id"] = []string{strconv.Itoa(*opts.ProjectId)}
+	}
+	if opts.AssignedTo != nil {
+		q["assigned_to"] = []string{strconv.Itoa(*opts.AssignedTo)}
+	}
+
+	var out envelope[Calendar]
+	err := s.c.do(ctx, request{method: http.MethodGet, path: "/task/calendar", query: q}, &out)
+
+	return out.Data, err
+}
+
+func (s *Tasks) Get(ctx context.Context, id int) (Task, error) {
+	var out envelope[Task]
+	err := s.c.do(ctx, request{method: http.MethodGet, path: idPath("/task/%d", id)}, &out)
+
+	return out.Data, err
+}
+
+func (s *Tasks) Create(ctx context.Context, data CreateTask) (Task, error) {
+	var out envelope[Task]
+	err := s.c.do(ctx, request{method: http.MethodPost, path: "/task/create", body: data}, &out)
+
+	return out.Data, err
+}
+
+// Update changes the task. When data.Version is set the update fails with
+// 412 if the task was changed since, see IsPreconditionFailed.
+func (s *Tasks) Update(ctx context.Context, id int, data UpdateTask) (Task, error) {
+	var out envelope[Task]
+	err := s.c.do(ctx, request{method: http.MethodPut, path: idPath("/task/%d", id), body: data, version: data.Version}, &out)
+
+	return out.Data, err
+}
+
+// Patch applies a JSON merge patch (RFC 7396), members set to nil are
+// cleared. patch is usually a map or a TaskPatchable.
+func (s *Tasks) Patch(ctx context.Context, id int, patch interface{}, version *int) (Task, error) {
+	var out envelope[Task]
+	err := s.c.do(ctx, request{
+		method:      http.MethodPatch,
+		path:        idPath("/task/%d", id),
+		body:        patch,
+		contentType: MergePatchType,
+		version:     version,
+	}, &out)
+
+	return out.Data, err
+}
+
+// Delete moves the task to the trash.
+func (s *Tasks) Delete(ctx context.Context, id int, version *int) error {
+	return s.c.do(ctx, request{method: http.MethodDelete, path: idPath("/task/%d", id), version: version}, nil)
+}
+
+// Move sets the status of the task and places it between two tasks of that
+// board column.
+func (s *Tasks) Move(ctx context.Context, id int, data MoveTask) (Task, error) {
+	var out envelope[Task]
+	err := s.c.do(ctx, request{method: http.MethodPost, path: idPath("/task/%d/move", id), body: data}, &out)
+
+	return out.Data, err
+}
+
+// Restore takes the task out of the trash.
+func (s *Tasks) Restore(ctx context.Context, id int) (Task, error) {
+	var out envelope[Task]
+	err := s.c.do(ctx, request{method: http.MethodPost, path: idPath("/task/%d/restore", id)}, &out)
+
+	return out.Data, err
+}
+
+// BulkUpdate patches or deletes several tasks at once. Either all of them
+// change or none, the *Error then lists the failed tasks as ids[i].
+func (s *Tasks) BulkUpdate(ctx context.Context, data BulkUpdate) ([]Task, error) {
+	var out envelope[[]Task]
+	err := s.c.do(ctx, request{method: http.MethodPost, path: "/task/bulk", body: data}, &out)
+
+	return out.Data, err
+}
+
+// BulkCreate creates several tasks at once. Either all of them are created
+// or none, the *Error then lists the failed tasks as items[i].
+func (s *Tasks) BulkCreate(ctx context.Context, data BulkCreate) ([]Task, error) {
+	var out envelope[[]Task]
+	err := s.c.do(ctx, request{method: http.MethodPost, path: "/task/bulk/create", body: data}, &out)
+
+	return out.Data, err
+}
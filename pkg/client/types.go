@@ -0,0 +1,39 @@
+package client
+
+import (
+	"task-management2/internal/entity"
+	"task-management2/internal/repository/postgres/projects"
+	"task-management2/internal/repository/postgres/tasks"
+	"task-management2/internal/repository/postgres/users"
+)
+
+// The request and response types are the ones of the server, so the client
+// can't drift from it.
+type (
+	User          = entity.User
+	UserListItem  = users.List
+	UserDetail    = users.Detail
+	CreateUser    = users.Create
+	UpdateUser    = users.Update
+	UserPatchable = users.Patchable
+
+	Project          = entity.Projects
+	ProjectListItem  = projects.List
+	ProjectDetail    = projects.Detail
+	CreateProject    = projects.Create
+	UpdateProject    = projects.Update
+	ProjectPatchable = projects.Patchable
+	Board            = projects.Board
+	Timeline         = projects.Timeline
+
+	Task          = entity.Tasks
+	TaskStats     = tasks.TaskStats
+	Calendar      = tasks.Calendar
+	CreateTask    = tasks.Create
+	UpdateTask    = tasks.Update
+	TaskPatchable = tasks.Patchable
+	MoveTask      = tasks.Move
+	BulkUpdate    = tasks.Bulk
+	BulkPatch     = tasks.BulkPatch
+	BulkCreate    = tasks.BulkCreate
+)
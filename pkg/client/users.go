@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Users is the /user endpoints.
+type Users struct {
+	c *Client
+}
+
+// List returns a page of users and the number of users.
+func (s *Users) List(ctx context.Context, opts ListOptions) ([]UserListItem, int, error) {
+	// unlike the other lists, offset counts users and not pages
+	q := url.Values{}
+	if opts.Limit > 0 || opts.Page > 0 {
+		if opts.Limit <= 0 {
+			opts.Limit = DefaultPageSize
+		}
+		q.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.Page > 1 {
+		q.Set("offset", strconv.Itoa((opts.Page-1)*opts.Limit))
+	}
+
+	var out envelope[[]UserListItem]
+	err := s.c.do(ctx, request{method: http.MethodGet, path: "/user/list", query: q}, &out)
+
+	return out.Data, out.Count, err
+}
+
+// All walks every user, pageSize at a time.
+func (s *Users) All(ctx context.Context, pageSize int) iter.Seq2[UserListItem, error] {
+	return pages(ctx, pageSize, s.List)
+}
+
+func (s *Users) Get(ctx context.Context, id int) (UserDetail, error) {
+	var out envelope[UserDetail]
+	err := s.c.do(ctx, request{method: http.MethodGet, path: idPath("/user/%d", id)}, &out)
+
+	return out.Data, err
+}
+
+func (s *Users) Create(ctx context.Context, data CreateUser) (User, error) {
+	var out envelope[User]
+	err := s.c.do(ctx, request{method: http.MethodPost, path: "/user/create", body: data}, &out)
+
+	return out.Data, err
+}
+
+// Update changes the user. When data.Version is set the update fails with
+// 412 if the user was changed since, see IsPreconditionFailed.
+func (s *Users) Update(ctx context.Context, id int, data UpdateUser) (User, error) {
+	data.Id = &id
+
+	var out envelope[User]
+	err := s.c.do(ctx, request{method: http.MethodPut, path: idPath("/user/%d", id), body: data, version: data.Version}, &out)
+
+	return out.Data, err
+}
+
+// Patch applies a JSON merge patch (RFC 7396), members set to nil are
+// cleared. patch is usually a map or a UserPatchable.
+func (s *Users) Patch(ctx context.Context, id int, patch interface{}, version *int) (UserDetail, error) {
+	var out envelope[UserDetail]
+	err := s.c.do(ctx, request{
+		method:      http.MethodPatch,
+		path:        idPath("/user/%d", id),
+		body:        patch,
+		contentType: MergePatchType,
+		version:     version,
+	}, &out)
+
+	return out.Data, err
+}
+
+// Delete moves the user to the trash.
+func (s *Users) Delete(ctx context.Context, id int, version *int) error {
+	return s.c.do(ctx, request{method: http.MethodDelete, path: idPath("/user/%d", id), version: version}, nil)
+}
+
+// Restore takes the user out of the trash.
+func (s *Users) Restore(ctx context.Context, id int) (UserDetail, error) {
+	var out envelope[UserDetail]
+	err := s.c.do(ctx, request{method: http.MethodPost, path: idPath("/user/%d/restore", id)}, &out)
+
+	return out.Data, err
+}